@@ -0,0 +1,578 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io"
+	"io/ioutil"
+	"math"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/niquefa/gominirandgen/randgen"
+)
+
+// Alphabet of the generated strings whose tag has no alphabet option
+const genAlphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+// Bounds of the values of the integer types, the unsigned ones stop at the largest int64
+var genIntegerBounds = map[string][2]int64{
+	"int": {math.MinInt64, math.MaxInt64}, "int8": {math.MinInt8, math.MaxInt8},
+	"int16": {math.MinInt16, math.MaxInt16}, "int32": {math.MinInt32, math.MaxInt32}, "rune": {math.MinInt32, math.MaxInt32},
+	"int64": {math.MinInt64, math.MaxInt64}, "uint": {0, math.MaxInt64}, "uint8": {0, math.MaxUint8},
+	"byte": {0, math.MaxUint8}, "uint16": {0, math.MaxUint16}, "uint32": {0, math.MaxUint32},
+	"uint64": {0, math.MaxInt64}, "uintptr": {0, math.MaxInt64},
+}
+
+// Levels of a recursive type filled by the generated functions, deeper values keep empty the fields that
+// contain the type
+const genMaxDepth = 5
+
+// Generators of the string kinds of the randgen tag, like randgen:"email"
+var genStringKinds = map[string]string{
+	"email": "g.Email()", "phone": "g.PhoneNumber()", "uuid": "g.UUIDv4()", "uuidv7": "g.UUIDv7()",
+	"ulid": "g.ULID()", "ksuid": "g.KSUID()",
+}
+
+// Options of the randgen tag
+var genTagOptions = map[string]bool{
+	"min": true, "max": true, "minlen": true, "maxlen": true, "alphabet": true, "oneof": true, "nil": true,
+}
+
+// The randgen tag of a field, like randgen:"email" or randgen:"min=1,max=99,nil=0.2"
+type genTag struct {
+	skip    bool
+	kind    string
+	options map[string]string
+}
+
+// Returns the randgen tag of the field
+func parseGenTag(field *ast.Field) (genTag, error) {
+	tag := genTag{options: make(map[string]string)}
+	if field.Tag == nil {
+		return tag, nil
+	}
+	literal, err := strconv.Unquote(field.Tag.Value)
+	if err != nil {
+		return tag, err
+	}
+	text := reflect.StructTag(literal).Get("randgen")
+	if text == "-" {
+		tag.skip = true
+		return tag, nil
+	}
+	for _, item := range strings.Split(text, ",") {
+		if item = strings.TrimSpace(item); item == "" {
+			continue
+		}
+		parts := strings.SplitN(item, "=", 2)
+		switch {
+		case len(parts) == 2 && genTagOptions[parts[0]]:
+			tag.options[parts[0]] = parts[1]
+		case len(parts) == 1 && genStringKinds[item] != "" && tag.kind == "":
+			tag.kind = item
+		default:
+			return tag, fmt.Errorf("error, unknown option %q in the randgen tag %q", item, text)
+		}
+	}
+	return tag, nil
+}
+
+// Returns the option of the tag as an int64, or the default value if the tag does not have it
+func (t genTag) integer(name string, defaultValue int64) (int64, error) {
+	text, ok := t.options[name]
+	if !ok {
+		return defaultValue, nil
+	}
+	value, err := strconv.ParseInt(text, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("error, the option %s=%s of the randgen tag is not an integer", name, text)
+	}
+	return value, nil
+}
+
+// Returns the option of the tag as a float64, or the default value if the tag does not have it
+func (t genTag) float(name string, defaultValue float64) (float64, error) {
+	text, ok := t.options[name]
+	if !ok {
+		return defaultValue, nil
+	}
+	value, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return 0, fmt.Errorf("error, the option %s=%s of the randgen tag is not a number", name, text)
+	}
+	return value, nil
+}
+
+// Writes the Random<Type> functions of the struct types of a Go source file
+type codeGenerator struct {
+	types     map[string]*ast.TypeSpec // type declarations of the file by name
+	recursive map[string]bool          // struct types that contain themselves, directly or through others
+	timeName  string                   // name of the time package in the file, empty if it is not imported
+	usesTime  bool
+	nested    bool // filling a field that contains its struct, the recursive calls go one level deeper
+	body      bytes.Buffer
+	variables int // suffix of the loop variables, so nested loops do not shadow each other
+}
+
+// Writes a line of the generated code, gofmt indents it later
+func (c *codeGenerator) line(format string, args ...interface{}) {
+	fmt.Fprintf(&c.body, format+"\n", args...)
+}
+
+// Returns the name of the generated function of the type: RandomUser for User and randomUser for user
+func genFunctionName(typeName string) string {
+	if unicode.IsUpper([]rune(typeName)[0]) {
+		return "Random" + typeName
+	}
+	return "random" + strings.ToUpper(typeName[:1]) + typeName[1:]
+}
+
+// Returns the name of the generated function of a recursive type that takes the nesting depth, like
+// randomNodeAtDepth for Node
+func genDepthFunctionName(typeName string) string {
+	return "random" + strings.ToUpper(typeName[:1]) + typeName[1:] + "AtDepth"
+}
+
+// Returns true if the type refers to the named type, directly or through the types of the file
+func (c *codeGenerator) reaches(expr ast.Expr, name string, visited map[string]bool) bool {
+	switch typed := expr.(type) {
+	case *ast.Ident:
+		if typed.Name == name {
+			return true
+		}
+		spec, ok := c.types[typed.Name]
+		if !ok || visited[typed.Name] {
+			return false
+		}
+		visited[typed.Name] = true
+		return c.reaches(spec.Type, name, visited)
+	case *ast.StarExpr:
+		return c.reaches(typed.X, name, visited)
+	case *ast.ArrayType:
+		return c.reaches(typed.Elt, name, visited)
+	case *ast.MapType:
+		return c.reaches(typed.Key, name, visited) || c.reaches(typed.Value, name, visited)
+	case *ast.StructType:
+		for _, field := range typed.Fields.List {
+			if c.reaches(field.Type, name, visited) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Writes the function that returns a random value of the struct type
+func (c *codeGenerator) structFunction(spec *ast.TypeSpec, structType *ast.StructType) error {
+	name := spec.Name.Name
+	c.line("// Returns a pseudo random %s drawn from the generator", name)
+	c.line("func %s(g *randgen.Generator) %s {", genFunctionName(name), name)
+	if c.recursive[name] {
+		c.line("return %s(g, 0)", genDepthFunctionName(name))
+		c.line("}")
+		c.line("")
+		c.line("// Returns a pseudo random %s nested depth levels inside another one, the fields that contain it", name)
+		c.line("// stay empty from %d levels on", genMaxDepth)
+		c.line("func %s(g *randgen.Generator, depth int) %s {", genDepthFunctionName(name), name)
+	}
+	c.line("var value %s", name)
+	for _, field := range structType.Fields.List {
+		tag, err := parseGenTag(field)
+		if err != nil {
+			return fmt.Errorf("%v, in the type %s", err, name)
+		}
+		if tag.skip {
+			continue
+		}
+		names := make([]string, 0, len(field.Names))
+		for _, fieldName := range field.Names {
+			names = append(names, fieldName.Name)
+		}
+		if len(field.Names) == 0 {
+			// embedded field, named after its type
+			embedded := field.Type
+			if star, ok := embedded.(*ast.StarExpr); ok {
+				embedded = star.X
+			}
+			if selector, ok := embedded.(*ast.SelectorExpr); ok {
+				embedded = selector.Sel
+			}
+			names = append(names, embedded.(*ast.Ident).Name)
+		}
+		_, bounded := tag.options["nil"]
+		if _, ok := tag.options["maxlen"]; ok {
+			bounded = true
+		}
+		c.nested = c.reaches(field.Type, name, make(map[string]bool))
+		if c.nested && !bounded {
+			// the type contains itself, it stays empty unless the tag bounds its size
+			continue
+		}
+		if c.nested {
+			c.line("if depth < %d {", genMaxDepth)
+		}
+		for _, fieldName := range names {
+			if fieldName == "_" {
+				continue
+			}
+			if err := c.fill("value."+fieldName, field.Type, types.ExprString(field.Type), tag, fieldName); err != nil {
+				return fmt.Errorf("%v, in the field %s of %s", err, fieldName, name)
+			}
+		}
+		if c.nested {
+			c.line("}")
+		}
+	}
+	c.nested = false
+	c.line("return value")
+	c.line("}")
+	c.line("")
+	return nil
+}
+
+// Returns the bounds of the length of the slices and maps of the tag, 0 to 5 by default
+func (t genTag) lengths() (int64, int64, error) {
+	minLength, err := t.integer("minlen", 0)
+	if err != nil {
+		return 0, 0, err
+	}
+	maxLength, err := t.integer("maxlen", 5)
+	if err != nil {
+		return 0, 0, err
+	}
+	if minLength < 0 || maxLength < minLength {
+		return 0, 0, fmt.Errorf("error, invalid lengths minlen=%d and maxlen=%d", minLength, maxLength)
+	}
+	return minLength, maxLength, nil
+}
+
+// Writes the statements that assign a random value of the type to the target. typeName is the type as
+// written in the source, like Status for a type Status string.
+func (c *codeGenerator) fill(target string, expr ast.Expr, typeName string, tag genTag, fieldName string) error {
+	switch typed := expr.(type) {
+	case *ast.Ident:
+		spec, ok := c.types[typed.Name]
+		if !ok {
+			value, err := c.basic(typed.Name, typeName, tag, fieldName)
+			if err != nil {
+				return err
+			}
+			c.line("%s = %s", target, value)
+			return nil
+		}
+		if _, ok := spec.Type.(*ast.StructType); ok {
+			if c.nested && c.recursive[typed.Name] {
+				c.line("%s = %s(g, depth+1)", target, genDepthFunctionName(typed.Name))
+				return nil
+			}
+			c.line("%s = %s(g)", target, genFunctionName(typed.Name))
+			return nil
+		}
+		return c.fill(target, spec.Type, typed.Name, tag, fieldName)
+	case *ast.SelectorExpr:
+		if packageName, ok := typed.X.(*ast.Ident); !ok || packageName.Name != c.timeName || c.timeName == "" {
+			return fmt.Errorf("error, unsupported type %s", typeName)
+		}
+		c.usesTime = true
+		switch typed.Sel.Name {
+		case "Time":
+			value, err := genTime(c.timeName, tag)
+			if err != nil {
+				return err
+			}
+			c.line("%s = %s", target, value)
+			return nil
+		case "Duration":
+			value, err := c.basic("int64", typeName, tag, fieldName)
+			if err != nil {
+				return err
+			}
+			c.line("%s = %s", target, value)
+			return nil
+		}
+		return fmt.Errorf("error, unsupported type %s", typeName)
+	case *ast.StarExpr:
+		probability, err := tag.float("nil", 0)
+		if err != nil {
+			return err
+		}
+		if probability < 0 || probability > 1 {
+			return fmt.Errorf("error, the probability nil=%v is not between 0 and 1", probability)
+		}
+		if probability > 0 {
+			c.line("if !g.Bernoulli(%v) {", probability)
+		}
+		c.line("%s = new(%s)", target, types.ExprString(typed.X))
+		if err := c.fill("*"+target, typed.X, types.ExprString(typed.X), tag, fieldName); err != nil {
+			return err
+		}
+		if probability > 0 {
+			c.line("}")
+		}
+		return nil
+	case *ast.ArrayType:
+		c.variables++
+		index := fmt.Sprintf("i%d", c.variables)
+		if typed.Len == nil {
+			minLength, maxLength, err := tag.lengths()
+			if err != nil {
+				return err
+			}
+			if element, ok := typed.Elt.(*ast.Ident); ok && (element.Name == "byte" || element.Name == "uint8") &&
+				tag.options["oneof"] == "" && tag.options["min"] == "" && tag.options["max"] == "" {
+				value := fmt.Sprintf("g.Bytes(g.Int(%d, %d))", minLength, maxLength)
+				if typeName != "[]byte" && typeName != "[]uint8" {
+					value = typeName + "(" + value + ")"
+				}
+				c.line("%s = %s", target, value)
+				return nil
+			}
+			c.line("%s = make(%s, g.Int(%d, %d))", target, typeName, minLength, maxLength)
+		}
+		c.line("for %s := range %s {", index, target)
+		if err := c.fill(target+"["+index+"]", typed.Elt, types.ExprString(typed.Elt), tag, fieldName); err != nil {
+			return err
+		}
+		c.line("}")
+		return nil
+	case *ast.MapType:
+		minLength, maxLength, err := tag.lengths()
+		if err != nil {
+			return err
+		}
+		c.variables++
+		index, key, value := fmt.Sprintf("i%d", c.variables), fmt.Sprintf("k%d", c.variables), fmt.Sprintf("v%d", c.variables)
+		c.line("%s = make(%s)", target, typeName)
+		c.line("for %s := g.Int(%d, %d); %s > 0; %s-- {", index, minLength, maxLength, index, index)
+		c.line("var %s %s", key, types.ExprString(typed.Key))
+		if err := c.fill(key, typed.Key, types.ExprString(typed.Key), genTag{options: map[string]string{}}, ""); err != nil {
+			return err
+		}
+		c.line("var %s %s", value, types.ExprString(typed.Value))
+		if err := c.fill(value, typed.Value, types.ExprString(typed.Value), tag, fieldName); err != nil {
+			return err
+		}
+		c.line("%s[%s] = %s", target, key, value)
+		c.line("}")
+		return nil
+	}
+	return fmt.Errorf("error, unsupported type %s", typeName)
+}
+
+// Returns the expression of a random value of a predeclared type, converted to typeName
+func (c *codeGenerator) basic(name, typeName string, tag genTag, fieldName string) (string, error) {
+	convert := func(value, from string) string {
+		if typeName == from {
+			return value
+		}
+		return typeName + "(" + value + ")"
+	}
+	oneOf := strings.Split(tag.options["oneof"], "|")
+	if tag.kind != "" && name != "string" {
+		return "", fmt.Errorf("error, the %s option of the randgen tag needs a string type, not %s", tag.kind, typeName)
+	}
+	switch {
+	case name == "string":
+		if _, ok := tag.options["oneof"]; ok {
+			quoted := make([]string, len(oneOf))
+			for i, value := range oneOf {
+				quoted[i] = strconv.Quote(value)
+			}
+			return convert(fmt.Sprintf("[]string{%s}[g.Int(0, %d)]", strings.Join(quoted, ", "), len(oneOf)-1), "string"), nil
+		}
+		if tag.kind != "" {
+			return convert(genStringKinds[tag.kind], "string"), nil
+		}
+		// the same guess as the text columns of the sql command, so Microphone is not a phone
+		if kind := randgen.GuessNameKind(fieldName); len(tag.options) == 0 && genStringKinds[kind] != "" {
+			return convert(genStringKinds[kind], "string"), nil
+		}
+		minLength, err := tag.integer("min", 1)
+		if err != nil {
+			return "", err
+		}
+		maxLength, err := tag.integer("max", 10)
+		if err != nil {
+			return "", err
+		}
+		alphabet, ok := tag.options["alphabet"]
+		if !ok {
+			alphabet = genAlphabet
+		}
+		if minLength < 0 || maxLength < minLength || alphabet == "" {
+			return "", fmt.Errorf("error, invalid string options min=%d, max=%d and alphabet=%q", minLength, maxLength, alphabet)
+		}
+		return convert(fmt.Sprintf("g.String(%d, %d, %s)", minLength, maxLength, strconv.Quote(alphabet)), "string"), nil
+	case name == "bool":
+		return convert("g.Bernoulli(0.5)", "bool"), nil
+	case name == "float32" || name == "float64":
+		if _, ok := tag.options["oneof"]; ok {
+			for _, value := range oneOf {
+				if _, err := strconv.ParseFloat(value, 64); err != nil {
+					return "", fmt.Errorf("error, the value %q of oneof is not a number", value)
+				}
+			}
+			return convert(fmt.Sprintf("[]float64{%s}[g.Int(0, %d)]", strings.Join(oneOf, ", "), len(oneOf)-1), "float64"), nil
+		}
+		minValue, err := tag.float("min", 0)
+		if err != nil {
+			return "", err
+		}
+		maxValue, err := tag.float("max", 1000)
+		if err != nil {
+			return "", err
+		}
+		if maxValue <= minValue {
+			return "", fmt.Errorf("error, invalid bounds min=%v and max=%v", minValue, maxValue)
+		}
+		return convert(fmt.Sprintf("g.Uniform(%v, %v)", minValue, maxValue), "float64"), nil
+	}
+	bounds, ok := genIntegerBounds[name]
+	if !ok {
+		return "", fmt.Errorf("error, unsupported type %s", typeName)
+	}
+	if _, ok := tag.options["oneof"]; ok {
+		for _, value := range oneOf {
+			number, err := strconv.ParseInt(value, 10, 64)
+			if err != nil || number < bounds[0] || number > bounds[1] {
+				return "", fmt.Errorf("error, the value %q of oneof is not a %s", value, name)
+			}
+		}
+		return convert(fmt.Sprintf("[]int64{%s}[g.Int(0, %d)]", strings.Join(oneOf, ", "), len(oneOf)-1), "int64"), nil
+	}
+	maxDefault := int64(1000)
+	if bounds[1] < maxDefault {
+		maxDefault = bounds[1]
+	}
+	minValue, err := tag.integer("min", 0)
+	if err != nil {
+		return "", err
+	}
+	maxValue, err := tag.integer("max", maxDefault)
+	if err != nil {
+		return "", err
+	}
+	if minValue < bounds[0] || maxValue > bounds[1] || maxValue < minValue || (minValue < 0 && maxValue > math.MaxInt64+minValue) {
+		return "", fmt.Errorf("error, invalid bounds min=%d and max=%d of %s", minValue, maxValue, name)
+	}
+	if minValue == 0 {
+		return convert(fmt.Sprintf("g.Int64(0, %d)", maxValue), "int64"), nil
+	}
+	return convert(fmt.Sprintf("%d + g.Int64(0, %d)", minValue, maxValue-minValue), "int64"), nil
+}
+
+// Returns the expression of a random time.Time between the dates of the min and max options, like
+// min=2020-01-01, from 2000-01-01 to 2030-12-31 by default. timeName is the name of the time package.
+func genTime(timeName string, tag genTag) (string, error) {
+	bounds := [2]time.Time{}
+	for i, option := range [][2]string{{"min", "2000-01-01"}, {"max", "2030-12-31"}} {
+		text, ok := tag.options[option[0]]
+		if !ok {
+			text = option[1]
+		}
+		date, err := time.Parse("2006-01-02", text)
+		if err != nil {
+			return "", fmt.Errorf("error, the option %s=%s of the randgen tag is not a date like 2006-01-02", option[0], text)
+		}
+		bounds[i] = date
+	}
+	if bounds[1].Before(bounds[0]) {
+		return "", fmt.Errorf("error, the date max=%s is before min=%s", tag.options["max"], tag.options["min"])
+	}
+	return fmt.Sprintf("%s.Unix(%d + g.Int64(0, %d), 0).UTC()", timeName, bounds[0].Unix(),
+		bounds[1].Unix()-bounds[0].Unix()), nil
+}
+
+// Returns the formatted source of a file with a Random<Type> function for every struct type of the Go
+// source, which fill the fields with the methods of randgen.Generator and no reflection
+func generateRandomFunctions(filename string, source []byte) ([]byte, error) {
+	files := token.NewFileSet()
+	file, err := parser.ParseFile(files, filename, source, 0)
+	if err != nil {
+		return nil, err
+	}
+	c := &codeGenerator{types: make(map[string]*ast.TypeSpec), recursive: make(map[string]bool)}
+	for _, spec := range file.Imports {
+		if path, _ := strconv.Unquote(spec.Path.Value); path == "time" {
+			c.timeName = "time"
+			if spec.Name != nil {
+				c.timeName = spec.Name.Name
+			}
+		}
+	}
+	structs := make([]*ast.TypeSpec, 0)
+	for _, declaration := range file.Decls {
+		general, ok := declaration.(*ast.GenDecl)
+		if !ok || general.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range general.Specs {
+			typeSpec := spec.(*ast.TypeSpec)
+			c.types[typeSpec.Name.Name] = typeSpec
+			if _, ok := typeSpec.Type.(*ast.StructType); ok {
+				structs = append(structs, typeSpec)
+			}
+		}
+	}
+	if len(structs) == 0 {
+		return nil, fmt.Errorf("error, the file %s has no struct types", filename)
+	}
+	for _, spec := range structs {
+		c.recursive[spec.Name.Name] = c.reaches(spec.Type, spec.Name.Name, make(map[string]bool))
+	}
+	for _, spec := range structs {
+		if err := c.structFunction(spec, spec.Type.(*ast.StructType)); err != nil {
+			return nil, err
+		}
+	}
+	var code bytes.Buffer
+	fmt.Fprintf(&code, "// Code generated by gominirandgen gen from %s; DO NOT EDIT.\n\n", filename)
+	fmt.Fprintf(&code, "package %s\n\nimport (\n", file.Name.Name)
+	if c.usesTime {
+		fmt.Fprintf(&code, "%s \"time\"\n\n", c.timeName)
+	}
+	fmt.Fprintf(&code, "\"github.com/niquefa/gominirandgen/randgen\"\n)\n\n")
+	code.Write(c.body.Bytes())
+	return format.Source(code.Bytes())
+}
+
+// Writes a Go file with a Random<Type>(g *randgen.Generator) function for every struct type of a Go source
+// file, for go generate. The randgen tag of the fields tunes their values.
+func runGen(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("gen", flag.ContinueOnError)
+	input := flags.String("input", os.Getenv("GOFILE"), "Go source file with the struct types, $GOFILE under go generate")
+	output := flags.String("output", "", "generated file, the input with the _randgen.go suffix by default, - for the standard output")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *input == "" {
+		return fmt.Errorf("error, the gen command needs an -input Go file")
+	}
+	source, err := ioutil.ReadFile(*input)
+	if err != nil {
+		return err
+	}
+	code, err := generateRandomFunctions(*input, source)
+	if err != nil {
+		return err
+	}
+	switch *output {
+	case "-":
+		_, err = stdout.Write(code)
+		return err
+	case "":
+		*output = strings.TrimSuffix(*input, ".go") + "_randgen.go"
+	}
+	return ioutil.WriteFile(*output, code, 0644)
+}
@@ -0,0 +1,216 @@
+package main
+
+import (
+	"bytes"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testGenSource = `package shop
+
+import clock "time"
+
+type Status string
+
+type Base struct {
+	ID int64 ` + "`randgen:\"min=1,max=999\"`" + `
+}
+
+type Customer struct {
+	Base
+	Name    string ` + "`json:\"name\" randgen:\"min=3,max=8,alphabet=abc\"`" + `
+	Email   string
+	HomeTel string
+	Microphone string
+	Headphones string
+	Contact string ` + "`randgen:\"phone\"`" + `
+	Status  Status ` + "`randgen:\"oneof=active|blocked\"`" + `
+	Age     uint8 ` + "`randgen:\"min=18,max=99\"`" + `
+	Offset  int ` + "`randgen:\"min=-5,max=5\"`" + `
+	Score   float32
+	Active  bool
+	Tags    []string ` + "`randgen:\"minlen=1,maxlen=3\"`" + `
+	Avatar  []byte
+	Meta    map[string]int
+	Born    clock.Time ` + "`randgen:\"min=1980-01-01,max=1980-01-02\"`" + `
+	Referrer *Customer ` + "`randgen:\"nil=0.5\"`" + `
+	Friends []Customer
+	Secret  string ` + "`randgen:\"-\"`" + `
+}
+`
+
+func TestGen(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gen")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "shop.go")
+	assert.Nil(t, ioutil.WriteFile(path, []byte(testGenSource), 0644))
+	var stdout, stderr bytes.Buffer
+	assert.Equal(t, 0, run([]string{"gen", "-input", path, "-output", "-"}, &stdout, &stderr), stderr.String())
+	code := stdout.String()
+	_, err = parser.ParseFile(token.NewFileSet(), "shop_randgen.go", code, 0)
+	assert.Nil(t, err)
+	for _, line := range []string{
+		"package shop",
+		`clock "time"`,
+		`"github.com/niquefa/gominirandgen/randgen"`,
+		"func RandomBase(g *randgen.Generator) Base {",
+		"value.ID = 1 + g.Int64(0, 998)",
+		"func RandomCustomer(g *randgen.Generator) Customer {",
+		"value.Base = RandomBase(g)",
+		`value.Name = g.String(3, 8, "abc")`,
+		"value.Email = g.Email()",
+		"value.HomeTel = g.PhoneNumber()",
+		`value.Microphone = g.String(1, 10, "`,
+		`value.Headphones = g.String(1, 10, "`,
+		"value.Contact = g.PhoneNumber()",
+		`value.Status = Status([]string{"active", "blocked"}[g.Int(0, 1)])`,
+		"value.Age = uint8(18 + g.Int64(0, 81))",
+		"value.Offset = int(-5 + g.Int64(0, 10))",
+		"value.Score = float32(g.Uniform(0, 1000))",
+		"value.Active = g.Bernoulli(0.5)",
+		"value.Tags = make([]string, g.Int(1, 3))",
+		"value.Avatar = g.Bytes(g.Int(0, 5))",
+		"value.Meta = make(map[string]int)",
+		"value.Born = clock.Unix(315532800+g.Int64(0, 86400), 0).UTC()",
+		"if !g.Bernoulli(0.5) {",
+		"func randomCustomerAtDepth(g *randgen.Generator, depth int) Customer {",
+		"if depth < 5 {",
+		"*value.Referrer = randomCustomerAtDepth(g, depth+1)",
+	} {
+		assert.Contains(t, code, line)
+	}
+	assert.NotContains(t, code, "value.Friends")
+	assert.NotContains(t, code, "value.Secret")
+	os.Setenv("GOFILE", path)
+	defer os.Unsetenv("GOFILE")
+	assert.Equal(t, 0, run([]string{"gen"}, &stdout, &stderr), stderr.String())
+	written, err := ioutil.ReadFile(filepath.Join(dir, "shop_randgen.go"))
+	assert.Nil(t, err)
+	assert.Equal(t, code, string(written))
+}
+
+// Types that contain themselves, whose generated functions must stop at some depth
+const testGenRecursiveSource = `package main
+
+type Node struct {
+	Value    int
+	Children []Node ` + "`randgen:\"maxlen=3\"`" + `
+}
+
+type Folder struct {
+	Name  string
+	Files []File ` + "`randgen:\"minlen=1,maxlen=2\"`" + `
+}
+
+type File struct {
+	Parent *Folder ` + "`randgen:\"nil=0\"`" + `
+	Root   Node
+}
+`
+
+// Runs the generated functions of the recursive types and prints the deepest Node and Folder they return
+const testGenRecursiveMain = `package main
+
+import (
+	"fmt"
+
+	"github.com/niquefa/gominirandgen/randgen"
+)
+
+func nodeDepth(node Node) int {
+	deepest := 0
+	for _, child := range node.Children {
+		if depth := nodeDepth(child) + 1; depth > deepest {
+			deepest = depth
+		}
+	}
+	return deepest
+}
+
+func folderDepth(folder Folder) int {
+	deepest := 0
+	for _, file := range folder.Files {
+		if file.Parent != nil {
+			if depth := folderDepth(*file.Parent) + 1; depth > deepest {
+				deepest = depth
+			}
+		}
+	}
+	return deepest
+}
+
+func main() {
+	nodes, folders := 0, 0
+	for seed := int64(0); seed < 100; seed++ {
+		g := randgen.NewGenerator(seed)
+		if depth := nodeDepth(RandomNode(g)); depth > nodes {
+			nodes = depth
+		}
+		if depth := folderDepth(RandomFolder(g)); depth > folders {
+			folders = depth
+		}
+	}
+	fmt.Println(nodes, folders)
+}
+`
+
+func TestGenRecursiveRuns(t *testing.T) {
+	goCommand, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("the go command is not available")
+	}
+	// inside the module, so the generated code imports this randgen package
+	dir, err := ioutil.TempDir(".", "_gen")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "types.go")
+	assert.Nil(t, ioutil.WriteFile(path, []byte(testGenRecursiveSource), 0644))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, "main.go"), []byte(testGenRecursiveMain), 0644))
+	var stdout, stderr bytes.Buffer
+	assert.Equal(t, 0, run([]string{"gen", "-input", path}, &stdout, &stderr), stderr.String())
+	command := exec.Command(goCommand, "run", "main.go", "types.go", "types_randgen.go")
+	command.Dir = dir
+	output, err := command.CombinedOutput()
+	assert.Nil(t, err, string(output))
+	depths := strings.Fields(string(output))
+	assert.Len(t, depths, 2, string(output))
+	for _, text := range depths {
+		depth, err := strconv.Atoi(text)
+		assert.Nil(t, err)
+		assert.True(t, depth > 0 && depth <= genMaxDepth, text)
+	}
+}
+
+func TestGenErrors(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gen")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	var stdout, stderr bytes.Buffer
+	for i, source := range []string{
+		"package a\n\ntype A int",
+		"package a\n\ntype A struct{ F chan int }",
+		"package a\n\ntype A struct{ F int `randgen:\"email\"` }",
+		"package a\n\ntype A struct{ F int8 `randgen:\"max=300\"` }",
+		"package a\n\ntype A struct{ F string `randgen:\"min=5,max=2\"` }",
+		"package a\n\ntype A struct{ F float64 `randgen:\"oneof=1|x\"` }",
+		"package a\n\ntype A struct{ F *int `randgen:\"nil=2\"` }",
+		"package a\n\ntype A struct{ F string `randgen:\"unknown\"` }",
+		"package a\n\nimport \"time\"\n\ntype A struct{ F time.Time `randgen:\"min=yesterday\"` }",
+		"package a\n\ntype A struct{",
+	} {
+		path := filepath.Join(dir, "a.go")
+		assert.Nil(t, ioutil.WriteFile(path, []byte(source), 0644))
+		assert.Equal(t, 1, run([]string{"gen", "-input", path, "-output", "-"}, &stdout, &stderr), i)
+	}
+	assert.Equal(t, 1, run([]string{"gen", "-input", ""}, &stdout, &stderr))
+}
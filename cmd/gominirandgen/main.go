@@ -0,0 +1,60 @@
+// Command gominirandgen generates random data from the command line, run it without arguments to list
+// its commands.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// A subcommand of the CLI, run receives the arguments after the name of the command
+type command struct {
+	name  string
+	usage string
+	run   func(args []string, stdout io.Writer) error
+}
+
+var commands = []command{
+	{"essentials", "shows the essential random functions of math/rand", runEssentials},
+	{"split", "splits a CSV or JSONL file in train, validation and test files, or in folds", runSplit},
+	{"schema", "writes random instances, valid or not, of a JSON schema as JSON lines", runSchema},
+	{"openapi", "writes random request and response bodies of the operations of an OpenAPI document", runOpenAPI},
+	{"sql", "writes INSERT statements with random rows for the tables of CREATE TABLE statements", runSQL},
+	{"gen", "writes Random<Type> functions for the struct types of a Go file, for go generate", runGen},
+	{"template", "renders a text/template file with random functions like randInt, randEmail or choose", runTemplate},
+	{"serve", "starts a mock HTTP server of an OpenAPI document that answers with random data", runServe},
+}
+
+// Writes the list of commands
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "usage: gominirandgen <command> [flags]")
+	fmt.Fprintln(w, "commands:")
+	for _, c := range commands {
+		fmt.Fprintf(w, "  %-12s %s\n", c.name, c.usage)
+	}
+}
+
+// Runs the command named by the first argument and returns the exit code
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) < 1 {
+		usage(stderr)
+		return 2
+	}
+	for _, c := range commands {
+		if c.name == args[0] {
+			if err := c.run(args[1:], stdout); err != nil {
+				fmt.Fprintln(stderr, err)
+				return 1
+			}
+			return 0
+		}
+	}
+	fmt.Fprintf(stderr, "unknown command %s\n", args[0])
+	usage(stderr)
+	return 2
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	assert.Equal(t, 2, run(nil, &stdout, &stderr))
+	assert.Contains(t, stderr.String(), "essentials")
+	stderr.Reset()
+	assert.Equal(t, 2, run([]string{"nonsense"}, &stdout, &stderr))
+	assert.Contains(t, stderr.String(), "unknown command nonsense")
+}
+
+func TestEssentials(t *testing.T) {
+	var first, second, stderr bytes.Buffer
+	assert.Equal(t, 0, run([]string{"essentials", "-seed", "7"}, &first, &stderr))
+	assert.Equal(t, 0, run([]string{"essentials", "-seed", "7"}, &second, &stderr))
+	assert.Contains(t, first.String(), "Perm")
+	assert.Equal(t, first.String(), second.String())
+	assert.Equal(t, 1, run([]string{"essentials", "-seed", "x"}, &first, &stderr))
+}
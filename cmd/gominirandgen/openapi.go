@@ -0,0 +1,142 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/niquefa/gominirandgen/randgen"
+)
+
+// Headers of the mock server: the seed of the generated response, sent back in every response so it can
+// be reproduced, and the documented status to answer with instead of the successful one
+const (
+	seedHeader   = "X-Random-Seed"
+	statusHeader = "X-Mock-Status"
+)
+
+// Loads the OpenAPI document of the -spec flag
+func loadSpec(path string) (*randgen.OpenAPISpec, error) {
+	if path == "" {
+		return nil, fmt.Errorf("error, the command needs an OpenAPI -spec file, JSON or YAML")
+	}
+	return randgen.LoadOpenAPISpec(path)
+}
+
+// Writes a random request body, if the operation has one, and a random body of its successful response
+// for every operation of an OpenAPI document, one JSON object per line
+func runOpenAPI(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("openapi", flag.ContinueOnError)
+	path := flags.String("spec", "", "OpenAPI 3 document, JSON or YAML")
+	seed := flags.Int64("seed", 1, "seed of the bodies, the same seed and document give the same bodies")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	spec, err := loadSpec(*path)
+	if err != nil {
+		return err
+	}
+	g := randgen.NewGenerator(*seed)
+	encoder := json.NewEncoder(stdout)
+	for _, operation := range spec.Operations {
+		example := map[string]interface{}{"method": operation.Method, "path": operation.Path}
+		if operation.ID != "" {
+			example["operationId"] = operation.ID
+		}
+		if operation.RequestBody != nil {
+			if example["request"], err = spec.RequestBody(g, operation); err != nil {
+				return err
+			}
+		}
+		status := operation.SuccessStatus()
+		example["status"] = status
+		if example["response"], err = spec.ResponseBody(g, operation, status); err != nil {
+			return err
+		}
+		if err := encoder.Encode(example); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// An HTTP handler that answers the operations of an OpenAPI document with random bodies of their schemas
+type mockServer struct {
+	spec *randgen.OpenAPISpec
+}
+
+// Writes a JSON body with the status
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+func (m mockServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	operation, pathFound := m.spec.FindOperation(r.Method, r.URL.Path)
+	if operation == nil {
+		if pathFound {
+			writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+		} else {
+			writeJSON(w, http.StatusNotFound, map[string]string{"error": "no operation for " + r.URL.Path})
+		}
+		return
+	}
+	seed := time.Now().UnixNano()
+	if text := r.Header.Get(seedHeader); text != "" {
+		var err error
+		if seed, err = strconv.ParseInt(text, 10, 64); err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid " + seedHeader + " " + text})
+			return
+		}
+	}
+	status := operation.SuccessStatus()
+	if text := r.Header.Get(statusHeader); text != "" {
+		status = text
+	}
+	if _, ok := operation.Responses[status]; !ok {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "the operation has no response " + status})
+		return
+	}
+	body, err := m.spec.ResponseBody(randgen.NewGenerator(seed), *operation, status)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return
+	}
+	code, err := strconv.Atoi(status)
+	if err != nil {
+		// default and ranges like 2XX
+		code = http.StatusOK
+		if len(status) == 3 && status[0] >= '1' && status[0] <= '5' {
+			code = int(status[0]-'0') * 100
+		}
+	}
+	w.Header().Set(seedHeader, strconv.FormatInt(seed, 10))
+	if operation.Responses[status] == nil {
+		w.WriteHeader(code)
+		return
+	}
+	writeJSON(w, code, body)
+}
+
+// Starts a local HTTP mock server of an OpenAPI document, every operation answers with random data that
+// conforms to the schema of its response
+func runServe(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("serve", flag.ContinueOnError)
+	path := flags.String("spec", "", "OpenAPI 3 document, JSON or YAML")
+	address := flags.String("addr", "localhost:8080", "address to listen on")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	spec, err := loadSpec(*path)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "serving %d operations of %s on http://%s, send %s to reproduce a response\n",
+		len(spec.Operations), *path, *address, seedHeader)
+	return http.ListenAndServe(*address, mockServer{spec})
+}
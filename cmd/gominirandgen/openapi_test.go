@@ -0,0 +1,140 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/niquefa/gominirandgen/randgen"
+	"github.com/stretchr/testify/assert"
+)
+
+const testSpec = `
+openapi: 3.0.3
+info: {title: Pets, version: "1"}
+paths:
+  /pets:
+    post:
+      operationId: createPet
+      requestBody:
+        content:
+          application/json:
+            schema: {$ref: '#/components/schemas/Pet'}
+      responses:
+        "201":
+          content:
+            application/json:
+              schema: {$ref: '#/components/schemas/Pet'}
+  /pets/{id}:
+    get:
+      operationId: getPet
+      responses:
+        "200":
+          content:
+            application/json:
+              schema: {$ref: '#/components/schemas/Pet'}
+        "404":
+          content:
+            application/json:
+              schema: {type: object, required: [message], properties: {message: {type: string}}}
+    delete:
+      responses:
+        "204": {description: deleted}
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [id, name, owner]
+      properties:
+        id: {type: integer, minimum: 1}
+        name: {type: string, minLength: 1, maxLength: 10}
+        owner: {type: string, format: email}
+`
+
+// Writes the test OpenAPI document to a temporary directory and returns its path
+func writeSpec(t *testing.T, dir string) string {
+	path := filepath.Join(dir, "pets.yaml")
+	assert.Nil(t, ioutil.WriteFile(path, []byte(testSpec), 0644))
+	return path
+}
+
+func TestOpenAPI(t *testing.T) {
+	dir, err := ioutil.TempDir("", "openapi")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := writeSpec(t, dir)
+	var stdout, second, stderr bytes.Buffer
+	assert.Equal(t, 0, run([]string{"openapi", "-spec", path, "-seed", "4"}, &stdout, &stderr), stderr.String())
+	assert.Equal(t, 0, run([]string{"openapi", "-spec", path, "-seed", "4"}, &second, &stderr))
+	assert.Equal(t, stdout.String(), second.String())
+	operations := make([]string, 0)
+	for scanner := bufio.NewScanner(&stdout); scanner.Scan(); {
+		var example map[string]interface{}
+		assert.Nil(t, json.Unmarshal(scanner.Bytes(), &example))
+		operations = append(operations, example["method"].(string)+" "+example["path"].(string)+" "+
+			example["status"].(string))
+		if example["operationId"] == "createPet" {
+			assert.Contains(t, example["request"], "owner")
+		}
+	}
+	assert.Equal(t, []string{"POST /pets 201", "GET /pets/{id} 200", "DELETE /pets/{id} 204"}, operations)
+	assert.Equal(t, 1, run([]string{"openapi"}, &stdout, &stderr))
+	assert.Equal(t, 1, run([]string{"serve", "-spec", filepath.Join(dir, "missing.yaml")}, &stdout, &stderr))
+}
+
+func TestMockServer(t *testing.T) {
+	spec, err := randgen.ParseOpenAPISpec([]byte(testSpec))
+	assert.Nil(t, err)
+	server := httptest.NewServer(mockServer{spec})
+	defer server.Close()
+	get := func(method, path string, headers map[string]string) (*http.Response, []byte) {
+		request, err := http.NewRequest(method, server.URL+path, nil)
+		assert.Nil(t, err)
+		for name, value := range headers {
+			request.Header.Set(name, value)
+		}
+		response, err := http.DefaultClient.Do(request)
+		assert.Nil(t, err)
+		defer response.Body.Close()
+		body, err := ioutil.ReadAll(response.Body)
+		assert.Nil(t, err)
+		return response, body
+	}
+	response, body := get("GET", "/pets/3", nil)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, "application/json", response.Header.Get("Content-Type"))
+	var pet map[string]interface{}
+	assert.Nil(t, json.Unmarshal(body, &pet))
+	assert.Contains(t, pet["owner"], "@")
+	seed := response.Header.Get(seedHeader)
+	assert.NotEmpty(t, seed)
+	_, again := get("GET", "/pets/3", map[string]string{seedHeader: seed})
+	assert.Equal(t, string(body), string(again))
+	response, body = get("GET", "/pets/3", map[string]string{statusHeader: "404"})
+	assert.Equal(t, http.StatusNotFound, response.StatusCode)
+	assert.Contains(t, string(body), "message")
+	response, body = get("DELETE", "/pets/3", nil)
+	assert.Equal(t, http.StatusNoContent, response.StatusCode)
+	assert.Empty(t, body)
+	response, _ = get("POST", "/pets", nil)
+	assert.Equal(t, http.StatusCreated, response.StatusCode)
+	for _, failure := range []struct {
+		method, path string
+		headers      map[string]string
+		status       int
+	}{
+		{"PUT", "/pets/3", nil, http.StatusMethodNotAllowed},
+		{"GET", "/owners", nil, http.StatusNotFound},
+		{"GET", "/pets/3", map[string]string{seedHeader: "x"}, http.StatusBadRequest},
+		{"GET", "/pets/3", map[string]string{statusHeader: "500"}, http.StatusBadRequest},
+	} {
+		response, _ = get(failure.method, failure.path, failure.headers)
+		assert.Equal(t, failure.status, response.StatusCode, failure.method+" "+failure.path)
+	}
+}
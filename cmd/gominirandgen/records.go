@@ -0,0 +1,193 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Formats of the data files read and written by the dataset commands
+const (
+	formatCSV   = "csv"
+	formatJSONL = "jsonl"
+)
+
+// Returns the format of the file from its extension when the given one is empty
+func dataFormat(path, format string) (string, error) {
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+		if format == "ndjson" || format == "json" {
+			format = formatJSONL
+		}
+	}
+	if format != formatCSV && format != formatJSONL {
+		return "", fmt.Errorf("error, unknown data format %q, use csv or jsonl", format)
+	}
+	return format, nil
+}
+
+// Reads the records of a CSV file, whose first row is the header, or of a JSONL file, one JSON object
+// per line, one record at a time so files of any size can be read
+type recordReader struct {
+	format string
+	file   *os.File
+	csv    *csv.Reader
+	lines  *bufio.Reader
+	header []string
+	column string // the column whose value is the key of every record, none if empty
+	index  int    // position of the column in the CSV header
+	line   int
+}
+
+// Opens the data file, the key of every record will be its value in the column
+func openRecords(path, format, column string) (*recordReader, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	reader := &recordReader{format: format, file: file, column: column, index: -1}
+	if format == formatJSONL {
+		reader.lines = bufio.NewReaderSize(file, 1<<16)
+		return reader, nil
+	}
+	reader.csv = csv.NewReader(bufio.NewReaderSize(file, 1<<16))
+	reader.header, err = reader.csv.Read()
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("error, could not read the header of %s: %v", path, err)
+	}
+	for i, name := range reader.header {
+		if name == column {
+			reader.index = i
+		}
+	}
+	if column != "" && reader.index < 0 {
+		file.Close()
+		return nil, fmt.Errorf("error, %s has no column %s", path, column)
+	}
+	return reader, nil
+}
+
+// Returns the next record, as CSV fields or as a JSON line, and its key. Returns io.EOF at the end.
+func (r *recordReader) next() ([]string, []byte, string, error) {
+	if r.format == formatCSV {
+		fields, err := r.csv.Read()
+		if err != nil {
+			return nil, nil, "", err
+		}
+		if r.index < 0 {
+			return fields, nil, "", nil
+		}
+		return fields, nil, fields[r.index], nil
+	}
+	for {
+		line, err := r.lines.ReadBytes('\n')
+		if err != nil && (err != io.EOF || len(line) == 0) {
+			return nil, nil, "", err
+		}
+		r.line++
+		line = bytes.TrimRight(line, "\r\n")
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		if r.column == "" {
+			return nil, line, "", nil
+		}
+		var object map[string]json.RawMessage
+		if err := json.Unmarshal(line, &object); err != nil {
+			return nil, nil, "", fmt.Errorf("error, line %d is not a JSON object: %v", r.line, err)
+		}
+		value, ok := object[r.column]
+		if !ok {
+			return nil, nil, "", fmt.Errorf("error, line %d has no field %s", r.line, r.column)
+		}
+		return nil, line, string(value), nil
+	}
+}
+
+func (r *recordReader) Close() error {
+	return r.file.Close()
+}
+
+// Writes records in the CSV or JSONL format
+type recordWriter struct {
+	format   string
+	file     *os.File
+	buffered *bufio.Writer
+	csv      *csv.Writer
+	count    int
+}
+
+// Creates the data file, CSV files start with the header
+func createRecords(path, format string, header []string) (*recordWriter, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	writer := &recordWriter{format: format, file: file, buffered: bufio.NewWriterSize(file, 1<<16)}
+	if format == formatCSV {
+		writer.csv = csv.NewWriter(writer.buffered)
+		if err := writer.csv.Write(header); err != nil {
+			file.Close()
+			return nil, err
+		}
+	}
+	return writer, nil
+}
+
+// Writes a record, given as CSV fields or as a JSON line
+func (w *recordWriter) write(fields []string, line []byte) error {
+	w.count++
+	if w.format == formatCSV {
+		return w.csv.Write(fields)
+	}
+	if _, err := w.buffered.Write(line); err != nil {
+		return err
+	}
+	return w.buffered.WriteByte('\n')
+}
+
+// Flushes and closes the file, closing it again does nothing
+func (w *recordWriter) Close() error {
+	if w.file == nil {
+		return nil
+	}
+	file := w.file
+	w.file = nil
+	if w.csv != nil {
+		w.csv.Flush()
+		if err := w.csv.Error(); err != nil {
+			file.Close()
+			return err
+		}
+	}
+	if err := w.buffered.Flush(); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// Returns the JSON object of the line with a new field first, like {"fold":2,"id":7}
+func addJSONField(line []byte, name string, value interface{}) ([]byte, error) {
+	trimmed := bytes.TrimSpace(line)
+	if len(trimmed) < 2 || trimmed[0] != '{' {
+		return nil, fmt.Errorf("error, %q is not a JSON object", line)
+	}
+	field, err := json.Marshal(map[string]interface{}{name: value})
+	if err != nil {
+		return nil, err
+	}
+	rest := bytes.TrimSpace(trimmed[1:])
+	if rest[0] == '}' {
+		return field, nil
+	}
+	result := append(field[:len(field)-1:len(field)-1], ',')
+	return append(result, rest...), nil
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDataFormat(t *testing.T) {
+	format, err := dataFormat("data/people.CSV", "")
+	assert.Nil(t, err)
+	assert.Equal(t, formatCSV, format)
+	format, err = dataFormat("events.ndjson", "")
+	assert.Nil(t, err)
+	assert.Equal(t, formatJSONL, format)
+	_, err = dataFormat("notes.txt", "")
+	assert.NotNil(t, err)
+}
+
+func TestAddJSONField(t *testing.T) {
+	line, err := addJSONField([]byte(` {"id": 7}`), "fold", 2)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"fold":2,"id": 7}`, string(line))
+	line, err = addJSONField([]byte(`{ }`), "fold", 0)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"fold":0}`, string(line))
+	_, err = addJSONField([]byte(`[1]`), "fold", 0)
+	assert.NotNil(t, err)
+}
+
+func TestRecordsRoundTrip(t *testing.T) {
+	dir, err := ioutil.TempDir("", "records")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "rows.jsonl")
+	assert.Nil(t, ioutil.WriteFile(path, []byte("{\"a\":1}\n\n{\"a\":\"x\"}\r\n{\"b\":2}"), 0644))
+	reader, err := openRecords(path, formatJSONL, "a")
+	assert.Nil(t, err)
+	defer reader.Close()
+	_, line, key, err := reader.next()
+	assert.Nil(t, err)
+	assert.Equal(t, `{"a":1}`, string(line))
+	assert.Equal(t, "1", key)
+	_, _, key, err = reader.next()
+	assert.Nil(t, err)
+	assert.Equal(t, `"x"`, key)
+	_, _, _, err = reader.next()
+	assert.Contains(t, err.Error(), "line 4 has no field a")
+
+	csvPath := filepath.Join(dir, "rows.csv")
+	writer, err := createRecords(csvPath, formatCSV, []string{"id", "name"})
+	assert.Nil(t, err)
+	assert.Nil(t, writer.write([]string{"1", "Ana, María"}, nil))
+	assert.Nil(t, writer.Close())
+	assert.Nil(t, writer.Close())
+	csvReader, err := openRecords(csvPath, formatCSV, "name")
+	assert.Nil(t, err)
+	defer csvReader.Close()
+	assert.Equal(t, []string{"id", "name"}, csvReader.header)
+	fields, _, key, err := csvReader.next()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"1", "Ana, María"}, fields)
+	assert.Equal(t, "Ana, María", key)
+	_, _, _, err = csvReader.next()
+	assert.Equal(t, io.EOF, err)
+	_, err = openRecords(csvPath, formatCSV, "missing")
+	assert.NotNil(t, err)
+}
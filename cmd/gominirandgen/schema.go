@@ -0,0 +1,49 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/niquefa/gominirandgen/randgen"
+)
+
+// Writes random instances of a JSON schema, one JSON document per line. With -invalid every line is an
+// object with the instance, the keyword it violates and the JSON pointer of the invalid value.
+func runSchema(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("schema", flag.ContinueOnError)
+	path := flags.String("schema", "", "JSON schema file, draft-07 or 2020-12")
+	count := flags.Int("count", 10, "number of instances")
+	invalid := flags.Bool("invalid", false, "instances that violate exactly one keyword of the schema")
+	seed := flags.Int64("seed", 1, "seed of the instances, the same seed and schema give the same instances")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *path == "" || *count < 1 {
+		return fmt.Errorf("error, the schema command needs a -schema file and a positive -count")
+	}
+	schema, err := randgen.LoadJSONSchema(*path)
+	if err != nil {
+		return err
+	}
+	g := randgen.NewGenerator(*seed)
+	encoder := json.NewEncoder(stdout)
+	for i := 0; i < *count; i++ {
+		var instance interface{}
+		if *invalid {
+			violation, err := schema.InvalidInstanceWith(g)
+			if err != nil {
+				return err
+			}
+			instance = map[string]interface{}{"instance": violation.Instance, "keyword": violation.Keyword,
+				"path": violation.Path}
+		} else if instance, err = schema.InstanceWith(g); err != nil {
+			return err
+		}
+		if err := encoder.Encode(instance); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/niquefa/gominirandgen/randgen"
+	"github.com/stretchr/testify/assert"
+)
+
+const testSchema = `{"type": "object", "required": ["id", "tags"], "additionalProperties": false,
+	"properties": {"id": {"type": "integer", "minimum": 1}, "tags": {"type": "array", "items": {"type": "string"}}}}`
+
+func TestSchema(t *testing.T) {
+	dir, err := ioutil.TempDir("", "schema")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "schema.json")
+	assert.Nil(t, ioutil.WriteFile(path, []byte(testSchema), 0644))
+	schema, err := randgen.LoadJSONSchema(path)
+	assert.Nil(t, err)
+	var stdout, stderr bytes.Buffer
+	assert.Equal(t, 0, run([]string{"schema", "-schema", path, "-count", "20"}, &stdout, &stderr), stderr.String())
+	lines := 0
+	for scanner := bufio.NewScanner(&stdout); scanner.Scan(); lines++ {
+		var instance interface{}
+		assert.Nil(t, json.Unmarshal(scanner.Bytes(), &instance))
+		assert.Empty(t, schema.Validate(instance))
+	}
+	assert.Equal(t, 20, lines)
+	stdout.Reset()
+	assert.Equal(t, 0, run([]string{"schema", "-schema", path, "-count", "20", "-invalid"}, &stdout, &stderr))
+	for scanner := bufio.NewScanner(&stdout); scanner.Scan(); {
+		var violation struct {
+			Instance interface{}
+			Keyword  string
+			Path     string
+		}
+		assert.Nil(t, json.Unmarshal(scanner.Bytes(), &violation))
+		errors := schema.Validate(violation.Instance)
+		if assert.Len(t, errors, 1) {
+			assert.Equal(t, violation.Keyword, errors[0].Keyword)
+			assert.Equal(t, violation.Path, errors[0].Path)
+		}
+	}
+	var first, second bytes.Buffer
+	assert.Equal(t, 0, run([]string{"schema", "-schema", path, "-count", "5", "-seed", "7"}, &first, &stderr))
+	assert.Equal(t, 0, run([]string{"schema", "-schema", path, "-count", "5", "-seed", "7"}, &second, &stderr))
+	assert.Equal(t, first.String(), second.String())
+	first.Reset()
+	second.Reset()
+	assert.Equal(t, 0, run([]string{"schema", "-schema", path, "-count", "5", "-invalid", "-seed", "7"}, &first, &stderr))
+	assert.Equal(t, 0, run([]string{"schema", "-schema", path, "-count", "5", "-invalid", "-seed", "7"}, &second, &stderr))
+	assert.Equal(t, first.String(), second.String())
+	assert.Equal(t, 1, run([]string{"schema"}, &stdout, &stderr))
+	assert.Equal(t, 1, run([]string{"schema", "-schema", filepath.Join(dir, "missing.json")}, &stdout, &stderr))
+}
@@ -0,0 +1,247 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/niquefa/gominirandgen/randgen"
+)
+
+// Names of the parts of a split with two or three ratios
+var splitPartNames = map[int][]string{
+	2: {"train", "test"},
+	3: {"train", "validation", "test"},
+}
+
+// Options of the split command
+type splitOptions struct {
+	input      string
+	format     string
+	outputDir  string
+	ratios     []float64
+	stratify   string
+	folds      int
+	group      string
+	foldColumn string
+	seed       int64
+}
+
+// Parses ratios like "0.8,0.1,0.1" or "80,10,10" and normalizes them to add up to 1
+func parseRatios(text string) ([]float64, error) {
+	ratios := make([]float64, 0)
+	sum := 0.0
+	for _, part := range strings.Split(text, ",") {
+		ratio, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil || ratio <= 0 {
+			return nil, fmt.Errorf("error, invalid ratio %q", part)
+		}
+		ratios = append(ratios, ratio)
+		sum += ratio
+	}
+	if _, ok := splitPartNames[len(ratios)]; !ok {
+		return nil, fmt.Errorf("error, there must be two (train, test) or three (train, validation, test) ratios")
+	}
+	for i := range ratios {
+		ratios[i] /= sum
+	}
+	return ratios, nil
+}
+
+// Writes train, validation and test files, or the input with a fold column, from a CSV or JSONL file.
+// The file is read twice: first only the keys of the records, to assign every record, then all of it
+// record by record, so it is never held in memory.
+func runSplit(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("split", flag.ContinueOnError)
+	options := splitOptions{}
+	ratios := ""
+	flags.StringVar(&options.input, "input", "", "CSV (with header) or JSONL file to split")
+	flags.StringVar(&options.format, "format", "", "csv or jsonl, by default from the extension of the input")
+	flags.StringVar(&options.outputDir, "output-dir", "", "directory of the output files, by default the one of the input")
+	flags.StringVar(&ratios, "ratios", "0.8,0.1,0.1", "ratios of train,validation,test or train,test")
+	flags.StringVar(&options.stratify, "stratify", "", "column whose proportions every part keeps")
+	flags.IntVar(&options.folds, "folds", 0, "number of folds, writes the input with a fold column instead of parts")
+	flags.StringVar(&options.group, "group", "", "column of the groups for group k-fold, a group never spans two folds")
+	flags.StringVar(&options.foldColumn, "fold-column", "fold", "name of the fold column")
+	flags.Int64Var(&options.seed, "seed", 1, "seed of the split, the same seed and input give the same files")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if options.input == "" {
+		return fmt.Errorf("error, the split command needs an -input file")
+	}
+	var err error
+	if options.format, err = dataFormat(options.input, options.format); err != nil {
+		return err
+	}
+	if options.ratios, err = parseRatios(ratios); err != nil {
+		return err
+	}
+	if options.folds == 1 || options.folds < 0 || (options.group != "" && options.folds == 0) {
+		return fmt.Errorf("error, -folds must be at least 2, and it is needed by -group")
+	}
+	if options.group != "" && options.stratify != "" {
+		return fmt.Errorf("error, -group and -stratify can not be used together")
+	}
+	if options.outputDir == "" {
+		options.outputDir = filepath.Dir(options.input)
+	}
+	return split(options, stdout)
+}
+
+// Returns the keys of the records of the file, their values in the column
+func readKeys(options splitOptions, column string) ([]string, error) {
+	reader, err := openRecords(options.input, options.format, column)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	keys := make([]string, 0)
+	for {
+		_, _, key, err := reader.next()
+		if err == io.EOF {
+			return keys, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+}
+
+// Returns the fold of every record such that all the records of a group are in the same fold. The groups
+// are shuffled and then, from the largest, every one goes to the fold with fewer records.
+func groupFolds(g *randgen.Generator, groups []string, folds int) []int {
+	members := make(map[string][]int)
+	names := make([]string, 0)
+	for index, group := range groups {
+		if _, ok := members[group]; !ok {
+			names = append(names, group)
+		}
+		members[group] = append(members[group], index)
+	}
+	sort.Strings(names)
+	shuffled := make([]string, len(names))
+	for i, j := range g.Perm(len(names)) {
+		shuffled[i] = names[j]
+	}
+	sort.SliceStable(shuffled, func(a, b int) bool { return len(members[shuffled[a]]) > len(members[shuffled[b]]) })
+	sizes := make([]int, folds)
+	assignment := make([]int, len(groups))
+	for _, group := range shuffled {
+		smallest := 0
+		for fold, size := range sizes {
+			if size < sizes[smallest] {
+				smallest = fold
+			}
+		}
+		for _, index := range members[group] {
+			assignment[index] = smallest
+		}
+		sizes[smallest] += len(members[group])
+	}
+	return assignment
+}
+
+// Returns the part, or fold, of every record
+func assignRecords(options splitOptions) ([]int, error) {
+	g := randgen.NewGenerator(options.seed)
+	column := options.stratify
+	if options.group != "" {
+		column = options.group
+	}
+	keys, err := readKeys(options, column)
+	if err != nil {
+		return nil, err
+	}
+	if options.group != "" {
+		return groupFolds(g, keys, options.folds), nil
+	}
+	fractions := options.ratios
+	if options.folds > 0 {
+		fractions = make([]float64, options.folds)
+		for i := range fractions {
+			fractions[i] = 1 / float64(options.folds)
+		}
+	}
+	parts, err := g.StratifiedPartitionIndices(keys, fractions)
+	if err != nil {
+		return nil, err
+	}
+	assignment := make([]int, len(keys))
+	for part, indices := range parts {
+		for _, index := range indices {
+			assignment[index] = part
+		}
+	}
+	return assignment, nil
+}
+
+// Writes the output files of the split
+func split(options splitOptions, stdout io.Writer) error {
+	assignment, err := assignRecords(options)
+	if err != nil {
+		return err
+	}
+	reader, err := openRecords(options.input, options.format, "")
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	extension := filepath.Ext(options.input)
+	base := strings.TrimSuffix(filepath.Base(options.input), extension)
+	names := splitPartNames[len(options.ratios)]
+	header := reader.header
+	if options.folds > 0 {
+		names = []string{"folds"}
+		if options.format == formatCSV {
+			header = append(append([]string(nil), header...), options.foldColumn)
+		}
+	}
+	writers := make([]*recordWriter, 0, len(names))
+	paths := make([]string, 0, len(names))
+	for _, name := range names {
+		path := filepath.Join(options.outputDir, base+"."+name+extension)
+		writer, err := createRecords(path, options.format, header)
+		if err != nil {
+			return err
+		}
+		defer writer.Close()
+		writers = append(writers, writer)
+		paths = append(paths, path)
+	}
+	for index := 0; ; index++ {
+		fields, line, _, err := reader.next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if index >= len(assignment) {
+			return fmt.Errorf("error, %s changed while it was split", options.input)
+		}
+		writer := writers[0]
+		if options.folds == 0 {
+			writer = writers[assignment[index]]
+		} else if options.format == formatCSV {
+			fields = append(fields, strconv.Itoa(assignment[index]))
+		} else if line, err = addJSONField(line, options.foldColumn, assignment[index]); err != nil {
+			return err
+		}
+		if err := writer.write(fields, line); err != nil {
+			return err
+		}
+	}
+	for i, writer := range writers {
+		if err := writer.Close(); err != nil {
+			return err
+		}
+		fmt.Fprintf(stdout, "%s: %d records in %s\n", names[i], writer.count, paths[i])
+	}
+	return nil
+}
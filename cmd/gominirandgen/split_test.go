@@ -0,0 +1,132 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Writes a CSV file of 1000 rows with ids, a label that is "fraud" in one row of ten and 100 users
+func writeSplitInput(t *testing.T, dir string) string {
+	var content bytes.Buffer
+	content.WriteString("id,label,user\n")
+	for i := 0; i < 1000; i++ {
+		label := "ok"
+		if i%10 == 0 {
+			label = "fraud"
+		}
+		fmt.Fprintf(&content, "%d,%s,u%d\n", i, label, i%100)
+	}
+	path := filepath.Join(dir, "transactions.csv")
+	assert.Nil(t, ioutil.WriteFile(path, content.Bytes(), 0644))
+	return path
+}
+
+func readCSV(t *testing.T, path string) [][]string {
+	file, err := os.Open(path)
+	assert.Nil(t, err)
+	defer file.Close()
+	rows, err := csv.NewReader(file).ReadAll()
+	assert.Nil(t, err)
+	return rows
+}
+
+func TestSplitStratified(t *testing.T) {
+	dir, err := ioutil.TempDir("", "split")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	input := writeSplitInput(t, dir)
+	var stdout, stderr bytes.Buffer
+	args := []string{"split", "-input", input, "-ratios", "80,10,10", "-stratify", "label", "-seed", "7"}
+	assert.Equal(t, 0, run(args, &stdout, &stderr), stderr.String())
+	assert.Contains(t, stdout.String(), "train: 800 records")
+	seen := make(map[string]bool)
+	for name, size := range map[string]int{"train": 800, "validation": 100, "test": 100} {
+		rows := readCSV(t, filepath.Join(dir, "transactions."+name+".csv"))
+		assert.Equal(t, []string{"id", "label", "user"}, rows[0])
+		assert.Len(t, rows, size+1)
+		fraud := 0
+		for _, row := range rows[1:] {
+			assert.False(t, seen[row[0]])
+			seen[row[0]] = true
+			if row[1] == "fraud" {
+				fraud++
+			}
+		}
+		assert.Equal(t, size/10, fraud)
+	}
+	first, _ := ioutil.ReadFile(filepath.Join(dir, "transactions.test.csv"))
+	assert.Equal(t, 0, run(args, &stdout, &stderr))
+	second, _ := ioutil.ReadFile(filepath.Join(dir, "transactions.test.csv"))
+	assert.Equal(t, first, second)
+}
+
+func TestSplitGroupFolds(t *testing.T) {
+	dir, err := ioutil.TempDir("", "split")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	input := writeSplitInput(t, dir)
+	var stdout, stderr bytes.Buffer
+	args := []string{"split", "-input", input, "-folds", "5", "-group", "user"}
+	assert.Equal(t, 0, run(args, &stdout, &stderr), stderr.String())
+	rows := readCSV(t, filepath.Join(dir, "transactions.folds.csv"))
+	assert.Equal(t, []string{"id", "label", "user", "fold"}, rows[0])
+	assert.Len(t, rows, 1001)
+	foldOfUser := make(map[string]string)
+	sizes := make(map[string]int)
+	for _, row := range rows[1:] {
+		if fold, ok := foldOfUser[row[2]]; ok {
+			assert.Equal(t, fold, row[3])
+		}
+		foldOfUser[row[2]] = row[3]
+		sizes[row[3]]++
+	}
+	assert.Len(t, sizes, 5)
+	for _, size := range sizes {
+		assert.Equal(t, 200, size)
+	}
+}
+
+func TestSplitJSONLFolds(t *testing.T) {
+	dir, err := ioutil.TempDir("", "split")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	lines := make([]string, 0)
+	for i := 0; i < 30; i++ {
+		lines = append(lines, fmt.Sprintf(`{"id":%d,"class":"c%d"}`, i, i%3))
+	}
+	input := filepath.Join(dir, "items.jsonl")
+	assert.Nil(t, ioutil.WriteFile(input, []byte(strings.Join(lines, "\n")+"\n"), 0644))
+	var stdout, stderr bytes.Buffer
+	args := []string{"split", "-input", input, "-folds", "3", "-stratify", "class", "-fold-column", "k"}
+	assert.Equal(t, 0, run(args, &stdout, &stderr), stderr.String())
+	content, err := ioutil.ReadFile(filepath.Join(dir, "items.folds.jsonl"))
+	assert.Nil(t, err)
+	output := strings.Split(strings.TrimSpace(string(content)), "\n")
+	assert.Len(t, output, 30)
+	for fold := 0; fold < 3; fold++ {
+		assert.Equal(t, 10, strings.Count(string(content), fmt.Sprintf(`{"k":%d,`, fold)))
+	}
+}
+
+func TestSplitErrors(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	for _, args := range [][]string{
+		{"split"},
+		{"split", "-input", "data.txt"},
+		{"split", "-input", "data.csv", "-ratios", "1,0"},
+		{"split", "-input", "data.csv", "-ratios", "1,1,1,1"},
+		{"split", "-input", "data.csv", "-group", "user"},
+		{"split", "-input", "data.csv", "-folds", "3", "-group", "user", "-stratify", "label"},
+		{"split", "-input", "missing.csv"},
+	} {
+		assert.Equal(t, 1, run(args, &stdout, &stderr), strings.Join(args, " "))
+	}
+}
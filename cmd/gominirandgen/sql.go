@@ -0,0 +1,64 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/niquefa/gominirandgen/randgen"
+)
+
+// Returns the rows of some tables from a list like users=100,orders=500
+func parseTableRows(list string) (map[string]int, error) {
+	tableRows := make(map[string]int)
+	if list == "" {
+		return tableRows, nil
+	}
+	for _, item := range strings.Split(list, ",") {
+		parts := strings.SplitN(item, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("error, invalid -table-rows item %q, expected table=rows", item)
+		}
+		rows, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+		if err != nil || rows < 0 {
+			return nil, fmt.Errorf("error, invalid rows %q of the table %s", parts[1], parts[0])
+		}
+		tableRows[strings.TrimSpace(parts[0])] = rows
+	}
+	return tableRows, nil
+}
+
+// Writes INSERT statements with random rows for the tables of a file of CREATE TABLE statements, every
+// table after the tables it references
+func runSQL(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("sql", flag.ContinueOnError)
+	path := flags.String("ddl", "", "SQL file with the CREATE TABLE statements")
+	rows := flags.Int("rows", randgen.DefaultSQLSeedOptions.Rows, "rows of every table")
+	tableRows := flags.String("table-rows", "", "rows of some tables, like users=100,orders=500")
+	nullProbability := flags.Float64("null", randgen.DefaultSQLSeedOptions.NullProbability,
+		"probability of NULL in the nullable columns that are not part of a key")
+	locale := flags.String("locale", randgen.DefaultSQLSeedOptions.Locale, "locale of the names and cities")
+	batch := flags.Int("batch", 100, "rows per INSERT statement")
+	seed := flags.Int64("seed", 1, "seed of the rows, the same seed and schema give the same script")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *path == "" {
+		return fmt.Errorf("error, the sql command needs a -ddl file with CREATE TABLE statements")
+	}
+	tables, err := randgen.LoadSQLSchema(*path)
+	if err != nil {
+		return err
+	}
+	options := randgen.SQLSeedOptions{Rows: *rows, NullProbability: *nullProbability, Locale: *locale}
+	if options.TableRows, err = parseTableRows(*tableRows); err != nil {
+		return err
+	}
+	rowsSeed, err := randgen.NewGenerator(*seed).SeedSQL(tables, options)
+	if err != nil {
+		return err
+	}
+	return rowsSeed.WriteInserts(stdout, *batch)
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testDDL = `CREATE TABLE orders (id INT PRIMARY KEY, user_id INT NOT NULL REFERENCES users (id), total DECIMAL(8, 2));
+CREATE TABLE users (id INT PRIMARY KEY, email VARCHAR(60) UNIQUE NOT NULL, phone VARCHAR(10));`
+
+func TestSQL(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sql")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "schema.sql")
+	assert.Nil(t, ioutil.WriteFile(path, []byte(testDDL), 0644))
+	var stdout, stderr bytes.Buffer
+	args := []string{"sql", "-ddl", path, "-rows", "4", "-table-rows", "orders=7", "-batch", "5", "-seed", "9"}
+	assert.Equal(t, 0, run(args, &stdout, &stderr), stderr.String())
+	script := stdout.String()
+	assert.True(t, strings.Index(script, "INSERT INTO users") < strings.Index(script, "INSERT INTO orders"))
+	assert.Contains(t, script, "-- users: 4 rows\nINSERT INTO users (id, email, phone) VALUES\n  (1, '")
+	assert.Contains(t, script, "-- orders: 7 rows\n")
+	assert.Equal(t, 3, strings.Count(script, "INSERT INTO"))
+	var again bytes.Buffer
+	assert.Equal(t, 0, run(args, &again, &stderr))
+	assert.Equal(t, script, again.String())
+	for _, args := range [][]string{{"sql"}, {"sql", "-ddl", filepath.Join(dir, "missing.sql")},
+		{"sql", "-ddl", path, "-table-rows", "users"}, {"sql", "-ddl", path, "-table-rows", "users=-1"},
+		{"sql", "-ddl", path, "-batch", "0"}} {
+		assert.Equal(t, 1, run(args, &stdout, &stderr), args)
+	}
+}
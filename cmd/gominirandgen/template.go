@@ -0,0 +1,73 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/niquefa/gominirandgen/randgen"
+)
+
+// Renders a text/template file with the random functions of the library, like randInt, randEmail or
+// choose, -count times with the same seeded generator, so every document is different but the whole
+// output is reproducible
+func runTemplate(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("template", flag.ContinueOnError)
+	path := flags.String("template", "", "text/template file")
+	dataPath := flags.String("data", "", "JSON file with the data of the template, its . value")
+	count := flags.Int("count", 1, "number of documents")
+	seed := flags.Int64("seed", 1, "seed of the documents, the same seed and template give the same documents")
+	separator := flags.String("separator", "\n", "text written after every document")
+	output := flags.String("output", "", "output file, with a verb like %03d it is one file per document, numbered from 1")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *path == "" || *count < 1 {
+		return fmt.Errorf("error, the template command needs a -template file and a positive -count")
+	}
+	text, err := ioutil.ReadFile(*path)
+	if err != nil {
+		return err
+	}
+	var data interface{}
+	if *dataPath != "" {
+		encoded, err := ioutil.ReadFile(*dataPath)
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(encoded, &data); err != nil {
+			return fmt.Errorf("error, the data file %s is not valid JSON: %v", *dataPath, err)
+		}
+	}
+	g := randgen.NewGenerator(*seed)
+	parsed, err := g.ParseTemplate(*path, string(text))
+	if err != nil {
+		return err
+	}
+	var all bytes.Buffer
+	for i := 0; i < *count; i++ {
+		var document bytes.Buffer
+		if err := parsed.Execute(&document, data); err != nil {
+			return err
+		}
+		document.WriteString(*separator)
+		if strings.Contains(*output, "%") {
+			if err := ioutil.WriteFile(fmt.Sprintf(*output, i+1), document.Bytes(), 0644); err != nil {
+				return err
+			}
+			continue
+		}
+		all.Write(document.Bytes())
+	}
+	switch {
+	case *output == "":
+		_, err = stdout.Write(all.Bytes())
+	case !strings.Contains(*output, "%"):
+		err = ioutil.WriteFile(*output, all.Bytes(), 0644)
+	}
+	return err
+}
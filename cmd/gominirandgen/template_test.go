@@ -0,0 +1,65 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testTemplate = `{"service": "{{.service}}", "replicas": {{randInt 1 5}}, "owner": "{{randEmail}}", ` +
+	`"hosts": [{{range $i := seq 3}}{{if $i}}, {{end}}"{{randString 4 8 "abcdef"}}"{{end}}]}`
+
+func TestTemplate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "template")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "config.tmpl")
+	assert.Nil(t, ioutil.WriteFile(path, []byte(testTemplate), 0644))
+	dataPath := filepath.Join(dir, "data.json")
+	assert.Nil(t, ioutil.WriteFile(dataPath, []byte(`{"service": "api"}`), 0644))
+	var stdout, stderr bytes.Buffer
+	args := []string{"template", "-template", path, "-data", dataPath, "-count", "5", "-seed", "3"}
+	assert.Equal(t, 0, run(args, &stdout, &stderr), stderr.String())
+	lines := strings.Split(strings.TrimSuffix(stdout.String(), "\n"), "\n")
+	assert.Len(t, lines, 5)
+	for _, line := range lines {
+		var document struct {
+			Service  string
+			Replicas int
+			Owner    string
+			Hosts    []string
+		}
+		assert.Nil(t, json.Unmarshal([]byte(line), &document), line)
+		assert.Equal(t, "api", document.Service)
+		assert.True(t, document.Replicas >= 1 && document.Replicas <= 5)
+		assert.Contains(t, document.Owner, "@")
+		assert.Len(t, document.Hosts, 3)
+	}
+	assert.NotEqual(t, lines[0], lines[1])
+	var again bytes.Buffer
+	assert.Equal(t, 0, run(args, &again, &stderr))
+	assert.Equal(t, stdout.String(), again.String())
+	pattern := filepath.Join(dir, "config-%02d.json")
+	assert.Equal(t, 0, run(append(args, "-output", pattern), &stdout, &stderr), stderr.String())
+	for i, line := range lines {
+		written, err := ioutil.ReadFile(filepath.Join(dir, "config-0"+string(rune('1'+i))+".json"))
+		assert.Nil(t, err)
+		assert.Equal(t, line+"\n", string(written))
+	}
+	single := filepath.Join(dir, "all.jsonl")
+	assert.Equal(t, 0, run(append(args, "-output", single), &stdout, &stderr), stderr.String())
+	written, err := ioutil.ReadFile(single)
+	assert.Nil(t, err)
+	assert.Equal(t, again.String(), string(written))
+	for _, args := range [][]string{{"template"}, {"template", "-template", path, "-count", "0"},
+		{"template", "-template", filepath.Join(dir, "missing.tmpl")}, {"template", "-template", path, "-data", path},
+		{"template", "-template", path}} {
+		assert.Equal(t, 1, run(args, &stdout, &stderr), args)
+	}
+}
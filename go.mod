@@ -1,5 +1,8 @@
-module github.com/gominirandgen
+module github.com/niquefa/gominirandgen
 
 go 1.12
 
-require github.com/stretchr/testify v1.3.0
+require (
+	github.com/stretchr/testify v1.4.0
+	gopkg.in/yaml.v2 v2.2.2
+)
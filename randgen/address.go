@@ -0,0 +1,190 @@
+package randgen
+
+import (
+	"fmt"
+	"strings"
+)
+
+// A part of an address complement, like Torre 2 or Apartamento 301
+type AddressComplement struct {
+	Type   string
+	Number string
+}
+
+// A structured Colombian address like Calle 45B Bis Sur # 12A - 30, Torre 2 Apartamento 301.
+// Use StreetLine, FreeForm or DIAN to render it.
+type Address struct {
+	StreetType   string // Calle, Carrera, Avenida, Diagonal...
+	StreetNumber int
+	StreetLetter string // optional letter suffix, like the B in Calle 45B
+	Bis          bool
+	Cardinal     string // "", Sur or Este
+	CrossNumber  int    // number of the crossing street, the one after '#'
+	CrossLetter  string
+	HouseNumber  int // distance from the corner, the one after '-'
+	Building     *AddressComplement
+	Unit         *AddressComplement
+	Neighborhood string
+	Municipality string
+	Department   string
+	DANECode     string // DANE code of the municipality, the first two digits are the department code
+}
+
+// Returns a letter suffix 30% of the times, an empty string otherwise
+func randomStreetLetter(g *Generator) string {
+	if g.Int(0, 9) < 3 {
+		return g.StringExactLength(1, colombianStreetLetters)
+	}
+	return ""
+}
+
+// Returns a pseudo random address in the given municipality
+func randomAddressIn(g *Generator, municipality colombianMunicipality) Address {
+	streetType, _ := g.ChooseString(colombianStreetTypes)
+	address := Address{
+		StreetType:   streetType,
+		StreetNumber: g.Int(1, 250),
+		StreetLetter: randomStreetLetter(g),
+		Bis:          g.Int(0, 9) == 0,
+		CrossNumber:  g.Int(1, 250),
+		CrossLetter:  randomStreetLetter(g),
+		HouseNumber:  g.Int(1, 99),
+		Municipality: municipality.Name,
+		Department:   municipality.Department,
+		DANECode:     municipality.DANECode,
+	}
+	if g.Int(0, 9) < 2 {
+		switch streetType {
+		case "Calle", "Avenida Calle", "Diagonal":
+			address.Cardinal = "Sur"
+		case "Carrera", "Avenida Carrera", "Transversal":
+			address.Cardinal = "Este"
+		default:
+			address.Cardinal, _ = g.ChooseString([]string{"Sur", "Este"})
+		}
+	}
+	if g.Int(0, 9) < 3 {
+		buildingType, _ := g.ChooseString(colombianBuildingTypes)
+		address.Building = &AddressComplement{buildingType, fmt.Sprintf("%d", g.Int(1, 12))}
+	}
+	if address.Building != nil || g.Int(0, 9) < 4 {
+		unitType, _ := g.ChooseString(colombianUnitTypes)
+		number := fmt.Sprintf("%d", g.Int(1, 30))
+		if unitType == "Apartamento" || unitType == "Oficina" {
+			number = fmt.Sprintf("%d%02d", g.Int(1, 25), g.Int(1, 8))
+		}
+		address.Unit = &AddressComplement{unitType, number}
+	}
+	neighborhoods := municipality.Neighborhoods
+	if len(neighborhoods) == 0 {
+		neighborhoods = commonNeighborhoods
+	}
+	address.Neighborhood, _ = g.ChooseString(neighborhoods)
+	return address
+}
+
+// Returns a pseudo random structured Colombian address in one of the municipalities of the dataset
+func RandomColombianAddress() Address {
+	return defaultGenerator.ColombianAddress()
+}
+
+// Returns a pseudo random structured Colombian address drawn from the generator, same as
+// RandomColombianAddress
+func (g *Generator) ColombianAddress() Address {
+	return randomAddressIn(g, colombianMunicipalities[g.Int(0, len(colombianMunicipalities)-1)])
+}
+
+// Returns a pseudo random structured Colombian address in the municipality with the given DANE code,
+// like "05001" for Medellín
+func RandomColombianAddressIn(daneCode string) (Address, error) {
+	return defaultGenerator.ColombianAddressIn(daneCode)
+}
+
+// Returns a pseudo random structured Colombian address in the municipality with the given DANE code drawn
+// from the generator, same as RandomColombianAddressIn
+func (g *Generator) ColombianAddressIn(daneCode string) (Address, error) {
+	for _, municipality := range colombianMunicipalities {
+		if municipality.DANECode == daneCode {
+			return randomAddressIn(g, municipality), nil
+		}
+	}
+	return Address{}, fmt.Errorf("error, invalid arguments in RandomColombianAddressIn(daneCode = %s)", daneCode)
+}
+
+// Returns the DANE codes of the municipalities known by RandomColombianAddressIn
+func ColombianDANECodes() []string {
+	codes := make([]string, 0, len(colombianMunicipalities))
+	for _, municipality := range colombianMunicipalities {
+		codes = append(codes, municipality.DANECode)
+	}
+	return codes
+}
+
+// Returns the street part of the address in the usual way, like Calle 45B Bis Sur # 12A - 30
+func (a Address) StreetLine() string {
+	line := fmt.Sprintf("%s %d%s", a.StreetType, a.StreetNumber, a.StreetLetter)
+	if a.Bis {
+		line += " Bis"
+	}
+	if a.Cardinal != "" {
+		line += " " + a.Cardinal
+	}
+	return line + fmt.Sprintf(" # %d%s - %d", a.CrossNumber, a.CrossLetter, a.HouseNumber)
+}
+
+// Returns the complements of the address, like Torre 2 Apartamento 301
+func (a Address) complements(abbreviate bool) []string {
+	parts := make([]string, 0, 4)
+	for _, complement := range []*AddressComplement{a.Building, a.Unit} {
+		if complement == nil {
+			continue
+		}
+		kind := complement.Type
+		if abbreviate {
+			kind = dianAbbreviations[kind]
+		}
+		parts = append(parts, kind, complement.Number)
+	}
+	return parts
+}
+
+// Returns the whole address as a person would write it, like
+// Calle 45B Sur # 12A - 30, Torre 2 Apartamento 301, Chapinero, Bogotá, D.C.
+func (a Address) FreeForm() string {
+	parts := []string{a.StreetLine()}
+	if complements := a.complements(false); len(complements) > 0 {
+		parts = append(parts, strings.Join(complements, " "))
+	}
+	parts = append(parts, a.Neighborhood, a.Municipality)
+	if a.Department != a.Municipality {
+		parts = append(parts, a.Department)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Returns the address as FreeForm does, so it prints well in templates and fmt
+func (a Address) String() string {
+	return a.FreeForm()
+}
+
+// Returns the address in the normalized form the DIAN uses in the RUT, upper case abbreviations
+// separated by single spaces and no '#' or '-', like CL 45 B BIS SUR 12 A 30 TO 2 AP 301
+func (a Address) DIAN() string {
+	parts := []string{dianAbbreviations[a.StreetType], fmt.Sprintf("%d", a.StreetNumber)}
+	if a.StreetLetter != "" {
+		parts = append(parts, a.StreetLetter)
+	}
+	if a.Bis {
+		parts = append(parts, dianAbbreviations["Bis"])
+	}
+	if a.Cardinal != "" {
+		parts = append(parts, dianAbbreviations[a.Cardinal])
+	}
+	parts = append(parts, fmt.Sprintf("%d", a.CrossNumber))
+	if a.CrossLetter != "" {
+		parts = append(parts, a.CrossLetter)
+	}
+	parts = append(parts, fmt.Sprintf("%d", a.HouseNumber))
+	parts = append(parts, a.complements(true)...)
+	return strings.Join(parts, " ")
+}
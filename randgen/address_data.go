@@ -0,0 +1,86 @@
+package randgen
+
+// A Colombian municipality with its DANE code and some of its neighborhoods (barrios)
+type colombianMunicipality struct {
+	DANECode      string
+	Name          string
+	Department    string
+	Neighborhoods []string
+}
+
+// Neighborhood names common to most Colombian towns, used for municipalities without their own list
+var commonNeighborhoods = []string{
+	"Centro", "La Esperanza", "San José", "El Prado", "Villa del Río", "Los Álamos", "La Floresta",
+	"Santa Mónica", "El Bosque", "Las Américas", "San Fernando", "La Victoria", "El Carmen", "Los Pinos",
+	"Villa Nueva", "San Martín", "El Recreo", "La Pradera", "Los Almendros", "Santa Lucía",
+}
+
+// Embedded dataset of municipalities, the department DANE code is the first two digits of the code
+var colombianMunicipalities = []colombianMunicipality{
+	{"11001", "Bogotá, D.C.", "Bogotá, D.C.", []string{
+		"Chapinero", "Usaquén", "Teusaquillo", "La Candelaria", "Kennedy", "Suba", "Cedritos", "Modelia",
+		"Galerías", "El Chicó", "Santa Bárbara", "Fontibón", "Engativá", "Bosa", "Palermo", "Quinta Camacho"}},
+	{"05001", "Medellín", "Antioquia", []string{
+		"El Poblado", "Laureles", "Belén", "Robledo", "Buenos Aires", "Manrique", "La América", "Estadio",
+		"Boston", "Prado", "Castilla", "Aranjuez"}},
+	{"05088", "Bello", "Antioquia", nil},
+	{"05266", "Envigado", "Antioquia", []string{"Zúñiga", "La Magnolia", "El Dorado", "Alcalá", "Las Vegas"}},
+	{"05360", "Itagüí", "Antioquia", nil},
+	{"05615", "Rionegro", "Antioquia", nil},
+	{"76001", "Cali", "Valle del Cauca", []string{
+		"San Fernando", "Granada", "El Peñón", "Ciudad Jardín", "Santa Mónica", "El Ingenio", "Tequendama",
+		"San Antonio", "Versalles", "Pance", "El Limonar", "Alameda"}},
+	{"76520", "Palmira", "Valle del Cauca", nil},
+	{"76109", "Buenaventura", "Valle del Cauca", nil},
+	{"08001", "Barranquilla", "Atlántico", []string{
+		"El Prado", "Riomar", "Alto Prado", "Boston", "Villa Country", "Ciudad Jardín", "Las Delicias",
+		"Villa Santos", "Paraíso"}},
+	{"08758", "Soledad", "Atlántico", nil},
+	{"13001", "Cartagena de Indias", "Bolívar", []string{
+		"Bocagrande", "Getsemaní", "Manga", "Castillogrande", "Crespo", "El Cabrero", "Pie de la Popa",
+		"El Laguito", "San Diego"}},
+	{"68001", "Bucaramanga", "Santander", []string{"Cabecera del Llano", "Sotomayor", "La Aurora", "San Alonso", "Provenza"}},
+	{"68276", "Floridablanca", "Santander", nil},
+	{"54001", "San José de Cúcuta", "Norte de Santander", []string{"Caobos", "La Riviera", "Quinta Oriental", "Prados del Este"}},
+	{"66001", "Pereira", "Risaralda", []string{"Pinares", "Álamos", "Cuba", "Boston", "El Jardín"}},
+	{"66170", "Dosquebradas", "Risaralda", nil},
+	{"17001", "Manizales", "Caldas", []string{"Palermo", "Chipre", "La Francia", "Milán", "Versalles"}},
+	{"63001", "Armenia", "Quindío", nil},
+	{"47001", "Santa Marta", "Magdalena", []string{"El Rodadero", "Bavaria", "Los Almendros", "Pescaíto"}},
+	{"73001", "Ibagué", "Tolima", nil},
+	{"50001", "Villavicencio", "Meta", nil},
+	{"52001", "Pasto", "Nariño", nil},
+	{"23001", "Montería", "Córdoba", nil},
+	{"41001", "Neiva", "Huila", nil},
+	{"19001", "Popayán", "Cauca", nil},
+	{"15001", "Tunja", "Boyacá", nil},
+	{"15759", "Sogamoso", "Boyacá", nil},
+	{"20001", "Valledupar", "Cesar", nil},
+	{"70001", "Sincelejo", "Sucre", nil},
+	{"44001", "Riohacha", "La Guajira", nil},
+	{"27001", "Quibdó", "Chocó", nil},
+	{"18001", "Florencia", "Caquetá", nil},
+	{"85001", "Yopal", "Casanare", nil},
+	{"25754", "Soacha", "Cundinamarca", nil},
+	{"25175", "Chía", "Cundinamarca", nil},
+	{"25899", "Zipaquirá", "Cundinamarca", nil},
+	{"25290", "Fusagasugá", "Cundinamarca", nil},
+	{"88001", "San Andrés", "Archipiélago de San Andrés, Providencia y Santa Catalina", nil},
+}
+
+// Street types and their DIAN abbreviations
+var colombianStreetTypes = []string{"Calle", "Carrera", "Avenida", "Avenida Calle", "Avenida Carrera", "Diagonal", "Transversal"}
+
+// Abbreviations of the DIAN normalized address form
+var dianAbbreviations = map[string]string{
+	"Calle": "CL", "Carrera": "KR", "Avenida": "AV", "Avenida Calle": "AC", "Avenida Carrera": "AK",
+	"Diagonal": "DG", "Transversal": "TV", "Torre": "TO", "Bloque": "BL", "Interior": "IN",
+	"Apartamento": "AP", "Casa": "CA", "Oficina": "OF", "Local": "LC", "Bis": "BIS", "Sur": "SUR", "Este": "ESTE",
+}
+
+// Building and unit types of address complements
+var colombianBuildingTypes = []string{"Torre", "Bloque", "Interior"}
+var colombianUnitTypes = []string{"Apartamento", "Apartamento", "Apartamento", "Casa", "Oficina", "Local"}
+
+// Letters used as suffix of street and cross numbers, like the B in Calle 45B, any letter of the alphabet
+var colombianStreetLetters = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
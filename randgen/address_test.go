@@ -0,0 +1,80 @@
+package randgen
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRandomColombianAddress(t *testing.T) {
+	for test := 0; test < 1000; test++ {
+		address := RandomColombianAddress()
+		assert.Contains(t, colombianStreetTypes, address.StreetType)
+		assert.Regexp(t, `^\d{5}$`, address.DANECode)
+		assert.NotEmpty(t, address.Neighborhood)
+		assert.NotEmpty(t, address.Municipality)
+		assert.NotEmpty(t, address.Department)
+		assert.Regexp(t, `^[A-Za-z ]+ \d+[A-Z]?( Bis)?( Sur| Este)? # \d+[A-Z]? - \d+$`, address.StreetLine())
+		assert.Regexp(t, `^(CL|KR|AV|AC|AK|DG|TV) \d+( [A-Z])?( BIS)?( SUR| ESTE)? \d+( [A-Z])? \d+`+
+			`( (TO|BL|IN) \d+)?( (AP|CA|OF|LC) \d+)?$`, address.DIAN())
+		assert.True(t, strings.HasPrefix(address.FreeForm(), address.StreetLine()+", "))
+		assert.True(t, strings.Contains(address.FreeForm(), address.Municipality))
+		if address.Building != nil {
+			assert.NotNil(t, address.Unit)
+		}
+	}
+}
+
+func TestRandomColombianAddressIn(t *testing.T) {
+	for _, code := range ColombianDANECodes() {
+		address, err := RandomColombianAddressIn(code)
+		assert.Nil(t, err)
+		assert.Equal(t, code, address.DANECode)
+	}
+	address, err := RandomColombianAddressIn("11001")
+	assert.Nil(t, err)
+	assert.Equal(t, "Bogotá, D.C.", address.Municipality)
+	assert.Equal(t, 1, strings.Count(address.FreeForm(), "Bogotá, D.C."))
+	_, err = RandomColombianAddressIn("99999")
+	assert.NotNil(t, err)
+}
+
+func TestAddressRendering(t *testing.T) {
+	address := Address{
+		StreetType: "Calle", StreetNumber: 45, StreetLetter: "B", Bis: true, Cardinal: "Sur",
+		CrossNumber: 12, CrossLetter: "A", HouseNumber: 30,
+		Building: &AddressComplement{"Torre", "2"}, Unit: &AddressComplement{"Apartamento", "301"},
+		Neighborhood: "Chapinero", Municipality: "Bogotá, D.C.", Department: "Bogotá, D.C.", DANECode: "11001",
+	}
+	assert.Equal(t, "Calle 45B Bis Sur # 12A - 30", address.StreetLine())
+	assert.Equal(t, "CL 45 B BIS SUR 12 A 30 TO 2 AP 301", address.DIAN())
+	assert.Equal(t, "Calle 45B Bis Sur # 12A - 30, Torre 2 Apartamento 301, Chapinero, Bogotá, D.C.", address.FreeForm())
+	assert.Equal(t, address.FreeForm(), address.String())
+}
+
+func TestColombianStreetLetters(t *testing.T) {
+	g := NewGenerator(8)
+	seen := make(map[string]bool)
+	for test := 0; test < 5000; test++ {
+		address := g.ColombianAddress()
+		seen[address.StreetLetter] = true
+		seen[address.CrossLetter] = true
+	}
+	for _, letter := range colombianStreetLetters {
+		assert.True(t, seen[string(letter)], string(letter))
+	}
+}
+
+func TestGeneratorColombianAddress(t *testing.T) {
+	for seed := int64(0); seed < 50; seed++ {
+		assert.Equal(t, NewGenerator(seed).ColombianAddress(), NewGenerator(seed).ColombianAddress())
+		first, err := NewGenerator(seed).ColombianAddressIn("05001")
+		assert.Nil(t, err)
+		second, _ := NewGenerator(seed).ColombianAddressIn("05001")
+		assert.Equal(t, first, second)
+		assert.Equal(t, "05001", first.DANECode)
+	}
+	_, err := NewGenerator(1).ColombianAddressIn("99999")
+	assert.NotNil(t, err)
+}
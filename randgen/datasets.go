@@ -0,0 +1,266 @@
+package randgen
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+)
+
+// A synthetic dataset: a feature matrix with one row per sample and the label of every sample, the
+// class index for classification datasets or the target value for regression datasets
+type Dataset struct {
+	FeatureNames []string
+	LabelName    string
+	Features     [][]float64
+	Labels       []float64
+}
+
+// Returns the number of samples
+func (d Dataset) Len() int {
+	return len(d.Labels)
+}
+
+// Writes the dataset as CSV, a header with the feature names and the label name and then one row per sample
+func (d Dataset) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(append(append([]string(nil), d.FeatureNames...), d.LabelName)); err != nil {
+		return err
+	}
+	row := make([]string, len(d.FeatureNames)+1)
+	for i, features := range d.Features {
+		for j, feature := range features {
+			row[j] = strconv.FormatFloat(feature, 'g', -1, 64)
+		}
+		row[len(features)] = strconv.FormatFloat(d.Labels[i], 'g', -1, 64)
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// Writes the dataset as CSV in the file of the given path
+func (d Dataset) SaveCSV(path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	buffered := bufio.NewWriter(file)
+	if err := d.WriteCSV(buffered); err != nil {
+		file.Close()
+		return err
+	}
+	if err := buffered.Flush(); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// Returns a dataset with 'dimensions' features named x1, x2, ... and room for the samples
+func newDataset(samples, dimensions int, labelName string) Dataset {
+	names := make([]string, dimensions)
+	for i := range names {
+		names[i] = fmt.Sprintf("x%d", i+1)
+	}
+	return Dataset{
+		FeatureNames: names, LabelName: labelName,
+		Features: make([][]float64, 0, samples), Labels: make([]float64, 0, samples),
+	}
+}
+
+// Returns the number of samples of every class: proportional to the weights, or the same for all the
+// classes if weights is nil, so imbalanced datasets have exact class ratios
+func classCounts(samples, classes int, weights []float64) ([]int, error) {
+	if samples < 1 {
+		return nil, fmt.Errorf("error, the dataset needs at least one sample, got %d", samples)
+	}
+	if weights == nil {
+		weights = make([]float64, classes)
+		for i := range weights {
+			weights[i] = 1
+		}
+	}
+	if len(weights) != classes {
+		return nil, fmt.Errorf("error, there must be %d class weights, got %d", classes, len(weights))
+	}
+	sum := 0.0
+	for _, weight := range weights {
+		if weight < 0 {
+			return nil, fmt.Errorf("error, negative class weight %v", weight)
+		}
+		sum += weight
+	}
+	if sum <= 0 {
+		return nil, fmt.Errorf("error, the class weights %v add up to zero", weights)
+	}
+	fractions := make([]float64, classes)
+	for i, weight := range weights {
+		fractions[i] = weight / sum
+	}
+	return sizesFromFractions(samples, fractions, nil), nil
+}
+
+// Shuffles the samples so the classes are not in blocks
+func (g *Generator) shuffleDataset(d Dataset) Dataset {
+	features := make([][]float64, len(d.Features))
+	labels := make([]float64, len(d.Labels))
+	for i, j := range g.Perm(len(d.Labels)) {
+		features[i] = d.Features[j]
+		labels[i] = d.Labels[j]
+	}
+	d.Features, d.Labels = features, labels
+	return d
+}
+
+// Returns Gaussian blobs: the samples of class i are normally distributed around centers[i] with the
+// given standard deviation in every dimension. Weights (nil for balanced classes) set the class ratios.
+func (g *Generator) Blobs(samples int, centers [][]float64, standardDeviation float64, weights []float64) (Dataset, error) {
+	if len(centers) < 1 || len(centers[0]) < 1 || standardDeviation < 0 {
+		return Dataset{}, fmt.Errorf("error, invalid arguments in Blobs(len(centers) = %d, standardDeviation = %v)",
+			len(centers), standardDeviation)
+	}
+	counts, err := classCounts(samples, len(centers), weights)
+	if err != nil {
+		return Dataset{}, err
+	}
+	dataset := newDataset(samples, len(centers[0]), "label")
+	for class, center := range centers {
+		if len(center) != len(centers[0]) {
+			return Dataset{}, fmt.Errorf("error, all the centers must have %d dimensions", len(centers[0]))
+		}
+		for i := 0; i < counts[class]; i++ {
+			point := make([]float64, len(center))
+			for j, coordinate := range center {
+				point[j] = g.Normal(coordinate, standardDeviation)
+			}
+			dataset.Features = append(dataset.Features, point)
+			dataset.Labels = append(dataset.Labels, float64(class))
+		}
+	}
+	return g.shuffleDataset(dataset), nil
+}
+
+// Returns two interleaving half circles in two dimensions, class 0 is the upper moon and class 1 the
+// lower one, with normal noise of the given standard deviation. Weights (nil for balanced classes) set
+// the class ratios.
+func (g *Generator) Moons(samples int, noise float64, weights []float64) (Dataset, error) {
+	counts, err := classCounts(samples, 2, weights)
+	if err != nil || noise < 0 {
+		return Dataset{}, fmt.Errorf("error, invalid arguments in Moons(samples = %d, noise = %v, weights = %v)",
+			samples, noise, weights)
+	}
+	dataset := newDataset(samples, 2, "label")
+	for class, count := range counts {
+		for i := 0; i < count; i++ {
+			angle := g.Uniform(0, math.Pi)
+			x, y := math.Cos(angle), math.Sin(angle)
+			if class == 1 {
+				x, y = 1-x, 0.5-y
+			}
+			dataset.Features = append(dataset.Features, []float64{g.Normal(x, noise), g.Normal(y, noise)})
+			dataset.Labels = append(dataset.Labels, float64(class))
+		}
+	}
+	return g.shuffleDataset(dataset), nil
+}
+
+// Returns two concentric circles in two dimensions, class 0 is the outer circle of radius 1 and class 1
+// the inner one of radius 'factor', in (0,1), with normal noise of the given standard deviation.
+// Weights (nil for balanced classes) set the class ratios.
+func (g *Generator) Circles(samples int, noise, factor float64, weights []float64) (Dataset, error) {
+	counts, err := classCounts(samples, 2, weights)
+	if err != nil || noise < 0 || factor <= 0 || factor >= 1 {
+		return Dataset{}, fmt.Errorf("error, invalid arguments in Circles(samples = %d, noise = %v, factor = %v, weights = %v)",
+			samples, noise, factor, weights)
+	}
+	dataset := newDataset(samples, 2, "label")
+	for class, count := range counts {
+		radius := 1.0
+		if class == 1 {
+			radius = factor
+		}
+		for i := 0; i < count; i++ {
+			angle := g.Uniform(0, 2*math.Pi)
+			dataset.Features = append(dataset.Features,
+				[]float64{g.Normal(radius*math.Cos(angle), noise), g.Normal(radius*math.Sin(angle), noise)})
+			dataset.Labels = append(dataset.Labels, float64(class))
+		}
+	}
+	return g.shuffleDataset(dataset), nil
+}
+
+// Returns the XOR problem in two dimensions: the points are uniform in [-1,1)x[-1,1), class 0 when both
+// coordinates have the same sign and class 1 otherwise, with normal noise of the given standard deviation
+// added after labelling. Weights (nil for balanced classes) set the class ratios.
+func (g *Generator) XOR(samples int, noise float64, weights []float64) (Dataset, error) {
+	counts, err := classCounts(samples, 2, weights)
+	if err != nil || noise < 0 {
+		return Dataset{}, fmt.Errorf("error, invalid arguments in XOR(samples = %d, noise = %v, weights = %v)",
+			samples, noise, weights)
+	}
+	dataset := newDataset(samples, 2, "label")
+	for class, count := range counts {
+		for i := 0; i < count; i++ {
+			x, y := g.Uniform(0, 1), g.Uniform(0, 1)
+			if g.Bernoulli(0.5) {
+				x = -x
+				if class == 0 {
+					y = -y
+				}
+			} else if class == 1 {
+				y = -y
+			}
+			dataset.Features = append(dataset.Features, []float64{g.Normal(x, noise), g.Normal(y, noise)})
+			dataset.Labels = append(dataset.Labels, float64(class))
+		}
+	}
+	return g.shuffleDataset(dataset), nil
+}
+
+// Returns a linear regression dataset: the features are standard normal and the target is
+// intercept + sum(coefficients[i] * x[i]) plus normal noise of the given standard deviation
+func (g *Generator) LinearRegression(samples int, coefficients []float64, intercept, noise float64) (Dataset, error) {
+	if samples < 1 || len(coefficients) < 1 || noise < 0 {
+		return Dataset{}, fmt.Errorf("error, invalid arguments in LinearRegression(samples = %d, coefficients = %v, noise = %v)",
+			samples, coefficients, noise)
+	}
+	dataset := newDataset(samples, len(coefficients), "target")
+	for i := 0; i < samples; i++ {
+		features := make([]float64, len(coefficients))
+		target := intercept
+		for j, coefficient := range coefficients {
+			features[j] = g.NormFloat64()
+			target += coefficient * features[j]
+		}
+		dataset.Features = append(dataset.Features, features)
+		dataset.Labels = append(dataset.Labels, g.Normal(target, noise))
+	}
+	return dataset, nil
+}
+
+// Returns a polynomial regression dataset with one feature x uniform in [minX,maxX) and the target
+// coefficients[0] + coefficients[1]*x + coefficients[2]*x^2 + ... plus normal noise of the given
+// standard deviation
+func (g *Generator) PolynomialRegression(samples int, coefficients []float64, minX, maxX, noise float64) (Dataset, error) {
+	if samples < 1 || len(coefficients) < 1 || maxX <= minX || noise < 0 {
+		return Dataset{}, fmt.Errorf("error, invalid arguments in PolynomialRegression(samples = %d, coefficients = %v, minX = %v, maxX = %v, noise = %v)",
+			samples, coefficients, minX, maxX, noise)
+	}
+	dataset := newDataset(samples, 1, "target")
+	for i := 0; i < samples; i++ {
+		x := g.Uniform(minX, maxX)
+		target := 0.0
+		for j := len(coefficients) - 1; j >= 0; j-- {
+			target = target*x + coefficients[j]
+		}
+		dataset.Features = append(dataset.Features, []float64{x})
+		dataset.Labels = append(dataset.Labels, g.Normal(target, noise))
+	}
+	return dataset, nil
+}
@@ -0,0 +1,124 @@
+package randgen
+
+import (
+	"bytes"
+	"encoding/csv"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Returns the number of samples of every class
+func countLabels(d Dataset) map[float64]int {
+	counts := make(map[float64]int)
+	for _, label := range d.Labels {
+		counts[label]++
+	}
+	return counts
+}
+
+func TestBlobs(t *testing.T) {
+	g := NewGenerator(1)
+	centers := [][]float64{{0, 0, 0}, {10, 10, 10}, {-10, 5, 0}}
+	dataset, err := g.Blobs(1000, centers, 0.5, []float64{0.8, 0.15, 0.05})
+	assert.Nil(t, err)
+	assert.Equal(t, 1000, dataset.Len())
+	assert.Equal(t, []string{"x1", "x2", "x3"}, dataset.FeatureNames)
+	assert.Equal(t, map[float64]int{0: 800, 1: 150, 2: 50}, countLabels(dataset))
+	for i, point := range dataset.Features {
+		center := centers[int(dataset.Labels[i])]
+		for j := range point {
+			assert.InDelta(t, center[j], point[j], 4)
+		}
+	}
+	again, _ := NewGenerator(1).Blobs(1000, centers, 0.5, []float64{0.8, 0.15, 0.05})
+	assert.Equal(t, dataset, again)
+	_, err = g.Blobs(10, [][]float64{{0}, {1, 2}}, 1, nil)
+	assert.NotNil(t, err)
+	_, err = g.Blobs(10, centers, 1, []float64{1, 1})
+	assert.NotNil(t, err)
+}
+
+func TestMoonsCirclesXOR(t *testing.T) {
+	g := NewGenerator(2)
+	moons, err := g.Moons(500, 0, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, map[float64]int{0: 250, 1: 250}, countLabels(moons))
+	for i, point := range moons.Features {
+		if moons.Labels[i] == 0 {
+			assert.InDelta(t, 1, math.Hypot(point[0], point[1]), 1e-9)
+		} else {
+			assert.InDelta(t, 1, math.Hypot(point[0]-1, point[1]-0.5), 1e-9)
+		}
+	}
+	circles, err := g.Circles(400, 0, 0.3, []float64{3, 1})
+	assert.Nil(t, err)
+	assert.Equal(t, map[float64]int{0: 300, 1: 100}, countLabels(circles))
+	for i, point := range circles.Features {
+		radius := 1.0
+		if circles.Labels[i] == 1 {
+			radius = 0.3
+		}
+		assert.InDelta(t, radius, math.Hypot(point[0], point[1]), 1e-9)
+	}
+	xor, err := g.XOR(1000, 0, nil)
+	assert.Nil(t, err)
+	for i, point := range xor.Features {
+		assert.Equal(t, point[0]*point[1] < 0, xor.Labels[i] == 1)
+	}
+	_, err = g.Circles(10, 0, 1, nil)
+	assert.NotNil(t, err)
+	_, err = g.Moons(0, 0, nil)
+	assert.NotNil(t, err)
+}
+
+func TestRegression(t *testing.T) {
+	g := NewGenerator(3)
+	linear, err := g.LinearRegression(200, []float64{2, -1}, 5, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, "target", linear.LabelName)
+	for i, x := range linear.Features {
+		assert.InDelta(t, 5+2*x[0]-x[1], linear.Labels[i], 1e-9)
+	}
+	polynomial, err := g.PolynomialRegression(200, []float64{1, 0, -3}, -2, 2, 0)
+	assert.Nil(t, err)
+	for i, x := range polynomial.Features {
+		assert.InDelta(t, 1-3*x[0]*x[0], polynomial.Labels[i], 1e-9)
+	}
+	noisy, err := g.LinearRegression(5000, []float64{1}, 0, 2)
+	assert.Nil(t, err)
+	squares := 0.0
+	for i, x := range noisy.Features {
+		squares += (noisy.Labels[i] - x[0]) * (noisy.Labels[i] - x[0])
+	}
+	assert.InDelta(t, 2, math.Sqrt(squares/5000), 0.1)
+	_, err = g.PolynomialRegression(10, []float64{1}, 1, 1, 0)
+	assert.NotNil(t, err)
+}
+
+func TestDatasetCSV(t *testing.T) {
+	dataset, err := NewGenerator(4).Blobs(10, [][]float64{{0, 1}, {2, 3}}, 1, nil)
+	assert.Nil(t, err)
+	var buffer bytes.Buffer
+	assert.Nil(t, dataset.WriteCSV(&buffer))
+	rows, err := csv.NewReader(&buffer).ReadAll()
+	assert.Nil(t, err)
+	assert.Len(t, rows, 11)
+	assert.Equal(t, []string{"x1", "x2", "label"}, rows[0])
+	assert.Contains(t, []string{"0", "1"}, rows[1][2])
+
+	dir, err := ioutil.TempDir("", "datasets")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "blobs.csv")
+	assert.Nil(t, dataset.SaveCSV(path))
+	content, err := ioutil.ReadFile(path)
+	assert.Nil(t, err)
+	var expected bytes.Buffer
+	assert.Nil(t, dataset.WriteCSV(&expected))
+	assert.Equal(t, expected.String(), string(content))
+}
@@ -0,0 +1,80 @@
+package randgen
+
+import (
+	"fmt"
+	"math"
+)
+
+// Returns a pseudo random number uniformly distributed in [minValue,maxValue), unlike Float64 the bounds
+// may be negative
+func (g *Generator) Uniform(minValue, maxValue float64) float64 {
+	if maxValue <= minValue {
+		panic(fmt.Sprintf("Error, invalid arguments in Generator.Uniform(%v,%v) function.", minValue, maxValue))
+	}
+	return minValue + (maxValue-minValue)*g.Float64(0, 1)
+}
+
+// Returns a pseudo random number with normal distribution of the given mean and standard deviation
+func (g *Generator) Normal(mean, standardDeviation float64) float64 {
+	if standardDeviation < 0 {
+		panic(fmt.Sprintf("Error, invalid arguments in Generator.Normal(%v,%v) function.", mean, standardDeviation))
+	}
+	return mean + standardDeviation*g.NormFloat64()
+}
+
+// Returns a pseudo random number with log-normal distribution, its logarithm is normal with the given
+// mean and standard deviation
+func (g *Generator) LogNormal(mean, standardDeviation float64) float64 {
+	return math.Exp(g.Normal(mean, standardDeviation))
+}
+
+// Returns a pseudo random number with exponential distribution of the given rate (mean 1/rate)
+func (g *Generator) Exponential(rate float64) float64 {
+	if rate <= 0 {
+		panic(fmt.Sprintf("Error, invalid arguments in Generator.Exponential(%v) function.", rate))
+	}
+	return -math.Log(1-g.Float64(0, 1)) / rate
+}
+
+// Returns true with probability p
+func (g *Generator) Bernoulli(p float64) bool {
+	return g.Float64(0, 1) < p
+}
+
+// Returns the index i with probability weights[i]/sum(weights), the weights must not be negative and at
+// least one must be positive
+func (g *Generator) Categorical(weights []float64) (int, error) {
+	sum := 0.0
+	for _, weight := range weights {
+		if weight < 0 {
+			return 0, fmt.Errorf("error, negative weight %v", weight)
+		}
+		sum += weight
+	}
+	if sum <= 0 {
+		return 0, fmt.Errorf("error, invalid arguments in Generator.Categorical(weights = %v)", weights)
+	}
+	draw := g.Float64(0, sum)
+	for i, weight := range weights {
+		if draw < weight {
+			return i, nil
+		}
+		draw -= weight
+	}
+	// only reached by rounding errors, return the last index with positive weight
+	for i := len(weights) - 1; ; i-- {
+		if weights[i] > 0 {
+			return i, nil
+		}
+	}
+}
+
+// Returns a pseudo random number with normal distribution of the given mean and standard deviation
+func RandomNormal(mean, standardDeviation float64) float64 {
+	return defaultGenerator.Normal(mean, standardDeviation)
+}
+
+// Returns a pseudo random number uniformly distributed in [minValue,maxValue), the bounds may be negative
+func RandomUniform(minValue, maxValue float64) float64 {
+	return defaultGenerator.Uniform(minValue, maxValue)
+}
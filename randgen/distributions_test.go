@@ -0,0 +1,63 @@
+package randgen
+
+import (
+	"math"
+	"testing"
+
+	"github.com/niquefa/gominirandgen/stats"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDistributions(t *testing.T) {
+	g := NewGenerator(qualityTestSeed)
+	uniform := make([]float64, 10000)
+	normal := make([]float64, 10000)
+	logNormal := make([]float64, 10000)
+	exponential := make([]float64, 10000)
+	for i := range uniform {
+		uniform[i] = g.Uniform(-3, 2)
+		normal[i] = g.Normal(-5, 0.5)
+		logNormal[i] = math.Log(g.LogNormal(1, 2))
+		exponential[i] = g.Exponential(0.5)
+	}
+	for _, test := range []struct {
+		sample []float64
+		cdf    func(float64) float64
+	}{
+		{uniform, stats.UniformCDF(-3, 2)},
+		{normal, stats.NormalCDF(-5, 0.5)},
+		{logNormal, stats.NormalCDF(1, 2)},
+		{exponential, stats.ExponentialCDF(0.5)},
+	} {
+		result, err := stats.KolmogorovSmirnov(test.sample, test.cdf)
+		assert.Nil(t, err)
+		assert.True(t, result.Passed(stats.DefaultAlpha), result.String())
+	}
+	assert.Panics(t, func() { g.Uniform(1, 1) })
+	assert.Panics(t, func() { g.Exponential(0) })
+	assert.GreaterOrEqual(t, RandomUniform(-1, 0), -1.0)
+	assert.False(t, math.IsNaN(RandomNormal(0, 1)))
+}
+
+func TestCategorical(t *testing.T) {
+	g := NewGenerator(qualityTestSeed)
+	counts := make([]int, 4)
+	trues := 0
+	for i := 0; i < 10000; i++ {
+		index, err := g.Categorical([]float64{1, 0, 3, 6})
+		assert.Nil(t, err)
+		counts[index]++
+		if g.Bernoulli(0.3) {
+			trues++
+		}
+	}
+	assert.Equal(t, 0, counts[1])
+	result, err := stats.ChiSquare([]int{counts[0], counts[2], counts[3]}, []float64{1000, 3000, 6000})
+	assert.Nil(t, err)
+	assert.True(t, result.Passed(stats.DefaultAlpha), result.String())
+	assert.InDelta(t, 3000, trues, 250)
+	_, err = g.Categorical([]float64{0, 0})
+	assert.NotNil(t, err)
+	_, err = g.Categorical([]float64{1, -1})
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,169 @@
+package randgen
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// Era in which a cédula de ciudadanía was issued, the number of digits depends on it
+type CedulaEra int
+
+const (
+	CedulaAnyEra     CedulaEra = iota
+	CedulaBefore1990           // 6 to 8 digits, issued by the old registry
+	Cedula1990To2003           // 8 digits, from 10.000.000 to 99.999.999
+	CedulaSince2004            // 10 digits, starting by 1 (1.000.000.000 and above)
+)
+
+// Primes used by the DIAN to compute the NIT check digit, from the rightmost digit to the left
+var nitWeights = []int{3, 7, 13, 17, 19, 23, 29, 37, 41, 43, 47, 53, 59, 67, 71}
+
+var onlyDigits = regexp.MustCompile(`^[0-9]+$`)
+var passportCOL = regexp.MustCompile(`^[A-Z]{2}[0-9]{6,7}$`)
+
+// Returns a pseudo random cédula de ciudadanía number issued in the given era
+func RandomCedula(era CedulaEra) string {
+	if era == CedulaAnyEra {
+		era = CedulaEra(RandomInt(int(CedulaBefore1990), int(CedulaSince2004)))
+	}
+	switch era {
+	case CedulaBefore1990:
+		return randomDigitsNoLeadingZero(RandomInt(6, 8))
+	case Cedula1990To2003:
+		return randomDigitsNoLeadingZero(8)
+	case CedulaSince2004:
+		return "10" + RandomStringExactLength(8, "0123456789")
+	}
+	panic(fmt.Sprintf("Error, invalid arguments in RandomCedula(era = %d)", era))
+}
+
+// Returns a pseudo random tarjeta de identidad number, the document of people between 7 and 17 years old.
+// Current ones have 10 digits and start by 1.
+func RandomTarjetaIdentidad() string {
+	return "1" + RandomStringExactLength(9, "0123456789")
+}
+
+// Returns a pseudo random cédula de extranjería number, 6 or 7 digits
+func RandomCedulaExtranjeria() string {
+	return randomDigitsNoLeadingZero(RandomInt(6, 7))
+}
+
+// Returns a pseudo random Colombian passport number, two letters followed by 6 or 7 digits
+func RandomPassportCOL() string {
+	return RandomStringExactLength(2, upperAll) + RandomStringExactLength(RandomInt(6, 7), "0123456789")
+}
+
+// Returns the DIAN check digit (dígito de verificación) of the given NIT number without the check digit.
+// Returns an error if the number is not made of 1 to 15 digits.
+func NITCheckDigit(nit string) (int, error) {
+	if !onlyDigits.MatchString(nit) || len(nit) > len(nitWeights) {
+		return 0, fmt.Errorf("error, invalid arguments in NITCheckDigit(nit = %s)", nit)
+	}
+	sum := 0
+	for i := 0; i < len(nit); i++ {
+		sum += int(nit[len(nit)-1-i]-'0') * nitWeights[i]
+	}
+	remainder := sum % 11
+	if remainder > 1 {
+		return 11 - remainder, nil
+	}
+	return remainder, nil
+}
+
+// Returns a pseudo random NIT of a company, 9 digits starting by 8 or 9, and its check digit
+func RandomNIT() (string, int) {
+	nit := RandomStringExactLength(1, "89") + RandomStringExactLength(8, "0123456789")
+	digit, _ := NITCheckDigit(nit)
+	return nit, digit
+}
+
+// Returns a pseudo random NIT with its check digit in the usual form, like 900123456-7
+func RandomNITWithCheckDigit() string {
+	nit, digit := RandomNIT()
+	return fmt.Sprintf("%s-%d", nit, digit)
+}
+
+// Returns a pseudo random RUT of a person, the RUT number of a person is its cédula with the NIT check
+// digit, like 1012345678-3
+func RandomRUT() string {
+	cedula := RandomCedula(CedulaAnyEra)
+	digit, _ := NITCheckDigit(cedula)
+	return fmt.Sprintf("%s-%d", cedula, digit)
+}
+
+// Returns true if the string is a plausible cédula de ciudadanía: 6 to 8 digits, or 10 digits starting by 1
+func IsValidCedula(cedula string) bool {
+	if !onlyDigits.MatchString(cedula) || cedula[0] == '0' {
+		return false
+	}
+	return (len(cedula) >= 6 && len(cedula) <= 8) || (len(cedula) == 10 && cedula[0] == '1')
+}
+
+// Returns true if the string is a plausible tarjeta de identidad: 10 digits starting by 1
+func IsValidTarjetaIdentidad(tarjeta string) bool {
+	return onlyDigits.MatchString(tarjeta) && len(tarjeta) == 10 && tarjeta[0] == '1'
+}
+
+// Returns true if the string is a plausible cédula de extranjería: 6 or 7 digits
+func IsValidCedulaExtranjeria(cedula string) bool {
+	return onlyDigits.MatchString(cedula) && cedula[0] != '0' && (len(cedula) == 6 || len(cedula) == 7)
+}
+
+// Returns true if the string is a plausible Colombian passport number: two letters and 6 or 7 digits
+func IsValidPassportCOL(passport string) bool {
+	return passportCOL.MatchString(passport)
+}
+
+// Returns true if the string is a NIT or RUT with a correct check digit, like 900123456-7. The dash
+// is optional, without it the last digit is taken as the check digit.
+func IsValidNIT(nit string) bool {
+	number, digit := nit, ""
+	if len(nit) > 2 && nit[len(nit)-2] == '-' {
+		number, digit = nit[:len(nit)-2], nit[len(nit)-1:]
+	} else if len(nit) > 1 {
+		number, digit = nit[:len(nit)-1], nit[len(nit)-1:]
+	}
+	expected, err := NITCheckDigit(number)
+	if err != nil || !onlyDigits.MatchString(digit) {
+		return false
+	}
+	actual, _ := strconv.Atoi(digit)
+	return actual == expected
+}
+
+// Returns a map with 'size' different documents as its keys, each one returned by the given generator.
+// Returns an error if the generator can not produce that many different documents.
+func randomDocumentSet(size int, name string, generator func() string) (map[string]bool, error) {
+	if size < 1 {
+		return nil, fmt.Errorf("error, invalid arguments in %s(size = %d)", name, size)
+	}
+	set := make(map[string]bool)
+	for attempts := 0; len(set) < size; attempts++ {
+		if attempts > 100*size {
+			return nil, fmt.Errorf("error, %s could not generate %d different documents", name, size)
+		}
+		set[generator()] = true
+	}
+	return set, nil
+}
+
+// Returns a map with 'size' different cédulas issued in the given era as its keys
+func RandomCedulaSet(size int, era CedulaEra) (map[string]bool, error) {
+	return randomDocumentSet(size, "RandomCedulaSet", func() string { return RandomCedula(era) })
+}
+
+// Returns a map with 'size' different tarjetas de identidad as its keys
+func RandomTarjetaIdentidadSet(size int) (map[string]bool, error) {
+	return randomDocumentSet(size, "RandomTarjetaIdentidadSet", RandomTarjetaIdentidad)
+}
+
+// Returns a map with 'size' different cédulas de extranjería as its keys
+func RandomCedulaExtranjeriaSet(size int) (map[string]bool, error) {
+	return randomDocumentSet(size, "RandomCedulaExtranjeriaSet", RandomCedulaExtranjeria)
+}
+
+// Returns a map with 'size' different NITs with their check digit as its keys, like 900123456-7
+func RandomNITSet(size int) (map[string]bool, error) {
+	return randomDocumentSet(size, "RandomNITSet", RandomNITWithCheckDigit)
+}
@@ -0,0 +1,69 @@
+package randgen
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNITCheckDigit(t *testing.T) {
+	known := map[string]int{"800197268": 4, "899999034": 1, "860034313": 7, "890903938": 8}
+	for nit, expected := range known {
+		digit, err := NITCheckDigit(nit)
+		assert.Nil(t, err)
+		assert.Equal(t, expected, digit, fmt.Sprintf("NITCheckDigit(%v)", nit))
+		assert.True(t, IsValidNIT(fmt.Sprintf("%s-%d", nit, expected)))
+		assert.False(t, IsValidNIT(fmt.Sprintf("%s-%d", nit, (expected+1)%10)))
+	}
+	_, err := NITCheckDigit("12a4")
+	assert.NotNil(t, err)
+	_, err = NITCheckDigit("1234567890123456")
+	assert.NotNil(t, err)
+	assert.False(t, IsValidNIT(""))
+	assert.False(t, IsValidNIT("-"))
+}
+
+func TestRandomColombianDocuments(t *testing.T) {
+	for test := 0; test < 1000; test++ {
+		assert.True(t, IsValidCedula(RandomCedula(CedulaAnyEra)))
+		assert.True(t, IsValidTarjetaIdentidad(RandomTarjetaIdentidad()))
+		assert.True(t, IsValidCedulaExtranjeria(RandomCedulaExtranjeria()))
+		assert.True(t, IsValidPassportCOL(RandomPassportCOL()))
+		assert.True(t, IsValidNIT(RandomNITWithCheckDigit()))
+		assert.True(t, IsValidNIT(RandomRUT()))
+		nit, digit := RandomNIT()
+		assert.Len(t, nit, 9)
+		assert.True(t, IsValidNIT(fmt.Sprintf("%s%d", nit, digit)))
+	}
+}
+
+func TestRandomCedulaByEra(t *testing.T) {
+	for test := 0; test < 1000; test++ {
+		old := RandomCedula(CedulaBefore1990)
+		assert.GreaterOrEqual(t, len(old), 6)
+		assert.LessOrEqual(t, len(old), 8)
+		assert.Len(t, RandomCedula(Cedula1990To2003), 8)
+		assert.Regexp(t, `^1\d{9}$`, RandomCedula(CedulaSince2004))
+	}
+	assert.Panics(t, func() { RandomCedula(CedulaEra(9)) })
+}
+
+func TestRandomDocumentSets(t *testing.T) {
+	set, err := RandomCedulaSet(1000, CedulaSince2004)
+	assert.Nil(t, err)
+	assert.Len(t, set, 1000)
+	set, err = RandomNITSet(1000)
+	assert.Nil(t, err)
+	assert.Len(t, set, 1000)
+	set, err = RandomTarjetaIdentidadSet(100)
+	assert.Nil(t, err)
+	assert.Len(t, set, 100)
+	set, err = RandomCedulaExtranjeriaSet(100)
+	assert.Nil(t, err)
+	assert.Len(t, set, 100)
+	_, err = RandomCedulaSet(0, CedulaAnyEra)
+	assert.NotNil(t, err)
+	_, err = randomDocumentSet(2, "constant", func() string { return "1" })
+	assert.NotNil(t, err)
+}
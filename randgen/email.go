@@ -0,0 +1,262 @@
+package randgen
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RFC 5321 limits: 64 octets for the local part, 255 for the domain and 254 for the whole address
+const (
+	maxEmailLocalLength  = 64
+	maxEmailDomainLength = 255
+	maxEmailLength       = 254
+	maxEmailLabelLength  = 63
+)
+
+// Characters allowed in an unquoted local part (RFC 5322 atext), besides letters and digits
+var emailAtextSymbols = "!#$%&'*+-/=?^_`{|}~"
+
+// A domain and how often it should appear compared to the others
+type WeightedDomain struct {
+	Domain string
+	Weight int
+}
+
+// Popular email providers weighted by a rough estimate of their market share
+var PopularEmailDomains = []WeightedDomain{
+	{"gmail.com", 45}, {"yahoo.com", 12}, {"hotmail.com", 10}, {"outlook.com", 10}, {"icloud.com", 7},
+	{"aol.com", 3}, {"protonmail.com", 3}, {"gmx.com", 2}, {"yandex.ru", 2}, {"zoho.com", 1},
+	{"hotmail.es", 2}, {"yahoo.es", 2}, {"une.net.co", 1},
+}
+
+// Internationalized domain names, used by EmailGenerator when IDNDomains is set
+var idnEmailDomains = []string{"correo.españa.es", "bücher.de", "пример.рф", "例え.jp", "café.co", "ñandú.com.ar"}
+
+// EmailGenerator produces valid email addresses. Unlike RandomEmail, even the zero value uses atext symbols
+// like ! # $ % & ' * and ~ in the local parts, and every option enables one more kind of valid address.
+type EmailGenerator struct {
+	Domains         []string         // domains to choose from with the same probability
+	WeightedDomains []WeightedDomain // weighted domains, used when Domains is empty
+	PlusAddressing  bool             // some addresses get a "+tag" suffix in the local part
+	QuotedLocal     bool             // some local parts are quoted strings with spaces and escapes
+	Subdomains      bool             // some domains get random subdomains
+	IDNDomains      bool             // some domains are internationalized (non ASCII)
+	IPLiterals      bool             // some domains are IP literals like [192.168.0.1] or [IPv6:...]
+	MaxLength       bool             // some addresses have the maximum allowed length
+}
+
+// An email address and the RFC 5322/5321 rule it exercises
+type LabeledEmail struct {
+	Address string
+	Rule    string
+}
+
+// Returns a domain from the configured lists, or a random "name.tld" domain if none is configured
+func (g EmailGenerator) domain() string {
+	if len(g.Domains) > 0 {
+		chosen, _ := ChooseString(g.Domains)
+		return chosen
+	}
+	if len(g.WeightedDomains) > 0 {
+		return chooseWeightedDomain(g.WeightedDomains)
+	}
+	return RandomStringExactLength(1, alphaLower) + RandomEnglishLowerCaseString(1, 9) + "." +
+		RandomEnglishLowerCaseString(2, 3)
+}
+
+// Returns one of the domains, each one with a probability proportional to its weight
+func chooseWeightedDomain(domains []WeightedDomain) string {
+	total := 0
+	for _, d := range domains {
+		total += d.Weight
+	}
+	if total <= 0 {
+		panic("Error, weighted domains with no positive total weight")
+	}
+	value := RandomInt(0, total-1)
+	for _, d := range domains {
+		if value < d.Weight {
+			return d.Domain
+		}
+		value -= d.Weight
+	}
+	return domains[len(domains)-1].Domain
+}
+
+// Returns a dot-atom local part of the given length, it never starts or ends with a dot and never
+// has two consecutive dots
+func randomDotAtom(length int) string {
+	alphabet := alphaLower + alphaDigits + emailAtextSymbols
+	local := []byte(RandomStringExactLength(length, alphabet))
+	for i := 1; i+1 < length; i++ {
+		if local[i-1] != '.' && RandomInt(0, 9) == 0 {
+			local[i] = '.'
+		}
+	}
+	return string(local)
+}
+
+// Returns a quoted local part, it contains spaces, an escaped quote and an escaped backslash
+func randomQuotedLocal() string {
+	words := make([]string, 0, 3)
+	for i := RandomInt(1, 3); i > 0; i-- {
+		words = append(words, RandomAlphaDigitString(1, 6))
+	}
+	extra, _ := ChooseString([]string{`\"`, `\\`, "@", "..", ",", ""})
+	return `"` + strings.Join(words, " ") + extra + `"`
+}
+
+// Returns a random IP literal domain, IPv4 or IPv6
+func randomIPLiteral() string {
+	if RandomInt(0, 1) == 0 {
+		return fmt.Sprintf("[%d.%d.%d.%d]", RandomInt(1, 255), RandomInt(0, 255), RandomInt(0, 255), RandomInt(1, 254))
+	}
+	groups := make([]string, 8)
+	for i := range groups {
+		groups[i] = fmt.Sprintf("%x", RandomInt(0, 0xFFFF))
+	}
+	return "[IPv6:" + strings.Join(groups, ":") + "]"
+}
+
+// Returns a domain of exactly 'length' characters made of labels of at most 63 characters
+func randomLongDomain(length int) string {
+	labels := make([]string, 0)
+	remaining := length - 3
+	for remaining > 0 {
+		size := remaining - 1
+		if size > maxEmailLabelLength {
+			size = maxEmailLabelLength
+		}
+		if remaining-size-1 == 1 {
+			size--
+		}
+		labels = append(labels, RandomStringExactLength(1, alphaLower)+RandomEnglishLowerCaseStringExactLength(size-1))
+		remaining -= size + 1
+	}
+	return strings.Join(labels, ".") + ".com"
+}
+
+// Returns a valid pseudo random email address according to the generator options
+func (g EmailGenerator) Email() string {
+	if g.MaxLength && RandomInt(0, 4) == 0 {
+		local := RandomStringExactLength(1, alphaLower) + randomDotAtom(maxEmailLocalLength-2) +
+			RandomStringExactLength(1, alphaLower)
+		return local + "@" + randomLongDomain(maxEmailLength-len(local)-1)
+	}
+	local := RandomStringExactLength(1, alphaLower) + randomDotAtom(RandomInt(1, 12)) +
+		RandomStringExactLength(1, alphaLower)
+	if g.QuotedLocal && RandomInt(0, 4) == 0 {
+		local = randomQuotedLocal()
+	}
+	if g.PlusAddressing && RandomInt(0, 2) == 0 && !strings.HasPrefix(local, `"`) {
+		local += "+" + RandomEnglishLowerCaseString(1, 10)
+	}
+	domain := g.domain()
+	switch {
+	case g.IPLiterals && RandomInt(0, 4) == 0:
+		domain = randomIPLiteral()
+	case g.IDNDomains && RandomInt(0, 4) == 0:
+		domain, _ = ChooseString(idnEmailDomains)
+	case g.Subdomains && RandomInt(0, 2) == 0:
+		for i := RandomInt(1, 3); i > 0; i-- {
+			domain = RandomEnglishLowerCaseString(2, 10) + "." + domain
+		}
+	}
+	return local + "@" + domain
+}
+
+// Returns a map with 'size' different emails as its keys, generated with the generator options
+func (g EmailGenerator) EmailSet(size int) (map[string]bool, error) {
+	if size < 1 {
+		return nil, fmt.Errorf("error, invalid arguments in EmailGenerator.EmailSet(size = %d)", size)
+	}
+	set := make(map[string]bool)
+	for len(set) < size {
+		set[g.Email()] = true
+	}
+	return set, nil
+}
+
+// Invalid email generators, each one labelled by the rule it breaks
+var invalidEmailRules = []struct {
+	rule     string
+	generate func(local, domain string) string
+}{
+	{"RFC 5322 3.4.1: addr-spec requires an @", func(local, domain string) string {
+		return local + domain
+	}},
+	{"RFC 5322 3.4.1: only one @ outside a quoted string", func(local, domain string) string {
+		return local + "@" + RandomEnglishLowerCaseString(1, 5) + "@" + domain
+	}},
+	{"RFC 5322 3.4.1: local part can not be empty", func(local, domain string) string {
+		return "@" + domain
+	}},
+	{"RFC 5322 3.4.1: domain can not be empty", func(local, domain string) string {
+		return local + "@"
+	}},
+	{"RFC 5322 3.2.3: dot-atom can not start with a dot", func(local, domain string) string {
+		return "." + local + "@" + domain
+	}},
+	{"RFC 5322 3.2.3: dot-atom can not end with a dot", func(local, domain string) string {
+		return local + ".@" + domain
+	}},
+	{"RFC 5322 3.2.3: dot-atom can not have consecutive dots", func(local, domain string) string {
+		return local[:1] + ".." + local[1:] + "@" + domain
+	}},
+	{"RFC 5322 3.2.3: specials must be quoted", func(local, domain string) string {
+		special, _ := ChooseString([]string{"(", ")", "<", ">", "[", "]", ":", ";", ",", "\\", " "})
+		return local + special + local + "@" + domain
+	}},
+	{"RFC 5322 3.2.4: quoted string must be closed", func(local, domain string) string {
+		return `"` + local + "@" + domain
+	}},
+	{"RFC 5321 4.5.3.1.1: local part is at most 64 octets", func(local, domain string) string {
+		return RandomEnglishLowerCaseStringExactLength(maxEmailLocalLength+RandomInt(1, 10)) + "@" + domain
+	}},
+	{"RFC 5321 4.5.3.1.2: domain is at most 255 octets", func(local, domain string) string {
+		return local + "@" + randomLongDomain(maxEmailDomainLength+RandomInt(2, 10))
+	}},
+	{"RFC 1035 2.3.4: domain labels are at most 63 octets", func(local, domain string) string {
+		return local + "@" + RandomEnglishLowerCaseStringExactLength(maxEmailLabelLength+RandomInt(1, 10)) + ".com"
+	}},
+	{"RFC 1035 2.3.1: domain labels can not start or end with a hyphen", func(local, domain string) string {
+		return local + "@-" + domain
+	}},
+	{"RFC 5322 3.4.1: domain can not have consecutive dots", func(local, domain string) string {
+		return local + "@" + strings.Replace(domain, ".", "..", 1)
+	}},
+	{"RFC 5321 4.1.3: IPv6 address literals need the IPv6: tag", func(local, domain string) string {
+		return local + fmt.Sprintf("@[2001:db8::%x]", RandomInt(1, 0xFFFF))
+	}},
+}
+
+// Returns a pseudo random valid email address, same as RandomEmail
+func (g *Generator) Email() string {
+	alphabet := alphaLower + "0123456789_0123456789.0123456789"
+	end := g.StringExactLength(g.Int(2, 8), alphaLower) + "." + g.StringExactLength(g.Int(2, 8), alphaLower)
+	end += g.StringExactLength(1, alphaLower)
+	return g.StringExactLength(1, alphaLower) + g.StringExactLength(g.Int(2, 8), alphabet) +
+		g.StringExactLength(1, alphaLower) + "@" + g.StringExactLength(1, alphaLower) + end
+}
+
+// Returns an invalid email address labelled with the rule it breaks, meant for validation tests
+func RandomInvalidEmail() LabeledEmail {
+	index := RandomInt(0, len(invalidEmailRules)-1)
+	local := RandomStringExactLength(1, alphaLower) + RandomAlphaDigitString(1, 10)
+	domain := RandomEnglishLowerCaseString(2, 10) + "." + RandomEnglishLowerCaseString(2, 3)
+	return LabeledEmail{
+		Address: invalidEmailRules[index].generate(local, domain),
+		Rule:    invalidEmailRules[index].rule,
+	}
+}
+
+// Returns one invalid email address for each rule known by RandomInvalidEmail
+func AllInvalidEmails() []LabeledEmail {
+	emails := make([]LabeledEmail, 0, len(invalidEmailRules))
+	for _, rule := range invalidEmailRules {
+		local := RandomStringExactLength(1, alphaLower) + RandomAlphaDigitString(1, 10)
+		domain := RandomEnglishLowerCaseString(2, 10) + "." + RandomEnglishLowerCaseString(2, 3)
+		emails = append(emails, LabeledEmail{Address: rule.generate(local, domain), Rule: rule.rule})
+	}
+	return emails
+}
@@ -0,0 +1,176 @@
+package randgen
+
+import (
+	"fmt"
+	"net/mail"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmailGenerator(t *testing.T) {
+	generator := EmailGenerator{
+		WeightedDomains: PopularEmailDomains,
+		PlusAddressing:  true,
+		QuotedLocal:     true,
+		Subdomains:      true,
+		IDNDomains:      true,
+		IPLiterals:      true,
+		MaxLength:       true,
+	}
+	for test := 0; test < 1000; test++ {
+		email := generator.Email()
+		_, err := mail.ParseAddress(email)
+		assert.Nil(t, err, fmt.Sprintf("%v must be a valid email", email))
+		assert.LessOrEqual(t, len(email), maxEmailLength)
+		atIndex := strings.LastIndex(email, "@")
+		assert.LessOrEqual(t, atIndex, maxEmailLocalLength)
+	}
+}
+
+func TestEmailGeneratorDomains(t *testing.T) {
+	domains := []string{"example.com", "example.org"}
+	generator := EmailGenerator{Domains: domains, PlusAddressing: true}
+	for test := 0; test < 100; test++ {
+		email := generator.Email()
+		domain := email[strings.LastIndex(email, "@")+1:]
+		assert.Contains(t, domains, domain)
+	}
+	set, err := generator.EmailSet(200)
+	assert.Nil(t, err)
+	assert.Len(t, set, 200)
+	_, err = generator.EmailSet(0)
+	assert.NotNil(t, err)
+}
+
+func TestChooseWeightedDomain(t *testing.T) {
+	frequency := make(map[string]int)
+	for test := 0; test < 10000; test++ {
+		frequency[chooseWeightedDomain([]WeightedDomain{{"a.com", 9}, {"b.com", 1}, {"c.com", 0}})]++
+	}
+	assert.Greater(t, frequency["a.com"], frequency["b.com"]*5)
+	assert.Equal(t, 0, frequency["c.com"])
+}
+
+func TestRandomLongDomain(t *testing.T) {
+	for length := 5; length < 300; length++ {
+		domain := randomLongDomain(length)
+		assert.Len(t, domain, length)
+		for _, label := range strings.Split(domain, ".") {
+			assert.GreaterOrEqual(t, len(label), 1, domain)
+			assert.LessOrEqual(t, len(label), maxEmailLabelLength, domain)
+		}
+	}
+}
+
+// Returns the local part and the domain of address, split at its last @
+func splitTestEmail(address string) (string, string) {
+	at := strings.LastIndex(address, "@")
+	if at < 0 {
+		return address, ""
+	}
+	return address[:at], address[at+1:]
+}
+
+// Returns whether address breaks the rule it is labelled with, one check for each of invalidEmailRules
+var invalidEmailChecks = map[string]func(local, domain, address string) bool{
+	"RFC 5322 3.4.1: addr-spec requires an @": func(local, domain, address string) bool {
+		return !strings.Contains(address, "@")
+	},
+	"RFC 5322 3.4.1: only one @ outside a quoted string": func(local, domain, address string) bool {
+		return !strings.Contains(address, `"`) && strings.Count(address, "@") > 1
+	},
+	"RFC 5322 3.4.1: local part can not be empty": func(local, domain, address string) bool {
+		return local == ""
+	},
+	"RFC 5322 3.4.1: domain can not be empty": func(local, domain, address string) bool {
+		return strings.HasSuffix(address, "@") && domain == ""
+	},
+	"RFC 5322 3.2.3: dot-atom can not start with a dot": func(local, domain, address string) bool {
+		return strings.HasPrefix(local, ".")
+	},
+	"RFC 5322 3.2.3: dot-atom can not end with a dot": func(local, domain, address string) bool {
+		return strings.HasSuffix(local, ".")
+	},
+	"RFC 5322 3.2.3: dot-atom can not have consecutive dots": func(local, domain, address string) bool {
+		return strings.Contains(local, "..")
+	},
+	"RFC 5322 3.2.3: specials must be quoted": func(local, domain, address string) bool {
+		return !strings.HasPrefix(local, `"`) && strings.ContainsAny(local, `()<>[]:;,\ `)
+	},
+	"RFC 5322 3.2.4: quoted string must be closed": func(local, domain, address string) bool {
+		return strings.HasPrefix(local, `"`) && strings.Count(address, `"`) == 1
+	},
+	"RFC 5321 4.5.3.1.1: local part is at most 64 octets": func(local, domain, address string) bool {
+		return len(local) > maxEmailLocalLength
+	},
+	"RFC 5321 4.5.3.1.2: domain is at most 255 octets": func(local, domain, address string) bool {
+		return len(domain) > maxEmailDomainLength
+	},
+	"RFC 1035 2.3.4: domain labels are at most 63 octets": func(local, domain, address string) bool {
+		for _, label := range strings.Split(domain, ".") {
+			if len(label) > maxEmailLabelLength {
+				return true
+			}
+		}
+		return false
+	},
+	"RFC 1035 2.3.1: domain labels can not start or end with a hyphen": func(local, domain, address string) bool {
+		for _, label := range strings.Split(domain, ".") {
+			if strings.HasPrefix(label, "-") || strings.HasSuffix(label, "-") {
+				return true
+			}
+		}
+		return false
+	},
+	"RFC 5322 3.4.1: domain can not have consecutive dots": func(local, domain, address string) bool {
+		return strings.Contains(domain, "..")
+	},
+	"RFC 5321 4.1.3: IPv6 address literals need the IPv6: tag": func(local, domain, address string) bool {
+		return strings.HasPrefix(domain, "[") && strings.Contains(domain, ":") && !strings.HasPrefix(domain, "[IPv6:")
+	},
+}
+
+// Asserts that email breaks its rule, and that net/mail also rejects it when the rule comes from RFC 5322
+func assertInvalidEmail(t *testing.T, email LabeledEmail) {
+	check, ok := invalidEmailChecks[email.Rule]
+	if !assert.True(t, ok, "no check for rule %v", email.Rule) {
+		return
+	}
+	local, domain := splitTestEmail(email.Address)
+	assert.True(t, check(local, domain, email.Address), "%v should break %v", email.Address, email.Rule)
+	if strings.HasPrefix(email.Rule, "RFC 5322") {
+		_, err := mail.ParseAddress(email.Address)
+		assert.Error(t, err, "%v should be rejected by net/mail: %v", email.Address, email.Rule)
+	}
+}
+
+func TestRandomInvalidEmail(t *testing.T) {
+	assert.Len(t, invalidEmailChecks, len(invalidEmailRules))
+	for test := 0; test < 1000; test++ {
+		assertInvalidEmail(t, RandomInvalidEmail())
+		valid := EmailGenerator{WeightedDomains: PopularEmailDomains, PlusAddressing: true, Subdomains: true}.Email()
+		local, domain := splitTestEmail(valid)
+		for rule, check := range invalidEmailChecks {
+			assert.False(t, check(local, domain, valid), "%v does not break %v", valid, rule)
+		}
+	}
+	for test := 0; test < 100; test++ {
+		emails := AllInvalidEmails()
+		assert.Len(t, emails, len(invalidEmailRules))
+		for index, email := range emails {
+			assert.Equal(t, invalidEmailRules[index].rule, email.Rule)
+			assertInvalidEmail(t, email)
+		}
+	}
+}
+
+func TestGeneratorEmail(t *testing.T) {
+	first, second := NewGenerator(5), NewGenerator(5)
+	for test := 0; test < 100; test++ {
+		email := first.Email()
+		assert.Equal(t, email, second.Email())
+		assert.Regexp(t, `^[a-z][a-z0-9_.]{2,8}[a-z]@[a-z]{3,9}\.[a-z]{3,9}$`, email)
+	}
+}
@@ -0,0 +1,84 @@
+package randgen
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Words of the field or column names of every kind of text, in the order GuessNameKind tries them. A word
+// like first_name matches consecutive words of the name.
+var nameKindWords = []struct {
+	kind  string
+	words []string
+}{
+	{"email", []string{"email", "mail"}},
+	{"phone", []string{"phone", "mobile", "cell", "tel", "telephone", "cellphone"}},
+	{"first_name", []string{"first_name", "firstname", "given_name", "forename"}},
+	{"last_name", []string{"last_name", "lastname", "surname", "family_name"}},
+	{"city", []string{"city"}},
+	{"company", []string{"company", "organization", "employer"}},
+	{"url", []string{"url", "website", "link"}},
+	{"uuid", []string{"uuid", "guid"}},
+	{"name", []string{"name", "author", "customer", "owner"}},
+	{"text", []string{"description", "comment", "note", "text", "body", "content", "bio"}},
+}
+
+// Returns the lower case words of a name, split at underscores, spaces and the like and at the case
+// changes of camel case: "customerEmail_2" gives customer, email and 2
+func nameWords(name string) []string {
+	words := make([]string, 0)
+	var word []rune
+	runes := []rune(name)
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if len(word) > 0 {
+				words = append(words, strings.ToLower(string(word)))
+			}
+			word = word[:0]
+			continue
+		}
+		upper := unicode.IsUpper(r)
+		if len(word) > 0 && upper && (!unicode.IsUpper(runes[i-1]) ||
+			(i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+			words = append(words, strings.ToLower(string(word)))
+			word = word[:0]
+		}
+		word = append(word, r)
+	}
+	if len(word) > 0 {
+		words = append(words, strings.ToLower(string(word)))
+	}
+	return words
+}
+
+// Returns true if the words have the word, or its plural. A word like first_name matches consecutive words.
+func hasNameWord(words []string, word string) bool {
+	parts := strings.Split(word, "_")
+	for i := 0; i+len(parts) <= len(words); i++ {
+		matches := true
+		for j, part := range parts {
+			if found := words[i+j]; found != part && (j < len(parts)-1 || found != part+"s") {
+				matches = false
+			}
+		}
+		if matches {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the kind of text that a field or column name suggests: email, phone, first_name, last_name,
+// city, company, url, uuid, name, text, or "" for none. The name is split in words, so customerEmail is an
+// email and home_tel a phone, but hotel_name is a name and Microphone nothing.
+func GuessNameKind(name string) string {
+	words := nameWords(name)
+	for _, kind := range nameKindWords {
+		for _, word := range kind.words {
+			if hasNameWord(words, word) {
+				return kind.kind
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,26 @@
+package randgen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNameWords(t *testing.T) {
+	assert.Equal(t, []string{"customer", "email", "2"}, nameWords("customerEmail_2"))
+	assert.Equal(t, []string{"hotel", "name"}, nameWords("hotel_name"))
+	assert.Equal(t, []string{"full", "name"}, nameWords("Full Name"))
+	assert.Equal(t, []string{"http", "server", "url"}, nameWords("HTTPServerURL"))
+	assert.Empty(t, nameWords("__"))
+}
+
+func TestGuessNameKind(t *testing.T) {
+	for name, kind := range map[string]string{
+		"email": "email", "contactEmail": "email", "e_mail": "email", "tel": "phone", "home_tel": "phone",
+		"customerPhone": "phone", "phones": "phone", "FirstName": "first_name", "last_name": "last_name",
+		"city": "city", "employer": "company", "websiteURL": "url", "user_uuid": "uuid", "hotel_name": "name",
+		"notes": "text", "hotel": "", "Microphone": "", "Headphones": "", "telemetry": "", "code": "",
+	} {
+		assert.Equal(t, kind, GuessNameKind(name), name)
+	}
+}
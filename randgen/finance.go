@@ -0,0 +1,393 @@
+package randgen
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Brand of a payment card
+type CardBrand int
+
+const (
+	CardAnyBrand CardBrand = iota
+	CardVisa
+	CardMastercard
+	CardAmex
+)
+
+// Prefixes (IIN ranges), number length and CVV length of a card brand
+type cardBrandSpec struct {
+	name      string
+	ranges    [][2]int
+	length    int
+	cvvLength int
+}
+
+var cardBrandSpecs = map[CardBrand]cardBrandSpec{
+	CardVisa:       {"Visa", [][2]int{{4, 4}}, 16, 3},
+	CardMastercard: {"Mastercard", [][2]int{{51, 55}, {2221, 2720}}, 16, 3},
+	CardAmex:       {"American Express", [][2]int{{34, 34}, {37, 37}}, 15, 4},
+}
+
+// Returns the name of the brand, like Visa
+func (b CardBrand) String() string {
+	if spec, ok := cardBrandSpecs[b]; ok {
+		return spec.name
+	}
+	return "Any"
+}
+
+// A payment card for tests, its number has a correct Luhn check digit
+type Card struct {
+	Brand       CardBrand
+	Number      string
+	ExpiryMonth int
+	ExpiryYear  int
+	CVV         string
+}
+
+// Returns the expiry date as printed on the card, like 07/29
+func (c Card) Expiry() string {
+	return fmt.Sprintf("%02d/%02d", c.ExpiryMonth, c.ExpiryYear%100)
+}
+
+// Returns the number in groups as printed on the card, 4-6-5 for Amex and 4-4-4-4 for the others
+func (c Card) FormattedNumber() string {
+	if len(c.Number) == 15 {
+		return c.Number[:4] + " " + c.Number[4:10] + " " + c.Number[10:]
+	}
+	groups := make([]string, 0, 4)
+	for i := 0; i < len(c.Number); i += 4 {
+		end := i + 4
+		if end > len(c.Number) {
+			end = len(c.Number)
+		}
+		groups = append(groups, c.Number[i:end])
+	}
+	return strings.Join(groups, " ")
+}
+
+// Returns the Luhn check digit that must be appended to the given digits
+func LuhnCheckDigit(digits string) (int, error) {
+	if !onlyDigits.MatchString(digits) {
+		return 0, fmt.Errorf("error, invalid arguments in LuhnCheckDigit(digits = %s)", digits)
+	}
+	sum := 0
+	for i := len(digits) - 1; i >= 0; i-- {
+		digit := int(digits[i] - '0')
+		if (len(digits)-1-i)%2 == 0 {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+		sum += digit
+	}
+	return (10 - sum%10) % 10, nil
+}
+
+// Returns true if the number is made of digits and its last digit is a correct Luhn check digit
+func IsValidLuhn(number string) bool {
+	if len(number) < 2 {
+		return false
+	}
+	check, err := LuhnCheckDigit(number[:len(number)-1])
+	return err == nil && int(number[len(number)-1]-'0') == check
+}
+
+// Returns the spec of the brand, a random one for CardAnyBrand
+func cardSpecOf(brand CardBrand) (cardBrandSpec, CardBrand, error) {
+	if brand == CardAnyBrand {
+		brand = CardBrand(RandomInt(int(CardVisa), int(CardAmex)))
+	}
+	spec, ok := cardBrandSpecs[brand]
+	if !ok {
+		return cardBrandSpec{}, brand, fmt.Errorf("error, unknown card brand %d", brand)
+	}
+	return spec, brand, nil
+}
+
+// Returns a pseudo random card number of the brand with a correct Luhn check digit
+func RandomCardNumber(brand CardBrand) (string, error) {
+	spec, _, err := cardSpecOf(brand)
+	if err != nil {
+		return "", err
+	}
+	iin := spec.ranges[RandomInt(0, len(spec.ranges)-1)]
+	prefix := fmt.Sprintf("%d", RandomInt(iin[0], iin[1]))
+	digits := prefix + RandomStringExactLength(spec.length-len(prefix)-1, digitsAll)
+	check, err := LuhnCheckDigit(digits)
+	return digits + fmt.Sprintf("%d", check), err
+}
+
+// Returns a pseudo random expiry month and year from the next month up to five years ahead
+func RandomCardExpiry() (int, int) {
+	now := time.Now().UTC()
+	expiry := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, RandomInt(1, 60), 0)
+	return int(expiry.Month()), expiry.Year()
+}
+
+// Returns a pseudo random CVV of the brand, four digits for Amex and three for the others
+func RandomCVV(brand CardBrand) (string, error) {
+	spec, _, err := cardSpecOf(brand)
+	if err != nil {
+		return "", err
+	}
+	return RandomStringExactLength(spec.cvvLength, digitsAll), nil
+}
+
+// Returns a pseudo random card of the brand, a random brand for CardAnyBrand
+func RandomCard(brand CardBrand) (Card, error) {
+	spec, brand, err := cardSpecOf(brand)
+	if err != nil {
+		return Card{}, err
+	}
+	number, err := RandomCardNumber(brand)
+	if err != nil {
+		return Card{}, err
+	}
+	month, year := RandomCardExpiry()
+	return Card{
+		Brand: brand, Number: number, ExpiryMonth: month, ExpiryYear: year,
+		CVV: RandomStringExactLength(spec.cvvLength, digitsAll),
+	}, nil
+}
+
+// Structure of the BBAN (the national part of an IBAN) by country, in the notation of the IBAN
+// registry: n for digits, a for upper case letters and c for both, like "4a14n"
+var ibanFormats = map[string]string{
+	"DE": "18n",
+	"ES": "20n",
+	"FR": "10n11c2n",
+	"GB": "4a14n",
+	"IT": "1a10n12c",
+	"NL": "4a10n",
+	"PT": "21n",
+	"BR": "23n1a1c",
+}
+
+// Returns the IBAN country codes with a known format, sorted
+func IBANCountries() []string {
+	countries := make([]string, 0, len(ibanFormats))
+	for country := range ibanFormats {
+		countries = append(countries, country)
+	}
+	sort.Strings(countries)
+	return countries
+}
+
+// Returns a pseudo random BBAN with the structure of the IBAN registry notation
+func randomBBAN(format string) string {
+	bban := ""
+	count := 0
+	for _, char := range format {
+		switch char {
+		case 'n':
+			bban += RandomStringExactLength(count, digitsAll)
+		case 'a':
+			bban += RandomStringExactLength(count, upperAll)
+		case 'c':
+			bban += RandomStringExactLength(count, upperAll+digitsAll)
+		default:
+			count = count*10 + int(char-'0')
+			continue
+		}
+		count = 0
+	}
+	return bban
+}
+
+// Returns the length of the BBAN with the structure of the IBAN registry notation
+func bbanLength(format string) int {
+	length, count := 0, 0
+	for _, char := range format {
+		if char >= '0' && char <= '9' {
+			count = count*10 + int(char-'0')
+			continue
+		}
+		length += count
+		count = 0
+	}
+	return length
+}
+
+// Returns the remainder of the IBAN, rearranged as the standard says, divided by 97. Letters count as
+// two digits, A = 10 to Z = 35
+func ibanMod97(rearranged string) int {
+	remainder := 0
+	for _, char := range rearranged {
+		switch {
+		case char >= '0' && char <= '9':
+			remainder = (remainder*10 + int(char-'0')) % 97
+		case char >= 'A' && char <= 'Z':
+			remainder = (remainder*100 + int(char-'A') + 10) % 97
+		default:
+			return -1
+		}
+	}
+	return remainder
+}
+
+// Returns the two check digits of the IBAN of the country with the given BBAN
+func IBANCheckDigits(country, bban string) (string, error) {
+	remainder := ibanMod97(strings.ToUpper(bban + country + "00"))
+	if len(country) != 2 || remainder < 0 {
+		return "", fmt.Errorf("error, invalid arguments in IBANCheckDigits(country = %s, bban = %s)", country, bban)
+	}
+	return fmt.Sprintf("%02d", 98-remainder), nil
+}
+
+// Returns true if the IBAN, with or without spaces, has correct check digits. For the countries of
+// IBANCountries the length of the BBAN is checked too
+func IsValidIBAN(iban string) bool {
+	iban = strings.ToUpper(strings.Replace(iban, " ", "", -1))
+	if len(iban) < 5 || len(iban) > 34 {
+		return false
+	}
+	if format, ok := ibanFormats[iban[:2]]; ok && len(iban)-4 != bbanLength(format) {
+		return false
+	}
+	return ibanMod97(iban[4:]+iban[:4]) == 1
+}
+
+// Returns a pseudo random IBAN of the country with correct mod-97 check digits, like
+// DE89370400440532013000, the country must be one of IBANCountries
+func RandomIBAN(country string) (string, error) {
+	format, ok := ibanFormats[country]
+	if !ok {
+		return "", fmt.Errorf("error, unknown IBAN format for country %s", country)
+	}
+	bban := randomBBAN(format)
+	check, err := IBANCheckDigits(country, bban)
+	return country + check + bban, err
+}
+
+// Kind of a Colombian bank account
+type BankAccountType int
+
+const (
+	AccountSavings  BankAccountType = iota // cuenta de ahorros
+	AccountChecking                        // cuenta corriente
+)
+
+// A Colombian bank with its ACH code and the length of its account numbers
+type ColombianBank struct {
+	Code          string
+	Name          string
+	AccountLength int
+}
+
+// Some of the Colombian banks
+var ColombianBanks = []ColombianBank{
+	{"1001", "Banco de Bogotá", 9},
+	{"1002", "Banco Popular", 9},
+	{"1007", "Bancolombia", 11},
+	{"1013", "BBVA Colombia", 9},
+	{"1019", "Scotiabank Colpatria", 10},
+	{"1023", "Banco de Occidente", 9},
+	{"1032", "Banco Caja Social", 11},
+	{"1051", "Davivienda", 12},
+	{"1052", "Banco AV Villas", 9},
+}
+
+// A Colombian bank account
+type ColombianBankAccount struct {
+	Bank   ColombianBank
+	Type   BankAccountType
+	Number string
+}
+
+// Returns a pseudo random account of one of the ColombianBanks, the number has the length used by the bank
+func RandomColombianBankAccount() ColombianBankAccount {
+	bank := ColombianBanks[RandomInt(0, len(ColombianBanks)-1)]
+	return ColombianBankAccount{
+		Bank:   bank,
+		Type:   BankAccountType(RandomInt(int(AccountSavings), int(AccountChecking))),
+		Number: RandomStringExactLength(1, digitsAll[1:]) + RandomStringExactLength(bank.AccountLength-1, digitsAll),
+	}
+}
+
+// An ISO 4217 currency, MinorUnits is the number of decimal places of its amounts
+type Currency struct {
+	Code       string
+	Number     string
+	Name       string
+	MinorUnits int
+}
+
+// Some ISO 4217 currencies, with zero, two and three decimal places
+var Currencies = []Currency{
+	{"ARS", "032", "Argentine peso", 2},
+	{"BHD", "048", "Bahraini dinar", 3},
+	{"BRL", "986", "Brazilian real", 2},
+	{"CAD", "124", "Canadian dollar", 2},
+	{"CHF", "756", "Swiss franc", 2},
+	{"CLP", "152", "Chilean peso", 0},
+	{"COP", "170", "Colombian peso", 2},
+	{"EUR", "978", "Euro", 2},
+	{"GBP", "826", "Pound sterling", 2},
+	{"JPY", "392", "Japanese yen", 0},
+	{"KWD", "414", "Kuwaiti dinar", 3},
+	{"MXN", "484", "Mexican peso", 2},
+	{"PEN", "604", "Peruvian sol", 2},
+	{"USD", "840", "United States dollar", 2},
+}
+
+// Returns the currency with the given ISO 4217 code
+func GetCurrency(code string) (Currency, error) {
+	for _, currency := range Currencies {
+		if currency.Code == code {
+			return currency, nil
+		}
+	}
+	return Currency{}, fmt.Errorf("error, unknown currency %s", code)
+}
+
+// Returns one of the Currencies randomly
+func RandomCurrency() Currency {
+	return Currencies[RandomInt(0, len(Currencies)-1)]
+}
+
+// An amount of money, kept as an integer number of minor units (cents) to avoid rounding errors
+type Amount struct {
+	Currency   Currency
+	MinorUnits int64
+}
+
+// Returns the amount with the decimal places of its currency, like 1234.50 or 1500
+func (a Amount) Decimal() string {
+	if a.Currency.MinorUnits == 0 {
+		return fmt.Sprintf("%d", a.MinorUnits)
+	}
+	scale := int64(math.Pow10(a.Currency.MinorUnits))
+	sign := ""
+	units := a.MinorUnits
+	if units < 0 {
+		sign, units = "-", -units
+	}
+	return fmt.Sprintf("%s%d.%0*d", sign, units/scale, a.Currency.MinorUnits, units%scale)
+}
+
+// Returns the amount followed by its currency code, like 1234.50 USD
+func (a Amount) String() string {
+	return a.Decimal() + " " + a.Currency.Code
+}
+
+// Returns a pseudo random amount of the currency in the interval [minValue,maxValue], with the decimal
+// places of the currency
+func RandomAmount(currencyCode string, minValue, maxValue float64) (Amount, error) {
+	currency, err := GetCurrency(currencyCode)
+	if err != nil {
+		return Amount{}, err
+	}
+	scale := math.Pow10(currency.MinorUnits)
+	low, high := int64(math.Ceil(minValue*scale)), int64(math.Floor(maxValue*scale))
+	if low < 0 || high < low {
+		return Amount{}, fmt.Errorf("error, invalid arguments in RandomAmount(currencyCode = %s, minValue = %v, maxValue = %v)",
+			currencyCode, minValue, maxValue)
+	}
+	return Amount{Currency: currency, MinorUnits: RandomInt64(low, high)}, nil
+}
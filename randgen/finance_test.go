@@ -0,0 +1,101 @@
+package randgen
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLuhn(t *testing.T) {
+	check, err := LuhnCheckDigit("7992739871")
+	assert.Nil(t, err)
+	assert.Equal(t, 3, check)
+	assert.True(t, IsValidLuhn("4111111111111111"))
+	assert.True(t, IsValidLuhn("378282246310005"))
+	assert.False(t, IsValidLuhn("4111111111111112"))
+	assert.False(t, IsValidLuhn("41111a1111111111"))
+	_, err = LuhnCheckDigit("12-3")
+	assert.NotNil(t, err)
+}
+
+func TestRandomCard(t *testing.T) {
+	patterns := map[CardBrand]string{
+		CardVisa:       `^4\d{15}$`,
+		CardMastercard: `^(5[1-5]\d{14}|2[2-7]\d{14})$`,
+		CardAmex:       `^3[47]\d{13}$`,
+	}
+	now := time.Now().UTC()
+	for test := 0; test < 1000; test++ {
+		for brand, pattern := range patterns {
+			card, err := RandomCard(brand)
+			assert.Nil(t, err)
+			assert.Equal(t, brand, card.Brand)
+			assert.Regexp(t, pattern, card.Number)
+			assert.True(t, IsValidLuhn(card.Number), card.Number)
+			assert.Len(t, strings.Replace(card.FormattedNumber(), " ", "", -1), len(card.Number))
+			assert.Regexp(t, `^\d{3,4}$`, card.CVV)
+			assert.Regexp(t, `^(0[1-9]|1[0-2])/\d\d$`, card.Expiry())
+			expiry := time.Date(card.ExpiryYear, time.Month(card.ExpiryMonth), 1, 0, 0, 0, 0, time.UTC)
+			assert.True(t, expiry.After(now))
+			assert.True(t, expiry.Before(now.AddDate(5, 1, 0)))
+		}
+		card, err := RandomCard(CardAnyBrand)
+		assert.Nil(t, err)
+		assert.True(t, IsValidLuhn(card.Number))
+	}
+	cvv, err := RandomCVV(CardAmex)
+	assert.Nil(t, err)
+	assert.Len(t, cvv, 4)
+	_, err = RandomCardNumber(CardBrand(9))
+	assert.NotNil(t, err)
+}
+
+func TestIBAN(t *testing.T) {
+	assert.True(t, IsValidIBAN("DE89 3704 0044 0532 0130 00"))
+	assert.True(t, IsValidIBAN("GB82WEST12345698765432"))
+	assert.False(t, IsValidIBAN("GB82WEST12345698765433"))
+	assert.False(t, IsValidIBAN("DE8937040044053201300"))
+	check, err := IBANCheckDigits("DE", "370400440532013000")
+	assert.Nil(t, err)
+	assert.Equal(t, "89", check)
+	for test := 0; test < 1000; test++ {
+		for _, country := range IBANCountries() {
+			iban, err := RandomIBAN(country)
+			assert.Nil(t, err)
+			assert.True(t, strings.HasPrefix(iban, country))
+			assert.True(t, IsValidIBAN(iban), iban)
+		}
+	}
+	_, err = RandomIBAN("XX")
+	assert.NotNil(t, err)
+}
+
+func TestRandomColombianBankAccount(t *testing.T) {
+	for test := 0; test < 1000; test++ {
+		account := RandomColombianBankAccount()
+		assert.Len(t, account.Number, account.Bank.AccountLength)
+		assert.Regexp(t, `^[1-9]\d+$`, account.Number)
+	}
+}
+
+func TestRandomAmount(t *testing.T) {
+	for test := 0; test < 1000; test++ {
+		usd, err := RandomAmount("USD", 10, 20)
+		assert.Nil(t, err)
+		assert.Regexp(t, `^(1\d\.\d\d|20\.00)$`, usd.Decimal())
+		jpy, err := RandomAmount("JPY", 100, 5000)
+		assert.Nil(t, err)
+		assert.Regexp(t, `^\d{3,4}$`, jpy.Decimal())
+		kwd, err := RandomAmount("KWD", 0, 1)
+		assert.Nil(t, err)
+		assert.Regexp(t, `^[01]\.\d{3} KWD$`, kwd.String())
+		assert.Len(t, RandomCurrency().Code, 3)
+	}
+	assert.Equal(t, "-12.05", Amount{Currency{Code: "EUR", MinorUnits: 2}, -1205}.Decimal())
+	_, err := RandomAmount("XXX", 0, 1)
+	assert.NotNil(t, err)
+	_, err = RandomAmount("USD", 2, 1)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,161 @@
+package randgen
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// Generator is a seedable source of pseudo random values. The package functions like RandomInt draw from
+// a generator seeded with the current time when the program starts, a Generator created with NewGenerator
+// and the same seed always produces the same sequence instead, so fixtures built with it are reproducible.
+// Time based values (UUIDv7, ULID, KSUID, snowflake IDs) read the clock of the generator, fix it with
+// SetClock to make them reproducible too. A Generator is safe for concurrent use.
+type Generator struct {
+	mutex  sync.Mutex
+	source *rand.Rand
+	clock  func() time.Time
+
+	// state of the monotonic ID generators, guarded by its own mutex
+	idMutex          sync.Mutex
+	lastUUIDv7Millis int64
+	lastUUIDv7       [16]byte
+	lastULIDMillis   int64
+	lastULID         [16]byte
+}
+
+// The generator used by the package level functions, like RandomInt or RandomUUIDv4
+var defaultGenerator = NewTimeSeededGenerator()
+
+// Returns a new generator seeded with the given seed, it reads the system clock
+func NewGenerator(seed int64) *Generator {
+	return &Generator{source: rand.New(rand.NewSource(seed)), clock: time.Now}
+}
+
+// Returns a new generator seeded with the current time
+func NewTimeSeededGenerator() *Generator {
+	return NewGenerator(time.Now().UTC().UnixNano())
+}
+
+// Replaces the clock read by the time based values of the generator, for example with a function
+// returning a fixed time
+func (g *Generator) SetClock(clock func() time.Time) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	g.clock = clock
+}
+
+// Returns the current time according to the clock of the generator
+func (g *Generator) Now() time.Time {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	return g.clock()
+}
+
+// Returns a pseudo random integer in the interval [minValue,maxValue].
+// Panic if one or more of the params is negative or maxValue < minValue, same as RandomInt
+func (g *Generator) Int(minValue int, maxValue int) int {
+	if minValue < 0 || maxValue < 0 || maxValue < minValue {
+		panic(fmt.Sprintf("Error, invalid arguments in Generator.Int(%d,%d) function.", minValue, maxValue))
+	}
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	return minValue + g.source.Intn(maxValue-minValue+1)
+}
+
+// Returns a pseudo random integer 64bit signed number in the interval [minValue,maxValue].
+// Panic if one or more of the params is negative or maxValue < minValue, same as RandomInt64
+func (g *Generator) Int64(minValue int64, maxValue int64) int64 {
+	if minValue < 0 || maxValue < 0 || maxValue < minValue {
+		panic(fmt.Sprintf("Error, invalid arguments in Generator.Int64(%d,%d) function.", minValue, maxValue))
+	}
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	if maxValue-minValue == 1<<63-1 {
+		return g.source.Int63()
+	}
+	return minValue + g.source.Int63n(maxValue-minValue+1)
+}
+
+// Returns a pseudo random float64 number in the interval [minValue,maxValue), same as RandomFloat64
+func (g *Generator) Float64(minValue float64, maxValue float64) float64 {
+	if minValue < 0 || maxValue < 0 || maxValue <= minValue {
+		panic(fmt.Sprintf("Error, invalid arguments in Generator.Float64(%v,%v) function.", minValue, maxValue))
+	}
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	return minValue + (maxValue-minValue)*g.source.Float64()
+}
+
+// Returns a pseudo random number with standard normal distribution (mean 0, standard deviation 1)
+func (g *Generator) NormFloat64() float64 {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	return g.source.NormFloat64()
+}
+
+// Returns 'size' pseudo random bytes
+func (g *Generator) Bytes(size int) []byte {
+	buffer := make([]byte, size)
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	g.source.Read(buffer)
+	return buffer
+}
+
+// Returns a pseudo random string of character from the given alphabet, it length will be exactly 'length'.
+// Same contract as RandomStringExactLength
+func (g *Generator) StringExactLength(length int, alphabet string) string {
+	if len(alphabet) <= 0 {
+		panic("Error, alphabet with no positive length")
+	}
+	if !isASCII(alphabet) {
+		runes := []rune(alphabet)
+		buffer := make([]rune, length)
+		for i := range buffer {
+			buffer[i] = runes[g.Int(0, len(runes)-1)]
+		}
+		return string(buffer)
+	}
+	buffer := make([]byte, length)
+	for i := range buffer {
+		buffer[i] = alphabet[g.Int(0, len(alphabet)-1)]
+	}
+	return string(buffer)
+}
+
+// Returns true if every byte of the text is an ASCII character
+func isASCII(text string) bool {
+	for i := 0; i < len(text); i++ {
+		if text[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns a pseudo random string of character from the given alphabet, it length will be at least
+// 'minLength' and less or equal to 'maxLength'. Same contract as RandomString
+func (g *Generator) String(minLength, maxLength int, alphabet string) string {
+	if minLength < 0 || maxLength < 0 || maxLength < minLength {
+		return ""
+	}
+	return g.StringExactLength(g.Int(minLength, maxLength), alphabet)
+}
+
+// Given some elements, choose and return one of them randomly, same as ChooseString
+func (g *Generator) ChooseString(elements []string) (string, error) {
+	if len(elements) < 1 {
+		return "", fmt.Errorf("error, invalid arguments in Generator.ChooseString(elements = %v)", elements)
+	}
+	return elements[g.Int(0, len(elements)-1)], nil
+}
+
+// Returns a pseudo random permutation of the integers [0,n)
+func (g *Generator) Perm(n int) []int {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	return g.source.Perm(n)
+}
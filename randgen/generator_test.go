@@ -0,0 +1,95 @@
+package randgen
+
+import (
+	"testing"
+	"unicode/utf8"
+
+	"github.com/niquefa/gominirandgen/stats"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGeneratorIsReproducible(t *testing.T) {
+	first := NewGenerator(42)
+	second := NewGenerator(42)
+	for test := 0; test < 1000; test++ {
+		assert.Equal(t, first.Int(0, 1000), second.Int(0, 1000))
+		assert.Equal(t, first.Int64(5, 1<<40), second.Int64(5, 1<<40))
+		assert.Equal(t, first.Float64(0, 1), second.Float64(0, 1))
+		assert.Equal(t, first.StringExactLength(10, alphaDigits), second.StringExactLength(10, alphaDigits))
+	}
+	assert.NotEqual(t, NewGenerator(1).Bytes(32), NewGenerator(2).Bytes(32))
+}
+
+func TestGeneratorRanges(t *testing.T) {
+	g := NewTimeSeededGenerator()
+	for test := 0; test < 1000; test++ {
+		value := g.Int(3, 7)
+		assert.GreaterOrEqual(t, value, 3)
+		assert.LessOrEqual(t, value, 7)
+		str := g.String(2, 5, alphaUpper)
+		assert.GreaterOrEqual(t, len(str), 2)
+		assert.LessOrEqual(t, len(str), 5)
+		assert.Len(t, g.Perm(5), 5)
+	}
+	assert.Panics(t, func() { g.Int(5, 4) })
+	assert.Equal(t, "", g.String(5, 4, alphaUpper))
+	_, err := g.ChooseString(nil)
+	assert.NotNil(t, err)
+}
+
+func TestGeneratorStringNonASCII(t *testing.T) {
+	g := NewGenerator(6)
+	for test := 0; test < 100; test++ {
+		text := g.StringExactLength(8, "ñáé€漢")
+		assert.True(t, utf8.ValidString(text), text)
+		assert.Regexp(t, `^[ñáé€漢]{8}$`, text)
+		assert.Regexp(t, `^[ñü]{2,4}$`, g.String(2, 4, "ñü"))
+		assert.Regexp(t, `^[ñü]{3}$`, RandomStringExactLength(3, "ñü"))
+	}
+}
+
+// The seed of the statistical tests, fixed so they never flake
+const qualityTestSeed = 20190601
+
+// Replaces the default generator of the package functions with one of the seed, so the statistical tests
+// of the package functions do not flake either. Returns the function that restores the previous one.
+func seedDefaultGenerator(seed int64) func() {
+	previous := defaultGenerator
+	defaultGenerator = NewGenerator(seed)
+	return func() {
+		defaultGenerator = previous
+	}
+}
+
+func TestGeneratorQuality(t *testing.T) {
+	g := NewGenerator(qualityTestSeed)
+	counts := make([]int, 10)
+	uniform := make([]float64, 10000)
+	normal := make([]float64, 10000)
+	for i := range uniform {
+		counts[g.Int(0, 9)]++
+		uniform[i] = g.Float64(0, 1)
+		normal[i] = g.NormFloat64()
+	}
+	days := int64(1 << 24)
+	birthdays := make([]int64, 512)
+	for i := range birthdays {
+		birthdays[i] = g.Int64(0, days-1)
+	}
+	results := make([]stats.Result, 0)
+	for _, test := range []func() (stats.Result, error){
+		func() (stats.Result, error) { return stats.ChiSquareUniform(counts) },
+		func() (stats.Result, error) { return stats.KolmogorovSmirnov(uniform, stats.UniformCDF(0, 1)) },
+		func() (stats.Result, error) { return stats.KolmogorovSmirnov(normal, stats.NormalCDF(0, 1)) },
+		func() (stats.Result, error) { return stats.Runs(uniform) },
+		func() (stats.Result, error) { return stats.SerialCorrelation(uniform, 1) },
+		func() (stats.Result, error) { return stats.BirthdaySpacings(birthdays, days) },
+	} {
+		result, err := test()
+		assert.Nil(t, err)
+		results = append(results, result)
+	}
+	for _, result := range results {
+		assert.True(t, result.Passed(stats.DefaultAlpha), result.String())
+	}
+}
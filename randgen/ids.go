@@ -0,0 +1,278 @@
+package randgen
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// Alphabets of the ULID (Crockford base 32) and KSUID (base 62) encodings
+const (
+	crockfordBase32 = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+	base62Alphabet  = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+)
+
+// Lengths of the encoded IDs
+const (
+	ULIDLength          = 26
+	KSUIDLength         = 27
+	DefaultNanoIDLength = 21
+)
+
+// KSUID timestamps are seconds since this epoch (2014-05-13T16:53:20Z) instead of the Unix epoch
+const ksuidEpoch = 1400000000
+
+// Snowflake IDs: 41 bits of milliseconds since the epoch, 10 bits of machine ID and 12 bits of sequence
+const (
+	SnowflakeEpochMillis  = 1288834974657 // the Twitter epoch, 2010-11-04T01:42:54.657Z
+	snowflakeMachineBits  = 10
+	snowflakeSequenceBits = 12
+	MaxSnowflakeMachineID = 1<<snowflakeMachineBits - 1
+	maxSnowflakeSequence  = 1<<snowflakeSequenceBits - 1
+)
+
+// Formats the 16 bytes as a UUID, like 0f8fad5b-d9cb-469f-a165-70867728950e
+func formatUUID(uuid [16]byte) string {
+	text := hex.EncodeToString(uuid[:])
+	return text[:8] + "-" + text[8:12] + "-" + text[12:16] + "-" + text[16:20] + "-" + text[20:]
+}
+
+// Writes the timestamp as a 48 bit big endian number in the first six bytes
+func putMillis48(id *[16]byte, millis int64) {
+	for i := 0; i < 6; i++ {
+		id[i] = byte(millis >> uint(40-8*i))
+	}
+}
+
+// Adds one to the big endian number in the bytes, returns false if it overflowed
+func incrementBytes(number []byte) bool {
+	for i := len(number) - 1; i >= 0; i-- {
+		number[i]++
+		if number[i] != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns a random version 4 UUID (RFC 9562), drawn from the generator
+func (g *Generator) UUIDv4() string {
+	var uuid [16]byte
+	copy(uuid[:], g.Bytes(16))
+	uuid[6] = uuid[6]&0x0f | 0x40
+	uuid[8] = uuid[8]&0x3f | 0x80
+	return formatUUID(uuid)
+}
+
+// Returns a version 7 UUID (RFC 9562): the milliseconds of the clock of the generator followed by
+// random bits, so UUIDs of different milliseconds sort by time
+func (g *Generator) UUIDv7() string {
+	var uuid [16]byte
+	copy(uuid[:], g.Bytes(16))
+	putMillis48(&uuid, g.Now().UnixNano()/int64(time.Millisecond))
+	uuid[6] = uuid[6]&0x0f | 0x70
+	uuid[8] = uuid[8]&0x3f | 0x80
+	return formatUUID(uuid)
+}
+
+// Same as UUIDv7, but every UUID sorts after the previous one of the generator: within the same
+// millisecond the 12 bits after the version are a counter, when it overflows, or the clock goes back,
+// the timestamp of the previous UUID is advanced by one millisecond
+func (g *Generator) MonotonicUUIDv7() string {
+	g.idMutex.Lock()
+	defer g.idMutex.Unlock()
+	millis := g.Now().UnixNano() / int64(time.Millisecond)
+	var uuid [16]byte
+	copy(uuid[:], g.Bytes(16))
+	counter := int(uuid[6]&0x07)<<8 | int(uuid[7]) // the counter starts in the lower half, leaving room to grow
+	if millis <= g.lastUUIDv7Millis {
+		millis = g.lastUUIDv7Millis
+		counter = int(g.lastUUIDv7[6]&0x0f)<<8 | int(g.lastUUIDv7[7]) + 1
+		if counter > 0xfff {
+			millis++
+			counter = 0
+		}
+	}
+	putMillis48(&uuid, millis)
+	uuid[6] = 0x70 | byte(counter>>8)
+	uuid[7] = byte(counter)
+	uuid[8] = uuid[8]&0x3f | 0x80
+	g.lastUUIDv7Millis = millis
+	g.lastUUIDv7 = uuid
+	return formatUUID(uuid)
+}
+
+// Encodes the 16 bytes of a ULID with the Crockford base 32 alphabet
+func encodeULID(id [16]byte) string {
+	var number big.Int
+	number.SetBytes(id[:])
+	encoded := number.Text(32)
+	encoded = strings.Repeat("0", ULIDLength-len(encoded)) + encoded
+	buffer := make([]byte, ULIDLength)
+	for i := range buffer {
+		buffer[i] = crockfordBase32[strings.IndexByte("0123456789abcdefghijklmnopqrstuv", encoded[i])]
+	}
+	return string(buffer)
+}
+
+// Returns a ULID: the milliseconds of the clock of the generator followed by 80 random bits, encoded as
+// 26 characters of Crockford base 32, like 01ARZ3NDEKTSV4RRFFQ69G5FAV
+func (g *Generator) ULID() string {
+	var id [16]byte
+	copy(id[6:], g.Bytes(10))
+	putMillis48(&id, g.Now().UnixNano()/int64(time.Millisecond))
+	return encodeULID(id)
+}
+
+// Same as ULID, but every ULID sorts after the previous one of the generator: within the same
+// millisecond the random part of the previous ULID is incremented by one, as the ULID specification
+// says. If it overflows, or the clock goes back, the timestamp is advanced by one millisecond.
+func (g *Generator) MonotonicULID() string {
+	g.idMutex.Lock()
+	defer g.idMutex.Unlock()
+	millis := g.Now().UnixNano() / int64(time.Millisecond)
+	var id [16]byte
+	if millis <= g.lastULIDMillis {
+		millis = g.lastULIDMillis
+		id = g.lastULID
+		if !incrementBytes(id[6:]) {
+			millis++
+			copy(id[6:], g.Bytes(10))
+		}
+	} else {
+		copy(id[6:], g.Bytes(10))
+	}
+	putMillis48(&id, millis)
+	g.lastULIDMillis = millis
+	g.lastULID = id
+	return encodeULID(id)
+}
+
+// Returns the time, with millisecond precision, encoded in the ULID
+func ULIDTime(ulid string) (time.Time, error) {
+	if len(ulid) != ULIDLength || ulid[0] > '7' {
+		return time.Time{}, fmt.Errorf("error, %q is not a valid ULID", ulid)
+	}
+	ulid = strings.ToUpper(ulid)
+	millis := int64(0)
+	for i := 0; i < 10; i++ {
+		value := strings.IndexByte(crockfordBase32, ulid[i])
+		if value < 0 {
+			return time.Time{}, fmt.Errorf("error, %q is not a valid ULID", ulid)
+		}
+		millis = millis<<5 | int64(value)
+	}
+	return time.Unix(0, millis*int64(time.Millisecond)).UTC(), nil
+}
+
+// Returns a KSUID: 4 bytes with the seconds of the clock of the generator since the KSUID epoch followed
+// by 16 random bytes, encoded as 27 characters of base 62, like 0ujtsYcgvSTl8PAuAdqWYSMnLOv. Clocks before
+// the KSUID epoch, or after the 4 bytes run out in 2150, give the first or the last timestamp.
+func (g *Generator) KSUID() string {
+	id := make([]byte, 20)
+	seconds := g.Now().Unix() - ksuidEpoch
+	if seconds < 0 {
+		seconds = 0
+	} else if seconds > math.MaxUint32 {
+		seconds = math.MaxUint32
+	}
+	for i := 0; i < 4; i++ {
+		id[i] = byte(seconds >> uint(24-8*i))
+	}
+	copy(id[4:], g.Bytes(16))
+	var number, remainder big.Int
+	number.SetBytes(id)
+	base := big.NewInt(62)
+	buffer := make([]byte, KSUIDLength)
+	for i := KSUIDLength - 1; i >= 0; i-- {
+		number.DivMod(&number, base, &remainder)
+		buffer[i] = base62Alphabet[remainder.Int64()]
+	}
+	return string(buffer)
+}
+
+// Returns a NanoID of the given size with characters of the alphabet, alphaDigits if the alphabet is
+// empty, so the IDs have no ambiguous characters like 0, O, 1 or l
+func (g *Generator) NanoID(size int, alphabet string) (string, error) {
+	if alphabet == "" {
+		alphabet = alphaDigits
+	}
+	if size < 1 {
+		return "", fmt.Errorf("error, invalid arguments in NanoID(size = %d, alphabet = %s)", size, alphabet)
+	}
+	return g.StringExactLength(size, alphabet), nil
+}
+
+// A node that generates Twitter style snowflake IDs, 64 bit integers made of the milliseconds since
+// SnowflakeEpochMillis, the machine ID of the node and a sequence number within the millisecond, so the
+// IDs of a node are unique and increasing
+type SnowflakeNode struct {
+	generator  *Generator
+	machineID  int64
+	lastMillis int64
+	sequence   int64
+}
+
+// Returns a snowflake node that reads the clock of the generator, the machine ID must be in the
+// interval [0,MaxSnowflakeMachineID]
+func (g *Generator) NewSnowflakeNode(machineID int64) (*SnowflakeNode, error) {
+	if machineID < 0 || machineID > MaxSnowflakeMachineID {
+		return nil, fmt.Errorf("error, invalid arguments in NewSnowflakeNode(machineID = %d)", machineID)
+	}
+	return &SnowflakeNode{generator: g, machineID: machineID, lastMillis: -1}, nil
+}
+
+// Returns the next ID of the node. When the 4096 sequence numbers of a millisecond are exhausted, or the
+// clock goes back, the timestamp is advanced by one millisecond instead of waiting for the clock.
+// A node is not safe for concurrent use.
+func (n *SnowflakeNode) Next() int64 {
+	millis := n.generator.Now().UnixNano()/int64(time.Millisecond) - SnowflakeEpochMillis
+	if millis <= n.lastMillis {
+		millis = n.lastMillis
+		n.sequence++
+		if n.sequence > maxSnowflakeSequence {
+			millis++
+			n.sequence = 0
+		}
+	} else {
+		n.sequence = 0
+	}
+	n.lastMillis = millis
+	return millis<<(snowflakeMachineBits+snowflakeSequenceBits) | n.machineID<<snowflakeSequenceBits | n.sequence
+}
+
+// Returns the time, machine ID and sequence number of a snowflake ID
+func ParseSnowflake(id int64) (time.Time, int64, int64) {
+	millis := id>>(snowflakeMachineBits+snowflakeSequenceBits) + SnowflakeEpochMillis
+	machineID := id >> snowflakeSequenceBits & MaxSnowflakeMachineID
+	return time.Unix(0, millis*int64(time.Millisecond)).UTC(), machineID, id & maxSnowflakeSequence
+}
+
+// Returns a random version 4 UUID
+func RandomUUIDv4() string {
+	return defaultGenerator.UUIDv4()
+}
+
+// Returns a version 7 UUID of the current time, every one sorts after the previous one
+func RandomUUIDv7() string {
+	return defaultGenerator.MonotonicUUIDv7()
+}
+
+// Returns a ULID of the current time, every one sorts after the previous one
+func RandomULID() string {
+	return defaultGenerator.MonotonicULID()
+}
+
+// Returns a KSUID of the current time
+func RandomKSUID() string {
+	return defaultGenerator.KSUID()
+}
+
+// Returns a NanoID of DefaultNanoIDLength characters of alphaDigits
+func RandomNanoID() string {
+	id, _ := defaultGenerator.NanoID(DefaultNanoIDLength, alphaDigits)
+	return id
+}
@@ -0,0 +1,129 @@
+package randgen
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var fixedIDTime = time.Date(2024, time.March, 1, 12, 30, 0, 0, time.UTC)
+
+func fixedClockGenerator(seed int64) *Generator {
+	g := NewGenerator(seed)
+	g.SetClock(func() time.Time { return fixedIDTime })
+	return g
+}
+
+func TestUUIDs(t *testing.T) {
+	uuid := `^[0-9a-f]{8}-[0-9a-f]{4}-%s[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`
+	for test := 0; test < 1000; test++ {
+		assert.Regexp(t, strings.Replace(uuid, "%s", "4", 1), RandomUUIDv4())
+		assert.Regexp(t, strings.Replace(uuid, "%s", "7", 1), RandomUUIDv7())
+	}
+	g := fixedClockGenerator(7)
+	// 2024-03-01T12:30:00Z is 0x018df9fe2940 milliseconds since the Unix epoch
+	assert.True(t, strings.HasPrefix(g.UUIDv7(), "018df9fe-2940-7"))
+	assert.Equal(t, fixedClockGenerator(7).UUIDv4(), fixedClockGenerator(7).UUIDv4())
+}
+
+func TestMonotonicUUIDv7(t *testing.T) {
+	g := fixedClockGenerator(1)
+	previous := ""
+	for test := 0; test < 10000; test++ {
+		uuid := g.MonotonicUUIDv7()
+		assert.True(t, uuid > previous, "%s after %s", uuid, previous)
+		assert.Equal(t, byte('7'), uuid[14])
+		previous = uuid
+	}
+}
+
+func TestULID(t *testing.T) {
+	g := fixedClockGenerator(3)
+	ulid := g.ULID()
+	assert.Len(t, ulid, ULIDLength)
+	assert.Regexp(t, `^[0-9A-HJKMNP-TV-Z]{26}$`, ulid)
+	decoded, err := ULIDTime(ulid)
+	assert.Nil(t, err)
+	assert.Equal(t, fixedIDTime, decoded)
+	assert.Equal(t, ulid, fixedClockGenerator(3).ULID())
+	_, err = ULIDTime("8ZZZZZZZZZZZZZZZZZZZZZZZZZ")
+	assert.NotNil(t, err)
+	_, err = ULIDTime("01ARZ3NDEK")
+	assert.NotNil(t, err)
+}
+
+func TestMonotonicULID(t *testing.T) {
+	g := fixedClockGenerator(5)
+	ulids := make([]string, 0, 10000)
+	for test := 0; test < 10000; test++ {
+		ulids = append(ulids, g.MonotonicULID())
+	}
+	assert.True(t, sort.StringsAreSorted(ulids))
+	assert.Equal(t, ulids[0][:10], ulids[len(ulids)-1][:10])
+	set := make(map[string]bool)
+	for _, ulid := range ulids {
+		set[ulid] = true
+	}
+	assert.Len(t, set, len(ulids))
+	assert.NotEqual(t, RandomULID(), RandomULID())
+}
+
+func TestKSUID(t *testing.T) {
+	for test := 0; test < 1000; test++ {
+		assert.Regexp(t, `^[0-9A-Za-z]{27}$`, RandomKSUID())
+	}
+	first := fixedClockGenerator(9).KSUID()
+	assert.Equal(t, first, fixedClockGenerator(9).KSUID())
+	later := NewGenerator(9)
+	later.SetClock(func() time.Time { return fixedIDTime.Add(time.Hour) })
+	assert.True(t, later.KSUID() > first)
+	// the greatest KSUID still has 27 characters
+	assert.True(t, "aWgEPTl1tmebfsQzFP4bxwgy80V" > first)
+	// clocks out of the KSUID range keep the order instead of wrapping around
+	early, epoch := NewGenerator(9), NewGenerator(9)
+	early.SetClock(func() time.Time { return time.Unix(ksuidEpoch-3600, 0) })
+	epoch.SetClock(func() time.Time { return time.Unix(ksuidEpoch, 0) })
+	assert.Equal(t, epoch.KSUID(), early.KSUID())
+	assert.True(t, early.KSUID() < first)
+	late := NewGenerator(9)
+	late.SetClock(func() time.Time { return time.Unix(ksuidEpoch+math.MaxUint32+3600, 0) })
+	assert.True(t, late.KSUID() > first)
+	assert.Len(t, late.KSUID(), KSUIDLength)
+}
+
+func TestNanoID(t *testing.T) {
+	for test := 0; test < 1000; test++ {
+		id := RandomNanoID()
+		assert.Len(t, id, DefaultNanoIDLength)
+		assert.Equal(t, DefaultNanoIDLength, countInAlphabet(id, alphaDigits))
+	}
+	g := NewGenerator(11)
+	id, err := g.NanoID(8, "ab")
+	assert.Nil(t, err)
+	assert.Regexp(t, `^[ab]{8}$`, id)
+	_, err = g.NanoID(0, "")
+	assert.NotNil(t, err)
+}
+
+func TestSnowflake(t *testing.T) {
+	g := fixedClockGenerator(13)
+	node, err := g.NewSnowflakeNode(77)
+	assert.Nil(t, err)
+	previous := int64(0)
+	for test := 0; test < 10000; test++ {
+		id := node.Next()
+		assert.True(t, id > previous)
+		previous = id
+	}
+	first := node.Next()
+	timestamp, machineID, _ := ParseSnowflake(first)
+	assert.Equal(t, int64(77), machineID)
+	// 10000 IDs in the same millisecond take two extra milliseconds of sequence numbers
+	assert.Equal(t, fixedIDTime.Add(2*time.Millisecond), timestamp)
+	_, err = g.NewSnowflakeNode(MaxSnowflakeMachineID + 1)
+	assert.NotNil(t, err)
+}
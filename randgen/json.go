@@ -0,0 +1,245 @@
+package randgen
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Options of the JSON documents returned by RandomJSON
+type JSONOptions struct {
+	MaxDepth        int     // maximum nesting of arrays and objects, 0 for scalars only
+	MaxBreadth      int     // maximum number of elements of an array or members of an object
+	KeyAlphabet     string  // alphabet of the object keys
+	MinKeyLength    int     // minimum length of the object keys
+	MaxKeyLength    int     // maximum length of the object keys
+	MinNumber       float64 // minimum of the regular numbers
+	MaxNumber       float64 // maximum of the regular numbers
+	ExtremeNumbers  bool    // also numbers like 1.7976931348623157e308, 5e-324, -0 or 2^64-1
+	EscapeEdgeCases bool    // strings with escapes, control characters, surrogate pairs and non ASCII text
+	Malformed       bool    // the document is near valid but malformed, see RandomMalformedJSON
+}
+
+// Options with every edge case turned on, keys of up to 8 letters and digits
+var DefaultJSONOptions = JSONOptions{
+	MaxDepth:        4,
+	MaxBreadth:      5,
+	KeyAlphabet:     alphaDigits,
+	MinKeyLength:    1,
+	MaxKeyLength:    8,
+	MinNumber:       -1e6,
+	MaxNumber:       1e6,
+	ExtremeNumbers:  true,
+	EscapeEdgeCases: true,
+}
+
+// Numbers that are valid JSON but stress parsers: limits of float64 and int64, negative zero, exponents
+var extremeJSONNumbers = []string{
+	"0", "-0", "0.0", "-0.0", "1E+2", "1e-7", "1.5e300", "1.7976931348623157e308", "-1.7976931348623157e308",
+	"5e-324", "2.2250738585072014e-308", "9007199254740993", "-9223372036854775808", "9223372036854775807",
+	"18446744073709551615", "123456789012345678901234567890", "0.1", "1e400",
+}
+
+// Fragments of JSON strings, already escaped, that stress parsers
+var jsonStringEdgeCases = []string{
+	`\"`, `\\`, `\/`, `\b`, `\f`, `\n`, `\r`, `\t`, `\u0000`, `\u001f`, `\u00e9`, `\u2028`, `\u2029`,
+	`\ud83d\ude00`, `\uD834\uDD1E`, `\ud800`, `\uffff`, "\u00f1", "\u65e5\u672c\u8a9e", "\U0001F600",
+	"e\u0301", "\u2028", "\u200b", "\u00a0",
+}
+
+// Characters of the regular strings, printable ASCII except the quote and the backslash
+const jsonPlainCharacters = " !#$%&'()*+,-./0123456789:;<=>?@ABCDEFGHIJKLMNOPQRSTUVWXYZ[]^_`abcdefghijklmnopqrstuvwxyz{|}~"
+
+// Returns an error if the options can not produce documents
+func (o JSONOptions) Validate() error {
+	if o.MaxDepth < 0 || o.MaxBreadth < 0 || o.MinKeyLength < 0 || o.MaxKeyLength < o.MinKeyLength ||
+		o.MaxNumber < o.MinNumber || (o.MaxKeyLength > 0 && o.KeyAlphabet == "") ||
+		(o.Malformed && (o.MaxDepth < 1 || o.MaxBreadth < 1)) {
+		return fmt.Errorf("error, invalid JSON options %+v", o)
+	}
+	return nil
+}
+
+// Returns the text as a JSON string literal
+func jsonQuote(text string) string {
+	quoted, _ := json.Marshal(text)
+	return string(quoted)
+}
+
+// Returns a JSON string literal with random content, escaped
+func (o JSONOptions) randomString() string {
+	var builder strings.Builder
+	builder.WriteByte('"')
+	for parts := RandomInt(0, 4); parts > 0; parts-- {
+		if o.EscapeEdgeCases && RandomInt(0, 2) == 0 {
+			builder.WriteString(jsonStringEdgeCases[RandomInt(0, len(jsonStringEdgeCases)-1)])
+		} else {
+			builder.WriteString(RandomString(0, 6, jsonPlainCharacters))
+		}
+	}
+	builder.WriteByte('"')
+	return builder.String()
+}
+
+// Returns a JSON number
+func (o JSONOptions) randomNumber() string {
+	if o.ExtremeNumbers && RandomInt(0, 3) == 0 {
+		return extremeJSONNumbers[RandomInt(0, len(extremeJSONNumbers)-1)]
+	}
+	value := interpolate(o.MinNumber, o.MaxNumber, RandomFloat64(0, 1))
+	if o.MinNumber >= math.MinInt64 && o.MaxNumber < math.MaxInt64 && RandomInt(0, 1) == 0 {
+		return strconv.FormatInt(int64(value), 10)
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+// Returns the number at the fraction u of the way from low to high, written so it does not overflow when
+// high-low is larger than the largest float64
+func interpolate(low, high, u float64) float64 {
+	return math.Max(low, math.Min(high, low*(1-u)+high*u))
+}
+
+// Returns a JSON value, an array or an object if container is set
+func (o JSONOptions) randomValue(depth int, container bool) string {
+	kind := RandomInt(0, 5)
+	if container {
+		kind = RandomInt(4, 5)
+	} else if depth >= o.MaxDepth {
+		kind = RandomInt(0, 3)
+	}
+	switch kind {
+	case 0:
+		return "null"
+	case 1:
+		return strconv.FormatBool(RandomInt(0, 1) == 0)
+	case 2:
+		return o.randomNumber()
+	case 3:
+		return o.randomString()
+	case 4:
+		elements := make([]string, RandomInt(0, o.MaxBreadth))
+		for i := range elements {
+			elements[i] = o.randomValue(depth+1, false)
+		}
+		return "[" + strings.Join(elements, ",") + "]"
+	}
+	keys := make(map[string]bool)
+	members := make([]string, 0)
+	for count := RandomInt(0, o.MaxBreadth); count > 0; count-- {
+		key := ""
+		if o.MaxKeyLength > 0 {
+			key = RandomString(o.MinKeyLength, o.MaxKeyLength, o.KeyAlphabet)
+		}
+		if keys[key] {
+			continue
+		}
+		keys[key] = true
+		members = append(members, jsonQuote(key)+":"+o.randomValue(depth+1, false))
+	}
+	return "{" + strings.Join(members, ",") + "}"
+}
+
+// Returns a pseudo random valid JSON document with the given options, or a near valid malformed one if
+// options.Malformed is set
+func RandomJSON(options JSONOptions) (string, error) {
+	if options.Malformed {
+		labeled, err := RandomMalformedJSON(options)
+		return labeled.Document, err
+	}
+	if err := options.Validate(); err != nil {
+		return "", err
+	}
+	return options.randomValue(0, false), nil
+}
+
+// A malformed JSON document and the rule it breaks
+type LabeledJSON struct {
+	Document string
+	Rule     string
+}
+
+// Returns the document, whose root is an array or an object, with a new element or member before its
+// closing bracket
+func insertIntoJSONRoot(document, element, member string) string {
+	last := len(document) - 1
+	separator := ""
+	if strings.TrimSpace(document[1:last]) != "" {
+		separator = ","
+	}
+	if document[last] == ']' {
+		return document[:last] + separator + element + document[last:]
+	}
+	return document[:last] + separator + member + document[last:]
+}
+
+// Ways to break a valid JSON document whose root is an array or an object, each one a single small mistake
+var malformedJSONRules = []struct {
+	rule   string
+	mutate func(document string) string
+}{
+	{"trailing comma", func(document string) string {
+		return document[:len(document)-1] + "," + document[len(document)-1:]
+	}},
+	{"unclosed string", func(document string) string {
+		return insertIntoJSONRoot(document, `"unclosed`, `"unclosed`)
+	}},
+	{"missing closing bracket", func(document string) string {
+		return document[:len(document)-1]
+	}},
+	{"extra closing bracket", func(document string) string {
+		return document + document[len(document)-1:]
+	}},
+	{"single quoted string", func(document string) string {
+		return insertIntoJSONRoot(document, `'single'`, `'single':1`)
+	}},
+	{"unquoted key", func(document string) string {
+		return insertIntoJSONRoot(document, `{key:1}`, `key:1`)
+	}},
+	{"missing colon", func(document string) string {
+		return insertIntoJSONRoot(document, `{"key" 1}`, `"key" 1`)
+	}},
+	{"number with leading zero", func(document string) string {
+		return insertIntoJSONRoot(document, `0123`, `"number":0123`)
+	}},
+	{"NaN is not a JSON number", func(document string) string {
+		return insertIntoJSONRoot(document, `NaN`, `"number":NaN`)
+	}},
+	{"raw control character in string", func(document string) string {
+		return insertIntoJSONRoot(document, "\"tab\there\"", "\"tab\":\"tab\there\"")
+	}},
+	{"invalid escape", func(document string) string {
+		return insertIntoJSONRoot(document, `"\x41"`, `"escape":"\x41"`)
+	}},
+	{"truncated unicode escape", func(document string) string {
+		return insertIntoJSONRoot(document, `"\u12"`, `"escape":"\u12"`)
+	}},
+	{"comment", func(document string) string {
+		return "/* comment */" + document
+	}},
+}
+
+// Returns a near valid JSON document with a single mistake, like a trailing comma or an unclosed string,
+// and the rule it breaks. The document is built with the options and its root is an array or an object.
+func RandomMalformedJSON(options JSONOptions) (LabeledJSON, error) {
+	options.Malformed = true
+	if err := options.Validate(); err != nil {
+		return LabeledJSON{}, err
+	}
+	rule := malformedJSONRules[RandomInt(0, len(malformedJSONRules)-1)]
+	return LabeledJSON{Document: rule.mutate(options.randomValue(0, true)), Rule: rule.rule}, nil
+}
+
+// Returns one malformed JSON document for each rule known by RandomMalformedJSON
+func AllMalformedJSON(options JSONOptions) ([]LabeledJSON, error) {
+	options.Malformed = true
+	if err := options.Validate(); err != nil {
+		return nil, err
+	}
+	documents := make([]LabeledJSON, 0, len(malformedJSONRules))
+	for _, rule := range malformedJSONRules {
+		documents = append(documents, LabeledJSON{Document: rule.mutate(options.randomValue(0, true)), Rule: rule.rule})
+	}
+	return documents, nil
+}
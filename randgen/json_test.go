@@ -0,0 +1,101 @@
+package randgen
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Returns the nesting depth of the decoded JSON value
+func jsonDepth(value interface{}) int {
+	depth := 0
+	switch typed := value.(type) {
+	case []interface{}:
+		for _, element := range typed {
+			if d := jsonDepth(element) + 1; d > depth {
+				depth = d
+			}
+		}
+		if depth == 0 {
+			depth = 1
+		}
+	case map[string]interface{}:
+		for _, member := range typed {
+			if d := jsonDepth(member) + 1; d > depth {
+				depth = d
+			}
+		}
+		if depth == 0 {
+			depth = 1
+		}
+	}
+	return depth
+}
+
+func TestRandomJSON(t *testing.T) {
+	for test := 0; test < 2000; test++ {
+		document, err := RandomJSON(DefaultJSONOptions)
+		assert.Nil(t, err)
+		assert.True(t, json.Valid([]byte(document)), document)
+		var value interface{}
+		decoder := json.NewDecoder(strings.NewReader(document))
+		decoder.UseNumber()
+		assert.Nil(t, decoder.Decode(&value), document)
+		assert.LessOrEqual(t, jsonDepth(value), DefaultJSONOptions.MaxDepth+1)
+	}
+	options := JSONOptions{MaxDepth: 2, MaxBreadth: 3, KeyAlphabet: "ab\"\\\x00", MinKeyLength: 1, MaxKeyLength: 3,
+		MinNumber: 0, MaxNumber: 10}
+	for test := 0; test < 1000; test++ {
+		document, err := RandomJSON(options)
+		assert.Nil(t, err)
+		var value interface{}
+		assert.Nil(t, json.Unmarshal([]byte(document), &value), document)
+		if object, ok := value.(map[string]interface{}); ok {
+			assert.LessOrEqual(t, len(object), 3)
+			for key := range object {
+				assert.Regexp(t, "^[ab\"\\\\\x00]{1,3}$", key)
+			}
+		}
+		if number, ok := value.(float64); ok {
+			assert.True(t, number >= 0 && number <= 10)
+		}
+	}
+	huge := JSONOptions{MinNumber: -1e308, MaxNumber: 1e308}
+	for test := 0; test < 1000; test++ {
+		document, _ := RandomJSON(huge)
+		if number, err := strconv.ParseFloat(document, 64); err == nil {
+			assert.False(t, math.IsInf(number, 0), document)
+			assert.True(t, number >= -1e308 && number <= 1e308, document)
+			assert.NotContains(t, []string{"9223372036854775807", "-9223372036854775808"}, document)
+		}
+	}
+	scalar, err := RandomJSON(JSONOptions{})
+	assert.Nil(t, err)
+	assert.True(t, json.Valid([]byte(scalar)), scalar)
+	_, err = RandomJSON(JSONOptions{MaxDepth: -1})
+	assert.NotNil(t, err)
+}
+
+func TestRandomMalformedJSON(t *testing.T) {
+	for test := 0; test < 1000; test++ {
+		labeled, err := RandomMalformedJSON(DefaultJSONOptions)
+		assert.Nil(t, err)
+		assert.NotEmpty(t, labeled.Rule)
+		assert.False(t, json.Valid([]byte(labeled.Document)), labeled.Rule+": "+labeled.Document)
+		document, err := RandomJSON(JSONOptions{MaxDepth: 1, MaxBreadth: 1, Malformed: true})
+		assert.Nil(t, err)
+		assert.False(t, json.Valid([]byte(document)), document)
+	}
+	all, err := AllMalformedJSON(DefaultJSONOptions)
+	assert.Nil(t, err)
+	assert.Len(t, all, len(malformedJSONRules))
+	for _, labeled := range all {
+		assert.False(t, json.Valid([]byte(labeled.Document)), labeled.Rule+": "+labeled.Document)
+	}
+	_, err = RandomMalformedJSON(JSONOptions{})
+	assert.NotNil(t, err)
+}
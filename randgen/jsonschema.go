@@ -0,0 +1,1155 @@
+package randgen
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"net"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// How many instances are tried before giving up, keywords like oneOf, not or pattern together with
+// maxLength are honoured by generating instances until one validates
+const maxJSONSchemaAttempts = 200
+
+// How many $ref can be followed one inside the other, so recursive references do not loop forever
+const maxJSONSchemaRefDepth = 64
+
+// Seconds from 1970 of the last date-time generated, 2099-12-31T23:59:59Z
+const jsonSchemaMaxSeconds = 4102444799
+
+// Options of the instances generated from a JSONSchema
+type JSONSchemaOptions struct {
+	MaxDepth  int     // nesting beyond which objects only get their required properties and arrays their minItems
+	MaxItems  int     // cap of the arrays without maxItems
+	MaxLength int     // cap of the strings without maxLength, beyond their minLength
+	MinNumber float64 // minimum of the numbers without minimum
+	MaxNumber float64 // maximum of the numbers without maximum
+}
+
+// Options of a JSONSchema created by ParseJSONSchema or LoadJSONSchema
+var DefaultJSONSchemaOptions = JSONSchemaOptions{MaxDepth: 5, MaxItems: 5, MaxLength: 12, MinNumber: -1e6, MaxNumber: 1e6}
+
+// A JSON Schema document, draft-07 or 2020-12, that produces random instances. It honours type, enum,
+// const, minLength, maxLength, pattern, format, minimum, maximum, exclusiveMinimum, exclusiveMaximum,
+// multipleOf, items, prefixItems, additionalItems, minItems, maxItems, uniqueItems, properties, required,
+// additionalProperties, patternProperties, minProperties, maxProperties, allOf, anyOf, oneOf, not and
+// $ref to the same document, like "#/definitions/name" or "#/$defs/name".
+type JSONSchema struct {
+	Options JSONSchemaOptions
+	root    interface{}
+}
+
+// A keyword of the schema that an instance does not satisfy, Path is the JSON pointer of the value
+type SchemaError struct {
+	Path    string
+	Keyword string
+	Message string
+}
+
+func (e SchemaError) Error() string {
+	return fmt.Sprintf("%s at %q: %s", e.Keyword, e.Path, e.Message)
+}
+
+// An instance that violates exactly one keyword of the schema, the one of the value at Path
+type InvalidJSONInstance struct {
+	Instance interface{}
+	Keyword  string
+	Path     string
+}
+
+// Returns the schema of the JSON document
+func ParseJSONSchema(data []byte) (*JSONSchema, error) {
+	var root interface{}
+	if err := json.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("error, the JSON schema is not valid JSON: %v", err)
+	}
+	switch root.(type) {
+	case bool, map[string]interface{}:
+		return &JSONSchema{Options: DefaultJSONSchemaOptions, root: root}, nil
+	}
+	return nil, fmt.Errorf("error, a JSON schema must be an object or a boolean")
+}
+
+// Returns the schema of the JSON file
+func LoadJSONSchema(path string) (*JSONSchema, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseJSONSchema(data)
+}
+
+// Returns the value referenced by ref, a JSON pointer inside the schema like "#/$defs/name"
+func (s *JSONSchema) resolve(ref string) (interface{}, error) {
+	if !strings.HasPrefix(ref, "#") {
+		return nil, fmt.Errorf("error, unsupported $ref %q, only references inside the document are supported", ref)
+	}
+	pointer, err := url.PathUnescape(ref[1:])
+	if err != nil || (pointer != "" && !strings.HasPrefix(pointer, "/")) {
+		return nil, fmt.Errorf("error, unsupported $ref %q, it must be a JSON pointer like #/$defs/name", ref)
+	}
+	node := s.root
+	if pointer == "" {
+		return node, nil
+	}
+	for _, token := range strings.Split(pointer[1:], "/") {
+		token = strings.Replace(strings.Replace(token, "~1", "/", -1), "~0", "~", -1)
+		found := false
+		switch typed := node.(type) {
+		case map[string]interface{}:
+			node, found = typed[token]
+		case []interface{}:
+			if index, err := strconv.Atoi(token); err == nil && index >= 0 && index < len(typed) {
+				node, found = typed[index], true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("error, the $ref %q does not point to a schema", ref)
+		}
+	}
+	return node, nil
+}
+
+// Returns the number of the keyword in the schema
+func schemaNumber(schema map[string]interface{}, keyword string) (float64, bool) {
+	value, ok := schema[keyword].(float64)
+	return value, ok
+}
+
+// Returns the JSON pointer of the child of path
+func childPath(path, token string) string {
+	return path + "/" + strings.Replace(strings.Replace(token, "~", "~0", -1), "/", "~1", -1)
+}
+
+// Returns the JSON type of the decoded value, integer for the numbers without fraction
+func jsonTypeOf(value interface{}) string {
+	switch typed := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		if typed == math.Trunc(typed) && !math.IsInf(typed, 0) {
+			return "integer"
+		}
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	}
+	return "object"
+}
+
+// Returns the types of the type keyword, a string or an array of strings
+func schemaTypes(schema map[string]interface{}) []string {
+	switch typed := schema["type"].(type) {
+	case string:
+		return []string{typed}
+	case []interface{}:
+		types := make([]string, 0, len(typed))
+		for _, name := range typed {
+			if text, ok := name.(string); ok {
+				types = append(types, text)
+			}
+		}
+		return types
+	}
+	return nil
+}
+
+// Returns true if the value is of one of the types, an integer is also a number
+func matchesJSONType(value interface{}, types []string) bool {
+	kind := jsonTypeOf(value)
+	for _, name := range types {
+		if name == kind || (name == "number" && kind == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the value encoded as JSON with the keys of its objects sorted, equal values have equal keys
+func canonicalJSON(value interface{}) string {
+	encoded, _ := json.Marshal(value)
+	return string(encoded)
+}
+
+// Returns true if the value is one of the values
+func containsJSON(values []interface{}, value interface{}) bool {
+	key := canonicalJSON(value)
+	for _, candidate := range values {
+		if canonicalJSON(candidate) == key {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the keywords of the instance that the schema does not satisfy, none if the instance is valid
+func (s *JSONSchema) Validate(instance interface{}) []SchemaError {
+	return s.validate(s.root, instance, "", 0)
+}
+
+// Returns the keywords of the value at path that the schema does not satisfy
+func (s *JSONSchema) validate(schema, instance interface{}, path string, depth int) []SchemaError {
+	errors := make([]SchemaError, 0)
+	fail := func(keyword, format string, args ...interface{}) {
+		errors = append(errors, SchemaError{path, keyword, fmt.Sprintf(format, args...)})
+	}
+	// the schemas of the items or properties left over, which name their own keyword when they are false
+	leftOver := func(keyword string, schema, value interface{}, path, format string, args ...interface{}) {
+		if allowed, ok := schema.(bool); ok && !allowed {
+			fail(keyword, format, args...)
+		} else if schema != nil {
+			errors = append(errors, s.validate(schema, value, path, depth)...)
+		}
+	}
+	m, ok := schema.(map[string]interface{})
+	if !ok {
+		if allowed, ok := schema.(bool); !ok || !allowed {
+			fail("false", "no value is valid")
+		}
+		return errors
+	}
+	if ref, ok := m["$ref"].(string); ok {
+		target, err := s.resolve(ref)
+		if err == nil && depth >= maxJSONSchemaRefDepth {
+			err = fmt.Errorf("error, more than %d nested $ref", maxJSONSchemaRefDepth)
+		}
+		if err != nil {
+			fail("$ref", "%v", err)
+		} else {
+			errors = append(errors, s.validate(target, instance, path, depth+1)...)
+		}
+	}
+	if types := schemaTypes(m); types != nil && !matchesJSONType(instance, types) {
+		fail("type", "%s is not of type %s", jsonTypeOf(instance), strings.Join(types, ", "))
+	}
+	if enum, ok := m["enum"].([]interface{}); ok && !containsJSON(enum, instance) {
+		fail("enum", "%s is not one of %s", canonicalJSON(instance), canonicalJSON(enum))
+	}
+	if constant, ok := m["const"]; ok && canonicalJSON(constant) != canonicalJSON(instance) {
+		fail("const", "%s is not %s", canonicalJSON(instance), canonicalJSON(constant))
+	}
+	if all, ok := m["allOf"].([]interface{}); ok {
+		for _, sub := range all {
+			errors = append(errors, s.validate(sub, instance, path, depth+1)...)
+		}
+	}
+	if branches, ok := m["anyOf"].([]interface{}); ok && s.countMatches(branches, instance, path, depth) == 0 {
+		fail("anyOf", "it matches none of the %d schemas", len(branches))
+	}
+	if branches, ok := m["oneOf"].([]interface{}); ok {
+		if matches := s.countMatches(branches, instance, path, depth); matches != 1 {
+			fail("oneOf", "it matches %d of the %d schemas instead of exactly one", matches, len(branches))
+		}
+	}
+	if not, ok := m["not"]; ok && len(s.validate(not, instance, path, depth+1)) == 0 {
+		fail("not", "it matches the schema of not")
+	}
+	switch value := instance.(type) {
+	case string:
+		length := float64(utf8.RuneCountInString(value))
+		if minimum, ok := schemaNumber(m, "minLength"); ok && length < minimum {
+			fail("minLength", "length %v is less than %v", length, minimum)
+		}
+		if maximum, ok := schemaNumber(m, "maxLength"); ok && length > maximum {
+			fail("maxLength", "length %v is greater than %v", length, maximum)
+		}
+		if pattern, ok := m["pattern"].(string); ok {
+			if matched, err := regexp.MatchString(pattern, value); err != nil || !matched {
+				fail("pattern", "%q does not match %q", value, pattern)
+			}
+		}
+	case float64:
+		if minimum, ok := schemaNumber(m, "minimum"); ok && value < minimum {
+			fail("minimum", "%v is less than %v", value, minimum)
+		}
+		if maximum, ok := schemaNumber(m, "maximum"); ok && value > maximum {
+			fail("maximum", "%v is greater than %v", value, maximum)
+		}
+		if minimum, ok := schemaNumber(m, "exclusiveMinimum"); ok && value <= minimum {
+			fail("exclusiveMinimum", "%v is not greater than %v", value, minimum)
+		}
+		if maximum, ok := schemaNumber(m, "exclusiveMaximum"); ok && value >= maximum {
+			fail("exclusiveMaximum", "%v is not less than %v", value, maximum)
+		}
+		if step, ok := schemaNumber(m, "multipleOf"); ok && step > 0 {
+			if quotient := value / step; math.Abs(quotient-math.Round(quotient)) > 1e-9 {
+				fail("multipleOf", "%v is not a multiple of %v", value, step)
+			}
+		}
+	case []interface{}:
+		length := float64(len(value))
+		if minimum, ok := schemaNumber(m, "minItems"); ok && length < minimum {
+			fail("minItems", "%v items are less than %v", length, minimum)
+		}
+		if maximum, ok := schemaNumber(m, "maxItems"); ok && length > maximum {
+			fail("maxItems", "%v items are more than %v", length, maximum)
+		}
+		if unique, _ := m["uniqueItems"].(bool); unique {
+			seen := make(map[string]bool)
+			for _, item := range value {
+				key := canonicalJSON(item)
+				if seen[key] {
+					fail("uniqueItems", "%s is repeated", key)
+					break
+				}
+				seen[key] = true
+			}
+		}
+		prefix, rest, restKeyword := arraySchemas(m)
+		for i, item := range value {
+			if i < len(prefix) {
+				errors = append(errors, s.validate(prefix[i], item, childPath(path, strconv.Itoa(i)), depth)...)
+			} else {
+				leftOver(restKeyword, rest, item, childPath(path, strconv.Itoa(i)), "item %d is not allowed", i)
+			}
+		}
+	case map[string]interface{}:
+		length := float64(len(value))
+		if minimum, ok := schemaNumber(m, "minProperties"); ok && length < minimum {
+			fail("minProperties", "%v properties are less than %v", length, minimum)
+		}
+		if maximum, ok := schemaNumber(m, "maxProperties"); ok && length > maximum {
+			fail("maxProperties", "%v properties are more than %v", length, maximum)
+		}
+		if required, ok := m["required"].([]interface{}); ok {
+			for _, name := range required {
+				if text, ok := name.(string); ok {
+					if _, present := value[text]; !present {
+						fail("required", "property %q is missing", text)
+					}
+				}
+			}
+		}
+		properties, _ := m["properties"].(map[string]interface{})
+		patterns, _ := m["patternProperties"].(map[string]interface{})
+		for _, name := range sortedKeys(value) {
+			matched := false
+			if property, ok := properties[name]; ok {
+				matched = true
+				errors = append(errors, s.validate(property, value[name], childPath(path, name), depth)...)
+			}
+			for pattern, property := range patterns {
+				if ok, _ := regexp.MatchString(pattern, name); ok {
+					matched = true
+					errors = append(errors, s.validate(property, value[name], childPath(path, name), depth)...)
+				}
+			}
+			if !matched {
+				leftOver("additionalProperties", m["additionalProperties"], value[name], childPath(path, name),
+					"property %q is not allowed", name)
+			}
+		}
+	}
+	return errors
+}
+
+// Returns how many of the schemas the value satisfies
+func (s *JSONSchema) countMatches(schemas []interface{}, instance interface{}, path string, depth int) int {
+	matches := 0
+	for _, sub := range schemas {
+		if len(s.validate(sub, instance, path, depth+1)) == 0 {
+			matches++
+		}
+	}
+	return matches
+}
+
+// Returns the schemas of the first items, of the items after them and the keyword of the later: items and
+// additionalItems in draft-07, prefixItems and items in 2020-12
+func arraySchemas(schema map[string]interface{}) ([]interface{}, interface{}, string) {
+	if tuple, ok := schema["items"].([]interface{}); ok {
+		return tuple, schema["additionalItems"], "additionalItems"
+	}
+	prefix, _ := schema["prefixItems"].([]interface{})
+	return prefix, schema["items"], "items"
+}
+
+// Returns the keys of the object sorted
+func sortedKeys(object map[string]interface{}) []string {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Returns the schema with its $ref and allOf merged into it, so the keywords of all of them can be read
+// from a single object. The merge is not exact, the generated instances are validated anyway.
+func (s *JSONSchema) flatten(schema interface{}, depth int) (map[string]interface{}, error) {
+	if depth > maxJSONSchemaRefDepth {
+		return nil, fmt.Errorf("error, more than %d nested $ref or allOf in the JSON schema", maxJSONSchemaRefDepth)
+	}
+	m, ok := schema.(map[string]interface{})
+	if !ok {
+		if allowed, ok := schema.(bool); ok && allowed {
+			return map[string]interface{}{}, nil
+		}
+		return nil, fmt.Errorf("error, the JSON schema %s has no instances", canonicalJSON(schema))
+	}
+	result := make(map[string]interface{}, len(m))
+	for keyword, value := range m {
+		if keyword != "$ref" && keyword != "allOf" {
+			result[keyword] = value
+		}
+	}
+	parts, _ := m["allOf"].([]interface{})
+	if ref, ok := m["$ref"].(string); ok {
+		target, err := s.resolve(ref)
+		if err != nil {
+			return nil, err
+		}
+		parts = append([]interface{}{target}, parts...)
+	}
+	for _, part := range parts {
+		flat, err := s.flatten(part, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		mergeSchemas(result, flat)
+	}
+	return result, nil
+}
+
+// Adds the keywords of from to into, combining the ones both have so their instances satisfy both when
+// that is easy: the larger minimum, the smaller maximum, the union of required and so on
+func mergeSchemas(into, from map[string]interface{}) {
+	for keyword, value := range from {
+		existing, ok := into[keyword]
+		if !ok {
+			into[keyword] = value
+			continue
+		}
+		switch keyword {
+		case "minimum", "exclusiveMinimum", "minLength", "minItems", "minProperties":
+			if bound, ok := schemaNumber(into, keyword); ok {
+				if other, ok := schemaNumber(from, keyword); ok {
+					into[keyword] = math.Max(bound, other)
+				}
+			}
+		case "maximum", "exclusiveMaximum", "maxLength", "maxItems", "maxProperties":
+			if bound, ok := schemaNumber(into, keyword); ok {
+				if other, ok := schemaNumber(from, keyword); ok {
+					into[keyword] = math.Min(bound, other)
+				}
+			}
+		case "required":
+			required, _ := existing.([]interface{})
+			more, _ := value.([]interface{})
+			union := append([]interface{}(nil), required...)
+			for _, name := range more {
+				if !containsJSON(union, name) {
+					union = append(union, name)
+				}
+			}
+			into[keyword] = union
+		case "properties":
+			properties, _ := existing.(map[string]interface{})
+			more, _ := value.(map[string]interface{})
+			merged := make(map[string]interface{}, len(properties)+len(more))
+			for name, property := range properties {
+				merged[name] = property
+			}
+			for name, property := range more {
+				if previous, ok := merged[name]; ok {
+					merged[name] = map[string]interface{}{"allOf": []interface{}{previous, property}}
+				} else {
+					merged[name] = property
+				}
+			}
+			into[keyword] = merged
+		case "type":
+			more := stringsToValues(schemaTypes(from))
+			types := make([]interface{}, 0)
+			for _, name := range schemaTypes(into) {
+				if containsJSON(more, name) {
+					types = append(types, name)
+				} else if (name == "number" && containsJSON(more, "integer")) || (name == "integer" && containsJSON(more, "number")) {
+					types = append(types, "integer")
+				}
+			}
+			into[keyword] = types
+		case "enum":
+			values, _ := existing.([]interface{})
+			more, _ := value.([]interface{})
+			common := make([]interface{}, 0)
+			for _, candidate := range values {
+				if containsJSON(more, candidate) {
+					common = append(common, candidate)
+				}
+			}
+			into[keyword] = common
+		}
+	}
+}
+
+// Returns the strings as decoded JSON values
+func stringsToValues(texts []string) []interface{} {
+	values := make([]interface{}, len(texts))
+	for i, text := range texts {
+		values[i] = text
+	}
+	return values
+}
+
+// Returns a pseudo random instance of the schema, as decoded by encoding/json: nil, bool, float64,
+// string, []interface{} or map[string]interface{}
+func (s *JSONSchema) Instance() (interface{}, error) {
+	return s.InstanceWith(defaultGenerator)
+}
+
+// Returns a pseudo random instance of the schema drawn from the generator, the same seed and schema give
+// the same instance
+func (s *JSONSchema) InstanceWith(g *Generator) (interface{}, error) {
+	return s.instanceOf(g, s.root)
+}
+
+// Returns a pseudo random instance of the schema, a part of the document like one of its definitions
+func (s *JSONSchema) instanceOf(g *Generator, schema interface{}) (interface{}, error) {
+	var last []SchemaError
+	for attempt := 0; attempt < maxJSONSchemaAttempts; attempt++ {
+		instance, err := s.generate(g, schema, 0, 0)
+		if err != nil {
+			return nil, err
+		}
+		if last = s.validate(schema, instance, "", 0); len(last) == 0 {
+			return instance, nil
+		}
+	}
+	return nil, fmt.Errorf("error, could not generate an instance of the JSON schema in %d attempts, the last one "+
+		"failed with %v", maxJSONSchemaAttempts, last)
+}
+
+// Returns a pseudo random instance of the schema encoded as JSON
+func (s *JSONSchema) InstanceJSON() (string, error) {
+	instance, err := s.Instance()
+	if err != nil {
+		return "", err
+	}
+	return canonicalJSON(instance), nil
+}
+
+// Returns whether reading the schema follows a $ref, its own or one of its allOf
+func hasSchemaRef(schema interface{}) bool {
+	m, ok := schema.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	if _, ok := m["$ref"]; ok {
+		return true
+	}
+	parts, _ := m["allOf"].([]interface{})
+	for _, part := range parts {
+		if hasSchemaRef(part) {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns a value for the schema, it usually satisfies it. The refs are the $ref followed to reach the
+// schema, a schema that requires itself through them gives an error instead of recursing forever.
+func (s *JSONSchema) generate(g *Generator, schema interface{}, depth, refs int) (interface{}, error) {
+	if hasSchemaRef(schema) {
+		refs++
+	}
+	if refs > maxJSONSchemaRefDepth {
+		return nil, fmt.Errorf("error, more than %d nested $ref while generating an instance of the JSON schema, "+
+			"it may require itself", maxJSONSchemaRefDepth)
+	}
+	m, err := s.flatten(schema, 0)
+	if err != nil {
+		return nil, err
+	}
+	if constant, ok := m["const"]; ok {
+		return constant, nil
+	}
+	if enum, ok := m["enum"].([]interface{}); ok {
+		if len(enum) == 0 {
+			return nil, fmt.Errorf("error, the JSON schema has an empty enum")
+		}
+		return enum[g.Int(0, len(enum)-1)], nil
+	}
+	for _, keyword := range []string{"oneOf", "anyOf"} {
+		if branches, ok := m[keyword].([]interface{}); ok && len(branches) > 0 {
+			chosen := branches[g.Int(0, len(branches)-1)]
+			branch, err := s.flatten(chosen, 0)
+			if err != nil {
+				return nil, err
+			}
+			rest := make(map[string]interface{}, len(m))
+			for name, value := range m {
+				if name != keyword {
+					rest[name] = value
+				}
+			}
+			mergeSchemas(rest, branch)
+			if hasSchemaRef(chosen) {
+				refs++
+			}
+			return s.generate(g, rest, depth, refs)
+		}
+	}
+	switch s.chooseType(g, m, depth) {
+	case "null":
+		return nil, nil
+	case "boolean":
+		return g.Int(0, 1) == 0, nil
+	case "integer":
+		return s.randomSchemaNumber(g, m, true)
+	case "number":
+		return s.randomSchemaNumber(g, m, false)
+	case "string":
+		return s.randomSchemaString(g, m)
+	case "array":
+		return s.randomSchemaArray(g, m, depth, refs)
+	}
+	return s.randomSchemaObject(g, m, depth, refs)
+}
+
+// Keywords that only apply to one type, used to guess the type of the schemas without the type keyword
+var jsonSchemaTypeKeywords = map[string]string{
+	"properties": "object", "required": "object", "additionalProperties": "object", "patternProperties": "object",
+	"minProperties": "object", "maxProperties": "object", "items": "array", "prefixItems": "array",
+	"additionalItems": "array", "minItems": "array", "maxItems": "array", "uniqueItems": "array",
+	"minLength": "string", "maxLength": "string", "pattern": "string", "format": "string", "minimum": "number",
+	"maximum": "number", "exclusiveMinimum": "number", "exclusiveMaximum": "number", "multipleOf": "number",
+}
+
+// Returns one of the types of the schema, or a guess from its keywords if it has no type keyword
+func (s *JSONSchema) chooseType(g *Generator, m map[string]interface{}, depth int) string {
+	types := schemaTypes(m)
+	if _, ok := m["type"]; !ok {
+		for _, keyword := range sortedKeys(m) {
+			if kind, ok := jsonSchemaTypeKeywords[keyword]; ok && !containsJSON(stringsToValues(types), kind) {
+				types = append(types, kind)
+			}
+		}
+		if len(types) == 0 {
+			types = []string{"null", "boolean", "integer", "number", "string"}
+			if depth < s.Options.MaxDepth {
+				types = append(types, "array", "object")
+			}
+		}
+	}
+	if len(types) == 0 {
+		return "null"
+	}
+	return types[g.Int(0, len(types)-1)]
+}
+
+// Returns a number between the bounds of the schema, or of the options if the schema has none
+func (s *JSONSchema) randomSchemaNumber(g *Generator, m map[string]interface{}, integer bool) (float64, error) {
+	low, high := s.Options.MinNumber, s.Options.MaxNumber
+	lowSet, highSet := false, false
+	if minimum, ok := schemaNumber(m, "minimum"); ok {
+		low, lowSet = minimum, true
+	}
+	if minimum, ok := schemaNumber(m, "exclusiveMinimum"); ok && (!lowSet || minimum >= low) {
+		low, lowSet = math.Nextafter(minimum, math.Inf(1)), true
+	}
+	if maximum, ok := schemaNumber(m, "maximum"); ok {
+		high, highSet = maximum, true
+	}
+	if maximum, ok := schemaNumber(m, "exclusiveMaximum"); ok && (!highSet || maximum <= high) {
+		high, highSet = math.Nextafter(maximum, math.Inf(-1)), true
+	}
+	span := s.Options.MaxNumber - s.Options.MinNumber
+	if lowSet && !highSet && high < low {
+		high = math.Min(low+span, math.MaxFloat64)
+	} else if highSet && !lowSet && high < low {
+		low = math.Max(high-span, -math.MaxFloat64)
+	}
+	if step, ok := schemaNumber(m, "multipleOf"); ok && step > 0 {
+		first, last := math.Ceil(low/step), math.Floor(high/step)
+		if first > last {
+			return 0, fmt.Errorf("error, there is no multiple of %v in [%v,%v]", step, low, high)
+		}
+		if last-first < 1<<53 {
+			return (first + float64(g.Int64(0, int64(last-first)))) * step, nil
+		}
+		return math.Floor(interpolate(first, last, g.Float64(0, 1))) * step, nil
+	}
+	if integer {
+		low, high = math.Ceil(low), math.Floor(high)
+		if low > high {
+			return 0, fmt.Errorf("error, there is no integer in [%v,%v]", low, high)
+		}
+		if high-low < 1<<53 {
+			return low + float64(g.Int64(0, int64(high-low))), nil
+		}
+		return math.Floor(interpolate(low, high, g.Float64(0, 1))), nil
+	}
+	if low > high {
+		return 0, fmt.Errorf("error, the interval [%v,%v] is empty", low, high)
+	}
+	if low == high {
+		return low, nil
+	}
+	return interpolate(low, high, g.Float64(0, 1)), nil
+}
+
+// Returns a host name like "k2jd8.com.co"
+func randomSchemaHostname(g *Generator) string {
+	tld, _ := g.ChooseString(topLevelDomains)
+	return g.StringExactLength(1, alphaLower) + g.String(2, 10, alphaLower+"0123456789") + "." + tld
+}
+
+// Generators of the string formats
+var jsonSchemaFormats = map[string]func(g *Generator) string{
+	"email": (*Generator).Email,
+	"phone": func(g *Generator) string {
+		phone, _ := g.Phone("CO", PhoneAnyLine)
+		return phone.E164()
+	},
+	"uuid":     (*Generator).UUIDv4,
+	"hostname": randomSchemaHostname,
+	"uri": func(g *Generator) string {
+		return "https://" + randomSchemaHostname(g) + "/" + g.String(1, 10, alphaLower+"0123456789")
+	},
+	"ipv4": func(g *Generator) string { return net.IP(g.Bytes(4)).String() },
+	"ipv6": func(g *Generator) string { return net.IP(g.Bytes(16)).String() },
+	"byte": func(g *Generator) string { return base64.StdEncoding.EncodeToString(g.Bytes(g.Int(1, 12))) },
+	"date-time": func(g *Generator) string {
+		return time.Unix(g.Int64(0, jsonSchemaMaxSeconds), 0).UTC().Format(time.RFC3339)
+	},
+	"date": func(g *Generator) string {
+		return time.Unix(g.Int64(0, jsonSchemaMaxSeconds), 0).UTC().Format("2006-01-02")
+	},
+}
+
+// Returns a string of the pattern and the format of the schema with a length between its bounds
+func (s *JSONSchema) randomSchemaString(g *Generator, m map[string]interface{}) (string, error) {
+	minLength, _ := schemaNumber(m, "minLength")
+	maxLength := minLength + float64(s.Options.MaxLength)
+	if maximum, ok := schemaNumber(m, "maxLength"); ok {
+		maxLength = math.Min(maximum, maxLength)
+	}
+	if maxLength < minLength {
+		return "", fmt.Errorf("error, no string has a length in [%v,%v]", minLength, maxLength)
+	}
+	if pattern, ok := m["pattern"].(string); ok {
+		options := RegexOptions{MaxRepeat: int(maxLength)}
+		var text string
+		for attempt := 0; attempt < maxJSONSchemaAttempts; attempt++ {
+			var err error
+			if text, err = g.FromRegex(pattern, options); err != nil {
+				return "", err
+			}
+			if length := float64(utf8.RuneCountInString(text)); length >= minLength && length <= maxLength {
+				break
+			}
+		}
+		return text, nil
+	}
+	if format, ok := m["format"].(string); ok {
+		if generator, ok := jsonSchemaFormats[format]; ok {
+			return generator(g), nil
+		}
+	}
+	return g.String(int(minLength), int(maxLength), alphaDigits), nil
+}
+
+// Returns an array with a number of items between the bounds of the schema, every item of its schema.
+// With uniqueItems the items are added to a set until it has the chosen size, as the Random*Set
+// functions do.
+func (s *JSONSchema) randomSchemaArray(g *Generator, m map[string]interface{}, depth, refs int) ([]interface{}, error) {
+	minItems, _ := schemaNumber(m, "minItems")
+	maxItems := math.Max(minItems, float64(s.Options.MaxItems))
+	if maximum, ok := schemaNumber(m, "maxItems"); ok {
+		maxItems = math.Min(maximum, maxItems)
+	}
+	if depth >= s.Options.MaxDepth {
+		maxItems = minItems
+	}
+	if maxItems < minItems {
+		return nil, fmt.Errorf("error, no array has a number of items in [%v,%v]", minItems, maxItems)
+	}
+	count := g.Int(int(minItems), int(maxItems))
+	prefix, rest, _ := arraySchemas(m)
+	if rest == nil {
+		rest = true
+	}
+	unique, _ := m["uniqueItems"].(bool)
+	items := make([]interface{}, 0, count)
+	seen := make(map[string]bool)
+	for attempt := 0; len(items) < count && attempt < maxJSONSchemaAttempts*count; attempt++ {
+		schema := rest
+		if len(items) < len(prefix) {
+			schema = prefix[len(items)]
+		} else if allowed, ok := rest.(bool); ok && !allowed {
+			break
+		}
+		item, err := s.generate(g, schema, depth+1, refs)
+		if err != nil {
+			return nil, err
+		}
+		if key := canonicalJSON(item); unique {
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// Returns an object with the required properties of the schema and some of the others
+func (s *JSONSchema) randomSchemaObject(g *Generator, m map[string]interface{}, depth,
+	refs int) (map[string]interface{}, error) {
+	properties, _ := m["properties"].(map[string]interface{})
+	additional, ok := m["additionalProperties"]
+	if !ok {
+		additional = true
+	}
+	maxProperties := math.Inf(1)
+	if maximum, ok := schemaNumber(m, "maxProperties"); ok {
+		maxProperties = maximum
+	}
+	minProperties, _ := schemaNumber(m, "minProperties")
+	object := make(map[string]interface{})
+	add := func(name string, schema interface{}) error {
+		if schema == nil {
+			schema = additional
+		}
+		value, err := s.generate(g, schema, depth+1, refs)
+		if err == nil {
+			object[name] = value
+		}
+		return err
+	}
+	required, _ := m["required"].([]interface{})
+	for _, name := range required {
+		if text, ok := name.(string); ok {
+			if err := add(text, properties[text]); err != nil {
+				return nil, err
+			}
+		}
+	}
+	optional := make([]string, 0)
+	for _, name := range sortedKeys(properties) {
+		if _, ok := object[name]; !ok {
+			optional = append(optional, name)
+		}
+	}
+	for _, i := range g.Perm(len(optional)) {
+		if float64(len(object)) >= maxProperties {
+			break
+		}
+		if float64(len(object)) < minProperties || (depth < s.Options.MaxDepth && g.Int(0, 1) == 0) {
+			if err := add(optional[i], properties[optional[i]]); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if allowed, ok := additional.(bool); ok && !allowed {
+		return object, nil
+	}
+	for attempt := 0; float64(len(object)) < minProperties && attempt < maxJSONSchemaAttempts; attempt++ {
+		name := g.String(1, 8, alphaLower)
+		if _, ok := object[name]; !ok {
+			if _, ok := properties[name]; !ok {
+				if err := add(name, nil); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	return object, nil
+}
+
+// A change of the value at a JSON pointer of an instance that should violate one keyword
+type schemaViolation struct {
+	path    string
+	keyword string
+	mutate  func() interface{}
+}
+
+// Returns a pseudo random instance that violates exactly one keyword of the schema, for negative tests.
+// The keywords inside anyOf, oneOf and not are never the violated one.
+func (s *JSONSchema) InvalidInstance() (InvalidJSONInstance, error) {
+	return s.InvalidInstanceWith(defaultGenerator)
+}
+
+// Returns a pseudo random instance that violates exactly one keyword of the schema drawn from the
+// generator, see InvalidInstance
+func (s *JSONSchema) InvalidInstanceWith(g *Generator) (InvalidJSONInstance, error) {
+	return s.invalidInstanceOf(g, s.root)
+}
+
+// Returns an instance that violates exactly one keyword of the schema, a part of the document
+func (s *JSONSchema) invalidInstanceOf(g *Generator, schema interface{}) (InvalidJSONInstance, error) {
+	for attempt := 0; attempt < maxJSONSchemaAttempts; attempt++ {
+		instance, err := s.instanceOf(g, schema)
+		if err != nil {
+			return InvalidJSONInstance{}, err
+		}
+		violations, err := s.violations(g, schema, instance, "")
+		if err != nil {
+			return InvalidJSONInstance{}, err
+		}
+		if len(violations) == 0 {
+			continue
+		}
+		violation := violations[g.Int(0, len(violations)-1)]
+		invalid := replaceJSONValue(instance, violation.path, violation.mutate())
+		errors := s.validate(schema, invalid, "", 0)
+		if len(errors) == 1 && errors[0].Keyword == violation.keyword && errors[0].Path == violation.path {
+			return InvalidJSONInstance{Instance: invalid, Keyword: violation.keyword, Path: violation.path}, nil
+		}
+	}
+	return InvalidJSONInstance{}, fmt.Errorf("error, could not generate an instance that violates exactly one " +
+		"keyword of the JSON schema")
+}
+
+// Returns the value with the value at the JSON pointer path replaced, the value given is not modified
+func replaceJSONValue(value interface{}, path string, replacement interface{}) interface{} {
+	if path == "" {
+		return replacement
+	}
+	tokens := strings.SplitN(path[1:], "/", 2)
+	rest := ""
+	if len(tokens) == 2 {
+		rest = "/" + tokens[1]
+	}
+	token := strings.Replace(strings.Replace(tokens[0], "~1", "/", -1), "~0", "~", -1)
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		copied := make(map[string]interface{}, len(typed))
+		for key, member := range typed {
+			copied[key] = member
+		}
+		copied[token] = replaceJSONValue(typed[token], rest, replacement)
+		return copied
+	case []interface{}:
+		copied := append([]interface{}(nil), typed...)
+		index, _ := strconv.Atoi(token)
+		copied[index] = replaceJSONValue(typed[index], rest, replacement)
+		return copied
+	}
+	return value
+}
+
+// Values of every JSON type, the ones not allowed by a type keyword violate it
+var jsonTypeSamples = []interface{}{nil, true, 7.0, 2.5, "text", []interface{}{}, map[string]interface{}{}}
+
+// Returns the changes of the instance, a valid value at path, that could violate a keyword of the schema
+func (s *JSONSchema) violations(g *Generator, schema, instance interface{}, path string) ([]schemaViolation, error) {
+	m, err := s.flatten(schema, 0)
+	if err != nil {
+		return nil, err
+	}
+	violations := make([]schemaViolation, 0)
+	add := func(keyword string, mutate func() interface{}) {
+		violations = append(violations, schemaViolation{path, keyword, mutate})
+	}
+	if enum, ok := m["enum"].([]interface{}); ok {
+		add("enum", func() interface{} { return differentJSONValue(g, instance, enum) })
+	} else if constant, ok := m["const"]; ok {
+		add("const", func() interface{} { return differentJSONValue(g, instance, []interface{}{constant}) })
+	} else if types := schemaTypes(m); types != nil {
+		others := make([]interface{}, 0)
+		for _, sample := range jsonTypeSamples {
+			if !matchesJSONType(sample, types) {
+				others = append(others, sample)
+			}
+		}
+		if len(others) > 0 {
+			add("type", func() interface{} { return others[g.Int(0, len(others)-1)] })
+		}
+	}
+	switch value := instance.(type) {
+	case string:
+		runes := []rune(value)
+		if minimum, ok := schemaNumber(m, "minLength"); ok && minimum > 0 {
+			add("minLength", func() interface{} { return string(runes[:int(minimum)-1]) })
+		}
+		if maximum, ok := schemaNumber(m, "maxLength"); ok {
+			add("maxLength", func() interface{} {
+				longer := append([]rune(nil), runes...)
+				for float64(len(longer)) <= maximum {
+					longer = append(longer, rune(alphaLower[g.Int(0, len(alphaLower)-1)]))
+				}
+				return string(longer)
+			})
+		}
+		if pattern, ok := m["pattern"].(string); ok {
+			add("pattern", func() interface{} {
+				text, _ := g.FromRegex(pattern, RegexOptions{Negate: true})
+				return text
+			})
+		}
+	case float64:
+		integer := containsJSON(stringsToValues(schemaTypes(m)), "integer")
+		below := func(bound float64) float64 {
+			if integer {
+				return math.Ceil(bound) - 1
+			}
+			return bound - 1
+		}
+		above := func(bound float64) float64 {
+			if integer {
+				return math.Floor(bound) + 1
+			}
+			return bound + 1
+		}
+		if minimum, ok := schemaNumber(m, "minimum"); ok {
+			add("minimum", func() interface{} { return below(minimum) })
+		}
+		if maximum, ok := schemaNumber(m, "maximum"); ok {
+			add("maximum", func() interface{} { return above(maximum) })
+		}
+		if minimum, ok := schemaNumber(m, "exclusiveMinimum"); ok {
+			add("exclusiveMinimum", func() interface{} { return minimum })
+		}
+		if maximum, ok := schemaNumber(m, "exclusiveMaximum"); ok {
+			add("exclusiveMaximum", func() interface{} { return maximum })
+		}
+		if step, ok := schemaNumber(m, "multipleOf"); ok && step > 0 {
+			add("multipleOf", func() interface{} {
+				if integer && step > 1 {
+					return value + 1
+				}
+				return value + step/2
+			})
+		}
+	case []interface{}:
+		if minimum, ok := schemaNumber(m, "minItems"); ok && minimum > 0 {
+			add("minItems", func() interface{} { return append([]interface{}(nil), value[:int(minimum)-1]...) })
+		}
+		prefix, rest, _ := arraySchemas(m)
+		if maximum, ok := schemaNumber(m, "maxItems"); ok && len(prefix) <= int(maximum) {
+			add("maxItems", func() interface{} {
+				longer := append([]interface{}(nil), value...)
+				for float64(len(longer)) <= maximum {
+					schema := rest
+					if len(longer) < len(prefix) {
+						schema = prefix[len(longer)]
+					}
+					item, err := s.generate(g, schema, s.Options.MaxDepth, 0)
+					if err != nil {
+						break
+					}
+					longer = append(longer, item)
+				}
+				return longer
+			})
+		}
+		if unique, _ := m["uniqueItems"].(bool); unique && len(value) > 1 {
+			add("uniqueItems", func() interface{} {
+				repeated := append([]interface{}(nil), value...)
+				repeated[len(repeated)-1] = repeated[0]
+				return repeated
+			})
+		}
+		for i, item := range value {
+			schema := rest
+			if i < len(prefix) {
+				schema = prefix[i]
+			}
+			if schema == nil {
+				continue
+			}
+			inner, err := s.violations(g, schema, item, childPath(path, strconv.Itoa(i)))
+			if err != nil {
+				return nil, err
+			}
+			violations = append(violations, inner...)
+		}
+	case map[string]interface{}:
+		properties, _ := m["properties"].(map[string]interface{})
+		without := func(name string) map[string]interface{} {
+			copied := make(map[string]interface{}, len(value))
+			for key, member := range value {
+				if key != name {
+					copied[key] = member
+				}
+			}
+			return copied
+		}
+		required, _ := m["required"].([]interface{})
+		for _, name := range required {
+			if text, ok := name.(string); ok {
+				add("required", func() interface{} { return without(text) })
+			}
+		}
+		if allowed, ok := m["additionalProperties"].(bool); ok && !allowed {
+			add("additionalProperties", func() interface{} {
+				extended := without("")
+				extended["unexpected"+g.StringExactLength(4, alphaDigits)] = true
+				return extended
+			})
+		}
+		if minimum, ok := schemaNumber(m, "minProperties"); ok && minimum > 0 {
+			add("minProperties", func() interface{} {
+				smaller := without("")
+				for _, name := range sortedKeys(value) {
+					if float64(len(smaller)) < minimum || containsJSON(required, name) {
+						continue
+					}
+					delete(smaller, name)
+				}
+				for _, name := range sortedKeys(smaller) {
+					if float64(len(smaller)) < minimum {
+						break
+					}
+					delete(smaller, name)
+				}
+				return smaller
+			})
+		}
+		if maximum, ok := schemaNumber(m, "maxProperties"); ok {
+			add("maxProperties", func() interface{} {
+				larger := without("")
+				for float64(len(larger)) <= maximum {
+					larger["extra"+g.StringExactLength(4, alphaDigits)] = true
+				}
+				return larger
+			})
+		}
+		for _, name := range sortedKeys(value) {
+			if property, ok := properties[name]; ok {
+				inner, err := s.violations(g, property, value[name], childPath(path, name))
+				if err != nil {
+					return nil, err
+				}
+				violations = append(violations, inner...)
+			}
+		}
+	}
+	return violations, nil
+}
+
+// Returns a value of the same type as value that is not one of the values, or null if there is none
+func differentJSONValue(g *Generator, value interface{}, values []interface{}) interface{} {
+	candidates := make([]interface{}, 0, 4)
+	switch typed := value.(type) {
+	case string:
+		candidates = append(candidates, typed+g.StringExactLength(1, alphaDigits), g.String(1, 8, alphaDigits))
+	case float64:
+		candidates = append(candidates, typed+1, typed-1, typed+float64(g.Int(2, 1000)))
+	case bool:
+		candidates = append(candidates, !typed)
+	}
+	candidates = append(candidates, nil, "text", 7.0)
+	for _, candidate := range candidates {
+		if !containsJSON(values, candidate) {
+			return candidate
+		}
+	}
+	return map[string]interface{}{}
+}
@@ -0,0 +1,214 @@
+package randgen
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// A draft-07 schema with most of the supported keywords
+const testDraft07Schema = `{
+	"$schema": "http://json-schema.org/draft-07/schema#",
+	"type": "object",
+	"required": ["id", "name", "age", "tags", "status", "address"],
+	"additionalProperties": false,
+	"properties": {
+		"id": {"type": "string", "pattern": "^[A-Z]{3}-\\d{4}$"},
+		"name": {"type": "string", "minLength": 2, "maxLength": 10},
+		"email": {"type": "string", "format": "email"},
+		"age": {"type": "integer", "minimum": 18, "exclusiveMaximum": 100},
+		"score": {"type": "number", "minimum": 0, "maximum": 1},
+		"price": {"type": "number", "multipleOf": 0.25, "exclusiveMinimum": 0, "maximum": 50},
+		"tags": {"type": "array", "items": {"type": "string", "enum": ["a", "b", "c", "d", "e"]},
+			"minItems": 1, "maxItems": 4, "uniqueItems": true},
+		"status": {"enum": ["active", "inactive", null]},
+		"version": {"const": 2},
+		"address": {"$ref": "#/definitions/address"},
+		"contact": {"oneOf": [{"type": "string", "maxLength": 5}, {"type": "integer", "minimum": 0}]},
+		"point": {"type": "array", "items": [{"type": "number"}, {"type": "number"}], "additionalItems": false},
+		"children": {"type": "array", "items": {"$ref": "#/definitions/node"}, "maxItems": 2}
+	},
+	"definitions": {
+		"address": {
+			"type": "object",
+			"required": ["street", "zip"],
+			"properties": {
+				"street": {"type": "string", "minLength": 1},
+				"zip": {"type": "string", "pattern": "^\\d{5}$"}
+			},
+			"minProperties": 2,
+			"maxProperties": 3
+		},
+		"node": {
+			"type": "object",
+			"required": ["value"],
+			"properties": {"value": {"anyOf": [{"type": "null"}, {"type": "boolean"}]},
+				"children": {"type": "array", "items": {"$ref": "#/definitions/node"}}}
+		}
+	}
+}`
+
+// A 2020-12 schema with prefixItems, $defs, allOf and not
+const test202012Schema = `{
+	"$schema": "https://json-schema.org/draft/2020-12/schema",
+	"$defs": {"positive": {"type": "integer", "exclusiveMinimum": 0}},
+	"type": "object",
+	"required": ["pair", "count", "code"],
+	"properties": {
+		"pair": {"type": "array", "prefixItems": [{"type": "string"}, {"$ref": "#/$defs/positive"}], "items": false},
+		"count": {"allOf": [{"$ref": "#/$defs/positive"}, {"maximum": 10}]},
+		"code": {"type": "string", "pattern": "^[a-z]{2,6}$", "not": {"const": "abc"}}
+	}
+}`
+
+func TestJSONSchemaInstance(t *testing.T) {
+	for _, text := range []string{testDraft07Schema, test202012Schema} {
+		schema, err := ParseJSONSchema([]byte(text))
+		assert.Nil(t, err)
+		for test := 0; test < 300; test++ {
+			instance, err := schema.Instance()
+			assert.Nil(t, err)
+			assert.Empty(t, schema.Validate(instance))
+			encoded, err := schema.InstanceJSON()
+			assert.Nil(t, err)
+			var decoded interface{}
+			assert.Nil(t, json.Unmarshal([]byte(encoded), &decoded))
+			assert.Empty(t, schema.Validate(decoded), encoded)
+		}
+	}
+	schema, _ := ParseJSONSchema([]byte(testDraft07Schema))
+	instance, _ := schema.Instance()
+	object := instance.(map[string]interface{})
+	assert.Regexp(t, regexp.MustCompile(`^[A-Z]{3}-\d{4}$`), object["id"])
+	assert.True(t, object["age"].(float64) >= 18 && object["age"].(float64) < 100)
+	tags := object["tags"].([]interface{})
+	assert.True(t, len(tags) >= 1 && len(tags) <= 4)
+}
+
+func TestJSONSchemaRecursiveRef(t *testing.T) {
+	schema, err := ParseJSONSchema([]byte(`{"$ref": "#/$defs/node", "$defs": {"node": {"type": "object",
+		"required": ["next"], "properties": {"next": {"$ref": "#/$defs/node"}}}}}`))
+	assert.Nil(t, err)
+	_, err = schema.Instance()
+	assert.NotNil(t, err)
+	_, err = schema.InvalidInstance()
+	assert.NotNil(t, err)
+	schema, _ = ParseJSONSchema([]byte(`{"$ref": "#/$defs/node", "$defs": {"node": {"type": "object",
+		"required": ["next"], "properties": {"next": {"oneOf": [{"$ref": "#/$defs/node"}]}}}}}`))
+	_, err = schema.Instance()
+	assert.NotNil(t, err)
+	schema, _ = ParseJSONSchema([]byte(`{"$ref": "#/$defs/node", "$defs": {"node": {"type": "object",
+		"required": ["value"], "properties": {"value": {"type": "integer"}, "next": {"$ref": "#/$defs/node"}}}}}`))
+	for test := 0; test < 50; test++ {
+		instance, err := schema.InstanceWith(NewGenerator(int64(test)))
+		assert.Nil(t, err)
+		assert.Empty(t, schema.Validate(instance))
+	}
+}
+
+func TestJSONSchemaValidate(t *testing.T) {
+	schema, err := ParseJSONSchema([]byte(testDraft07Schema))
+	assert.Nil(t, err)
+	valid := map[string]interface{}{"id": "ABC-1234", "name": "Ana", "age": 30.0, "tags": []interface{}{"a", "b"},
+		"status": nil, "address": map[string]interface{}{"street": "Calle 1", "zip": "05001"}}
+	assert.Empty(t, schema.Validate(valid))
+	invalid := map[string]interface{}{"id": "ABC-1234", "name": "A", "age": 100.0, "tags": []interface{}{"a", "a"},
+		"status": "gone", "address": map[string]interface{}{"zip": "5001"}, "other": 1.0}
+	keywords := make(map[string]string)
+	for _, schemaError := range schema.Validate(invalid) {
+		keywords[schemaError.Path+" "+schemaError.Keyword] = schemaError.Message
+	}
+	for _, expected := range []string{"/name minLength", "/age exclusiveMaximum", "/tags uniqueItems", "/status enum",
+		"/address required", "/address minProperties", "/address/zip pattern", " additionalProperties"} {
+		assert.Contains(t, keywords, expected)
+	}
+	assert.Len(t, keywords, 8)
+	falseSchema, err := ParseJSONSchema([]byte(`false`))
+	assert.Nil(t, err)
+	assert.Len(t, falseSchema.Validate(1.0), 1)
+	_, err = falseSchema.Instance()
+	assert.NotNil(t, err)
+}
+
+func TestJSONSchemaInvalidInstance(t *testing.T) {
+	for _, text := range []string{testDraft07Schema, test202012Schema} {
+		schema, _ := ParseJSONSchema([]byte(text))
+		keywords := make(map[string]bool)
+		for test := 0; test < 300; test++ {
+			invalid, err := schema.InvalidInstance()
+			assert.Nil(t, err)
+			errors := schema.Validate(invalid.Instance)
+			if assert.Len(t, errors, 1) {
+				assert.Equal(t, invalid.Keyword, errors[0].Keyword)
+				assert.Equal(t, invalid.Path, errors[0].Path)
+			}
+			keywords[invalid.Keyword] = true
+		}
+		assert.True(t, len(keywords) >= 5, "%v", keywords)
+	}
+	schema, _ := ParseJSONSchema([]byte(`true`))
+	_, err := schema.InvalidInstance()
+	assert.NotNil(t, err)
+}
+
+func TestLoadJSONSchema(t *testing.T) {
+	directory, err := ioutil.TempDir("", "jsonschema")
+	assert.Nil(t, err)
+	defer os.RemoveAll(directory)
+	path := filepath.Join(directory, "schema.json")
+	assert.Nil(t, ioutil.WriteFile(path, []byte(test202012Schema), 0644))
+	schema, err := LoadJSONSchema(path)
+	assert.Nil(t, err)
+	instance, err := schema.Instance()
+	assert.Nil(t, err)
+	assert.Empty(t, schema.Validate(instance))
+	_, err = LoadJSONSchema(filepath.Join(directory, "missing.json"))
+	assert.NotNil(t, err)
+	for _, text := range []string{`{`, `[1]`, `{"$ref": "other.json#/a"}`, `{"$ref": "#/$defs/missing"}`,
+		`{"$ref": "#"}`, `{"type": "integer", "minimum": 1, "maximum": 0}`} {
+		schema, err := ParseJSONSchema([]byte(text))
+		if err == nil {
+			_, err = schema.Instance()
+		}
+		assert.NotNil(t, err, text)
+	}
+}
+
+func TestJSONSchemaInstanceWith(t *testing.T) {
+	schema, _ := ParseJSONSchema([]byte(testDraft07Schema))
+	first, second := NewGenerator(21), NewGenerator(21)
+	for test := 0; test < 20; test++ {
+		instance, err := schema.InstanceWith(first)
+		assert.Nil(t, err)
+		again, _ := schema.InstanceWith(second)
+		assert.Equal(t, canonicalJSON(instance), canonicalJSON(again))
+		invalid, err := schema.InvalidInstanceWith(first)
+		assert.Nil(t, err)
+		invalidAgain, _ := schema.InvalidInstanceWith(second)
+		assert.Equal(t, invalid.Keyword+invalid.Path, invalidAgain.Keyword+invalidAgain.Path)
+		assert.Equal(t, canonicalJSON(invalid.Instance), canonicalJSON(invalidAgain.Instance))
+	}
+}
+
+func TestJSONSchemaHugeNumbers(t *testing.T) {
+	for _, text := range []string{`{"type": "number", "minimum": -1e308, "maximum": 1e308}`,
+		`{"type": "integer", "minimum": -1e308, "maximum": 1e308}`,
+		`{"type": "number", "minimum": -1e308, "maximum": 1e308, "multipleOf": 1e290}`,
+		`{"type": "number", "exclusiveMinimum": 1.7e308}`} {
+		schema, err := ParseJSONSchema([]byte(text))
+		assert.Nil(t, err)
+		for test := 0; test < 100; test++ {
+			instance, err := schema.InstanceWith(NewGenerator(int64(test)))
+			assert.Nil(t, err, text)
+			number, _ := instance.(float64)
+			assert.False(t, math.IsInf(number, 0), text)
+			assert.Empty(t, schema.Validate(instance), text)
+		}
+	}
+}
@@ -0,0 +1,348 @@
+package randgen
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Gender of the first names returned by RandomFirstName
+type Gender int
+
+const (
+	GenderAny Gender = iota
+	GenderMale
+	GenderFemale
+)
+
+// A city of a locale, the postal codes of the city start by PostalPrefix
+type LocaleCity struct {
+	Name         string `json:"name"`
+	Region       string `json:"region"`
+	PostalPrefix string `json:"postalPrefix"`
+}
+
+// A Locale holds the datasets used by the "person" style generators. Postal code and phone formats use
+// '#' for a random digit. Street formats use the placeholders {streetType}, {streetName}, {number},
+// {letter}, {house} and {digit} instead, so a '#' in them stays, like in "Calle 45 # 12 - 30". Custom
+// locales can be loaded from JSON files with LoadLocaleFile, the JSON keys are the ones of the struct tags.
+type Locale struct {
+	Code             string       `json:"code"`    // like es_CO
+	Country          string       `json:"country"` // ISO 3166-1 alpha-2 code
+	MaleFirstNames   []string     `json:"maleFirstNames"`
+	FemaleFirstNames []string     `json:"femaleFirstNames"`
+	LastNames        []string     `json:"lastNames"`
+	DoubleSurnames   bool         `json:"doubleSurnames"` // full names carry two last names, like in Spanish
+	StreetTypes      []string     `json:"streetTypes"`
+	StreetNames      []string     `json:"streetNames"`
+	StreetFormats    []string     `json:"streetFormats"`
+	MaxHouseNumber   int          `json:"maxHouseNumber"` // largest {house} of the street formats, 9999 when zero
+	Cities           []LocaleCity `json:"cities"`
+	PostalCodeFormat string       `json:"postalCodeFormat"`
+	PhoneFormats     []string     `json:"phoneFormats"`
+	CompanySuffixes  []string     `json:"companySuffixes"`
+	EmailDomains     []string     `json:"emailDomains"`
+}
+
+// A postal address of a locale
+type LocaleAddress struct {
+	Street     string
+	City       string
+	Region     string
+	PostalCode string
+	Country    string
+}
+
+// Registered locales by code, guarded by localesMutex since they can be registered while others are read
+var locales = map[string]Locale{}
+var localesMutex sync.RWMutex
+
+func init() {
+	for _, locale := range []Locale{localeEsCO, localeEsMX, localeEnUS, localePtBR} {
+		if err := RegisterLocale(locale); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// Returns an error if the locale misses one of the datasets the generators need
+func (l Locale) Validate() error {
+	missing := make([]string, 0)
+	check := func(name string, size int) {
+		if size == 0 {
+			missing = append(missing, name)
+		}
+	}
+	check("code", len(l.Code))
+	check("country", len(l.Country))
+	check("maleFirstNames", len(l.MaleFirstNames))
+	check("femaleFirstNames", len(l.FemaleFirstNames))
+	check("lastNames", len(l.LastNames))
+	check("streetTypes", len(l.StreetTypes))
+	check("streetFormats", len(l.StreetFormats))
+	check("cities", len(l.Cities))
+	check("postalCodeFormat", len(l.PostalCodeFormat))
+	check("phoneFormats", len(l.PhoneFormats))
+	check("companySuffixes", len(l.CompanySuffixes))
+	check("emailDomains", len(l.EmailDomains))
+	for _, format := range l.StreetFormats {
+		if strings.Contains(format, "{streetName}") && len(l.StreetNames) == 0 {
+			check("streetNames", 0)
+			break
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("error, locale %s misses %s", l.Code, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// Adds the locale to the registry, replacing a previous locale with the same code
+func RegisterLocale(locale Locale) error {
+	if err := locale.Validate(); err != nil {
+		return err
+	}
+	localesMutex.Lock()
+	defer localesMutex.Unlock()
+	locales[locale.Code] = locale
+	return nil
+}
+
+// Reads a locale from a JSON file, it is not registered
+func LoadLocaleFile(path string) (Locale, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return Locale{}, err
+	}
+	var locale Locale
+	if err := json.Unmarshal(data, &locale); err != nil {
+		return Locale{}, fmt.Errorf("error, invalid locale file %s: %v", path, err)
+	}
+	return locale, locale.Validate()
+}
+
+// Reads a locale from a JSON file and adds it to the registry
+func RegisterLocaleFile(path string) error {
+	locale, err := LoadLocaleFile(path)
+	if err != nil {
+		return err
+	}
+	return RegisterLocale(locale)
+}
+
+// Returns the registered locale with the given code
+func GetLocale(code string) (Locale, error) {
+	localesMutex.RLock()
+	locale, ok := locales[code]
+	localesMutex.RUnlock()
+	if !ok {
+		return Locale{}, fmt.Errorf("error, unknown locale %s", code)
+	}
+	return locale, nil
+}
+
+// Returns the codes of the registered locales, sorted
+func LocaleCodes() []string {
+	localesMutex.RLock()
+	defer localesMutex.RUnlock()
+	codes := make([]string, 0, len(locales))
+	for code := range locales {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// Replaces every '#' of the format by a random digit
+func expandDigits(format string) string {
+	buffer := []byte(format)
+	for i := range buffer {
+		if buffer[i] == '#' {
+			buffer[i] = "0123456789"[RandomInt(0, 9)]
+		}
+	}
+	return string(buffer)
+}
+
+// Returns a pseudo random first name of the locale, GenderAny chooses the gender randomly
+func (l Locale) FirstName(gender Gender) string {
+	return l.firstName(defaultGenerator, gender)
+}
+
+// Returns a first name of the locale drawn from the generator
+func (l Locale) firstName(g *Generator, gender Gender) string {
+	if gender == GenderAny {
+		gender = Gender(g.Int(int(GenderMale), int(GenderFemale)))
+	}
+	if gender == GenderFemale {
+		name, _ := g.ChooseString(l.FemaleFirstNames)
+		return name
+	}
+	name, _ := g.ChooseString(l.MaleFirstNames)
+	return name
+}
+
+// Returns one pseudo random last name of the locale, or two different ones if the locale uses
+// double surnames
+func (l Locale) LastName() string {
+	return l.lastName(defaultGenerator)
+}
+
+// Returns the last names of the locale drawn from the generator
+func (l Locale) lastName(g *Generator) string {
+	first, _ := g.ChooseString(l.LastNames)
+	if !l.DoubleSurnames {
+		return first
+	}
+	others := make([]string, 0, len(l.LastNames))
+	for _, name := range l.LastNames {
+		if name != first {
+			others = append(others, name)
+		}
+	}
+	if len(others) == 0 {
+		return first
+	}
+	second, _ := g.ChooseString(others)
+	return first + " " + second
+}
+
+// Returns a pseudo random full name of the locale, like "Camila Rojas Ospina"
+func (l Locale) FullName(gender Gender) string {
+	return l.FirstName(gender) + " " + l.LastName()
+}
+
+// Returns a pseudo random city of the locale
+func (l Locale) City() LocaleCity {
+	return l.Cities[RandomInt(0, len(l.Cities)-1)]
+}
+
+// Returns a pseudo random postal code of the city following the postal code format of the locale
+func (l Locale) PostalCode(city LocaleCity) string {
+	code := []byte(expandDigits(l.PostalCodeFormat))
+	for i, j := 0, 0; i < len(code) && j < len(city.PostalPrefix); i++ {
+		if l.PostalCodeFormat[i] == '#' {
+			code[i] = city.PostalPrefix[j]
+			j++
+		}
+	}
+	return string(code)
+}
+
+// Returns a pseudo random street line following one of the street formats of the locale
+func (l Locale) Street() string {
+	format, _ := ChooseString(l.StreetFormats)
+	maxHouse := l.MaxHouseNumber
+	if maxHouse < 1 {
+		maxHouse = 9999
+	}
+	replacements := []struct {
+		placeholder string
+		value       func() string
+	}{
+		{"{streetType}", func() string { value, _ := ChooseString(l.StreetTypes); return value }},
+		{"{streetName}", func() string { value, _ := ChooseString(l.StreetNames); return value }},
+		{"{number}", func() string { return fmt.Sprintf("%d", RandomInt(1, 250)) }},
+		{"{letter}", func() string { return randomStreetLetter(defaultGenerator) }},
+		{"{house}", func() string { return fmt.Sprintf("%d", RandomInt(1, maxHouse)) }},
+		{"{digit}", func() string { return strconv.Itoa(RandomInt(0, 9)) }},
+	}
+	for _, replacement := range replacements {
+		for strings.Contains(format, replacement.placeholder) {
+			format = strings.Replace(format, replacement.placeholder, replacement.value(), 1)
+		}
+	}
+	return format
+}
+
+// Returns a pseudo random postal address of the locale
+func (l Locale) Address() LocaleAddress {
+	city := l.City()
+	return LocaleAddress{
+		Street:     l.Street(),
+		City:       city.Name,
+		Region:     city.Region,
+		PostalCode: l.PostalCode(city),
+		Country:    l.Country,
+	}
+}
+
+// Returns a pseudo random phone number following one of the phone formats of the locale
+func (l Locale) Phone() string {
+	format, _ := ChooseString(l.PhoneFormats)
+	return expandDigits(format)
+}
+
+// Returns a pseudo random company name, a last name of the locale and a company suffix
+func (l Locale) CompanyName() string {
+	suffix, _ := ChooseString(l.CompanySuffixes)
+	name, _ := ChooseString(l.LastNames)
+	if RandomInt(0, 2) == 0 {
+		other, _ := ChooseString(l.LastNames)
+		name += " & " + other
+	}
+	return name + " " + suffix
+}
+
+// Returns the address in a single line, like "Rua Paulista, 123, São Paulo - SP, 01310-100, BR"
+func (a LocaleAddress) String() string {
+	return fmt.Sprintf("%s, %s - %s, %s, %s", a.Street, a.City, a.Region, a.PostalCode, a.Country)
+}
+
+// Returns a pseudo random first name of the registered locale with the given code
+func RandomFirstName(localeCode string, gender Gender) (string, error) {
+	locale, err := GetLocale(localeCode)
+	if err != nil {
+		return "", err
+	}
+	return locale.FirstName(gender), nil
+}
+
+// Returns a pseudo random last name of the registered locale with the given code
+func RandomLastName(localeCode string) (string, error) {
+	locale, err := GetLocale(localeCode)
+	if err != nil {
+		return "", err
+	}
+	return locale.LastName(), nil
+}
+
+// Returns a pseudo random full name of the registered locale with the given code
+func RandomFullName(localeCode string, gender Gender) (string, error) {
+	locale, err := GetLocale(localeCode)
+	if err != nil {
+		return "", err
+	}
+	return locale.FullName(gender), nil
+}
+
+// Returns a pseudo random postal address of the registered locale with the given code
+func RandomLocaleAddress(localeCode string) (LocaleAddress, error) {
+	locale, err := GetLocale(localeCode)
+	if err != nil {
+		return LocaleAddress{}, err
+	}
+	return locale.Address(), nil
+}
+
+// Returns a pseudo random phone number of the registered locale with the given code
+func RandomLocalePhone(localeCode string) (string, error) {
+	locale, err := GetLocale(localeCode)
+	if err != nil {
+		return "", err
+	}
+	return locale.Phone(), nil
+}
+
+// Returns a pseudo random company name of the registered locale with the given code
+func RandomCompanyName(localeCode string) (string, error) {
+	locale, err := GetLocale(localeCode)
+	if err != nil {
+		return "", err
+	}
+	return locale.CompanyName(), nil
+}
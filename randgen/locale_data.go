@@ -0,0 +1,138 @@
+package randgen
+
+// Embedded datasets of the locales registered by default: es_CO, es_MX, en_US and pt_BR
+
+var localeEsCO = Locale{
+	Code:    "es_CO",
+	Country: "CO",
+	MaleFirstNames: []string{
+		"Juan", "Carlos", "Andrés", "Luis", "Jorge", "José", "Santiago", "Sebastián", "Alejandro", "Daniel",
+		"David", "Felipe", "Camilo", "Diego", "Julián", "Mateo", "Nicolás", "Óscar", "Rafael", "Jaime",
+		"Hernán", "Fabio", "Germán", "Álvaro", "Mauricio",
+	},
+	FemaleFirstNames: []string{
+		"María", "Luisa", "Ana", "Valentina", "Daniela", "Camila", "Laura", "Paula", "Natalia", "Carolina",
+		"Andrea", "Juliana", "Sofía", "Mariana", "Catalina", "Diana", "Sandra", "Gloria", "Claudia", "Lucía",
+		"Angélica", "Marcela", "Isabella", "Manuela", "Paola",
+	},
+	LastNames: []string{
+		"Rodríguez", "Gómez", "González", "Martínez", "García", "López", "Hernández", "Sánchez", "Ramírez",
+		"Pérez", "Díaz", "Muñoz", "Rojas", "Moreno", "Jiménez", "Vargas", "Castro", "Gutiérrez", "Ospina",
+		"Restrepo", "Ríos", "Cárdenas", "Mejía", "Quintero", "Zapata", "Cardona", "Suárez", "Valencia",
+	},
+	DoubleSurnames:   true,
+	StreetTypes:      []string{"Calle", "Carrera", "Avenida", "Diagonal", "Transversal"},
+	StreetFormats:    []string{"{streetType} {number}{letter} # {number}{letter} - {house}"},
+	MaxHouseNumber:   99,
+	PostalCodeFormat: "######",
+	Cities: []LocaleCity{
+		{"Bogotá, D.C.", "Bogotá, D.C.", "11"}, {"Medellín", "Antioquia", "05"}, {"Cali", "Valle del Cauca", "76"},
+		{"Barranquilla", "Atlántico", "08"}, {"Cartagena de Indias", "Bolívar", "13"},
+		{"Bucaramanga", "Santander", "68"}, {"Pereira", "Risaralda", "66"}, {"Manizales", "Caldas", "17"},
+		{"Santa Marta", "Magdalena", "47"}, {"Ibagué", "Tolima", "73"},
+	},
+	PhoneFormats:    []string{"+57 3## ### ####", "+57 60# ### ####"},
+	CompanySuffixes: []string{"S.A.S.", "S.A.", "Ltda.", "& Cía.", "S.C.A."},
+	EmailDomains:    []string{"gmail.com", "hotmail.com", "outlook.com", "yahoo.com", "une.net.co"},
+}
+
+var localeEsMX = Locale{
+	Code:    "es_MX",
+	Country: "MX",
+	MaleFirstNames: []string{
+		"José", "Juan", "Luis", "Miguel", "Carlos", "Jesús", "Alejandro", "Francisco", "Jorge", "Fernando",
+		"Ricardo", "Eduardo", "Roberto", "Arturo", "Emiliano", "Diego", "Santiago", "Leonardo", "Iker", "Rodrigo",
+	},
+	FemaleFirstNames: []string{
+		"María", "Guadalupe", "Juana", "Margarita", "Verónica", "Leticia", "Rosa", "Alejandra", "Adriana",
+		"Gabriela", "Ximena", "Regina", "Fernanda", "Valeria", "Renata", "Itzel", "Mónica", "Patricia",
+	},
+	LastNames: []string{
+		"Hernández", "García", "Martínez", "López", "González", "Pérez", "Rodríguez", "Sánchez", "Ramírez",
+		"Cruz", "Flores", "Gómez", "Morales", "Vázquez", "Reyes", "Jiménez", "Torres", "Díaz", "Gutiérrez",
+		"Ruiz", "Mendoza", "Aguilar", "Ortiz", "Moreno", "Castillo", "Juárez",
+	},
+	DoubleSurnames: true,
+	StreetTypes:    []string{"Calle", "Avenida", "Calzada", "Privada", "Boulevard"},
+	StreetNames: []string{
+		"Insurgentes", "Reforma", "Juárez", "Hidalgo", "Morelos", "Madero", "5 de Mayo", "Revolución",
+		"Benito Juárez", "Miguel Hidalgo", "Constitución", "Independencia", "Zaragoza", "Allende",
+	},
+	StreetFormats:    []string{"{streetType} {streetName} {house}", "{streetType} {streetName} {house} Int. {number}"},
+	PostalCodeFormat: "#####",
+	Cities: []LocaleCity{
+		{"Ciudad de México", "CDMX", "0"}, {"Guadalajara", "Jalisco", "44"}, {"Monterrey", "Nuevo León", "64"},
+		{"Puebla", "Puebla", "72"}, {"Tijuana", "Baja California", "22"}, {"León", "Guanajuato", "37"},
+		{"Mérida", "Yucatán", "97"}, {"Querétaro", "Querétaro", "76"}, {"Cancún", "Quintana Roo", "77"},
+	},
+	PhoneFormats:    []string{"+52 55 #### ####", "+52 33 #### ####", "+52 81 #### ####", "+52 ### ### ####"},
+	CompanySuffixes: []string{"S.A. de C.V.", "S. de R.L. de C.V.", "S.A.P.I. de C.V.", "S.C."},
+	EmailDomains:    []string{"gmail.com", "hotmail.com", "outlook.com", "yahoo.com.mx", "prodigy.net.mx"},
+}
+
+var localeEnUS = Locale{
+	Code:    "en_US",
+	Country: "US",
+	MaleFirstNames: []string{
+		"James", "John", "Robert", "Michael", "William", "David", "Richard", "Joseph", "Thomas", "Charles",
+		"Christopher", "Daniel", "Matthew", "Anthony", "Mark", "Steven", "Andrew", "Joshua", "Kevin", "Brian",
+	},
+	FemaleFirstNames: []string{
+		"Mary", "Patricia", "Jennifer", "Linda", "Elizabeth", "Barbara", "Susan", "Jessica", "Sarah", "Karen",
+		"Nancy", "Lisa", "Betty", "Margaret", "Sandra", "Ashley", "Emily", "Olivia", "Emma", "Ava",
+	},
+	LastNames: []string{
+		"Smith", "Johnson", "Williams", "Brown", "Jones", "Garcia", "Miller", "Davis", "Rodriguez", "Martinez",
+		"Wilson", "Anderson", "Taylor", "Thomas", "Moore", "Jackson", "Martin", "Lee", "Thompson", "White",
+		"Harris", "Clark", "Lewis", "Walker", "Young", "O'Brien",
+	},
+	StreetTypes: []string{"St", "Ave", "Blvd", "Rd", "Ln", "Dr", "Ct", "Way"},
+	StreetNames: []string{
+		"Main", "Oak", "Pine", "Maple", "Cedar", "Elm", "Washington", "Lake", "Hill", "Park", "Sunset",
+		"Lincoln", "Jefferson", "Highland", "Madison", "Church", "River", "Spring",
+	},
+	StreetFormats:    []string{"{house} {streetName} {streetType}", "{house} {streetName} {streetType} Apt {number}"},
+	PostalCodeFormat: "#####",
+	Cities: []LocaleCity{
+		{"New York", "NY", "10"}, {"Los Angeles", "CA", "90"}, {"Chicago", "IL", "606"}, {"Houston", "TX", "770"},
+		{"Phoenix", "AZ", "850"}, {"Philadelphia", "PA", "191"}, {"San Antonio", "TX", "782"},
+		{"San Diego", "CA", "921"}, {"Dallas", "TX", "752"}, {"Miami", "FL", "331"}, {"Seattle", "WA", "981"},
+	},
+	PhoneFormats:    []string{"+1 ###-###-####"},
+	CompanySuffixes: []string{"Inc.", "LLC", "Corp.", "Co.", "Group", "& Sons"},
+	EmailDomains:    []string{"gmail.com", "yahoo.com", "outlook.com", "icloud.com", "aol.com"},
+}
+
+var localePtBR = Locale{
+	Code:    "pt_BR",
+	Country: "BR",
+	MaleFirstNames: []string{
+		"João", "José", "Antônio", "Francisco", "Carlos", "Paulo", "Pedro", "Lucas", "Luiz", "Marcos",
+		"Gabriel", "Rafael", "Daniel", "Marcelo", "Bruno", "Eduardo", "Felipe", "Gustavo", "Miguel", "Arthur",
+	},
+	FemaleFirstNames: []string{
+		"Maria", "Ana", "Francisca", "Antônia", "Adriana", "Juliana", "Márcia", "Fernanda", "Patrícia",
+		"Aline", "Camila", "Beatriz", "Larissa", "Letícia", "Júlia", "Alice", "Helena", "Laura", "Valentina",
+	},
+	LastNames: []string{
+		"Silva", "Santos", "Oliveira", "Souza", "Rodrigues", "Ferreira", "Alves", "Pereira", "Lima", "Gomes",
+		"Costa", "Ribeiro", "Martins", "Carvalho", "Almeida", "Lopes", "Soares", "Fernandes", "Vieira",
+		"Barbosa", "Rocha", "Dias", "Nascimento", "Andrade",
+	},
+	DoubleSurnames: true,
+	StreetTypes:    []string{"Rua", "Avenida", "Travessa", "Alameda", "Praça"},
+	StreetNames: []string{
+		"das Flores", "São João", "Sete de Setembro", "XV de Novembro", "Paulista", "Brasil", "Getúlio Vargas",
+		"Tiradentes", "Dom Pedro II", "Santos Dumont", "da Paz", "Rio Branco", "Amazonas",
+	},
+	StreetFormats:    []string{"{streetType} {streetName}, {house}", "{streetType} {streetName}, {house}, Apto {number}"},
+	PostalCodeFormat: "#####-###",
+	Cities: []LocaleCity{
+		{"São Paulo", "SP", "0"}, {"Rio de Janeiro", "RJ", "2"}, {"Belo Horizonte", "MG", "30"},
+		{"Brasília", "DF", "70"}, {"Salvador", "BA", "40"}, {"Fortaleza", "CE", "60"}, {"Curitiba", "PR", "80"},
+		{"Recife", "PE", "50"}, {"Porto Alegre", "RS", "90"}, {"Manaus", "AM", "69"},
+	},
+	PhoneFormats:    []string{"+55 11 9####-####", "+55 21 9####-####", "+55 ## ####-####"},
+	CompanySuffixes: []string{"Ltda.", "S.A.", "ME", "EIRELI"},
+	EmailDomains:    []string{"gmail.com", "hotmail.com", "outlook.com", "yahoo.com.br", "uol.com.br", "bol.com.br"},
+}
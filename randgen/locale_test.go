@@ -0,0 +1,146 @@
+package randgen
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuiltinLocales(t *testing.T) {
+	assert.Equal(t, []string{"en_US", "es_CO", "es_MX", "pt_BR"}, LocaleCodes())
+	for _, code := range LocaleCodes() {
+		locale, err := GetLocale(code)
+		assert.Nil(t, err)
+		for test := 0; test < 100; test++ {
+			assert.Contains(t, locale.FemaleFirstNames, locale.FirstName(GenderFemale))
+			assert.Contains(t, locale.MaleFirstNames, locale.FirstName(GenderMale))
+			lastNames := strings.Split(locale.LastName(), " ")
+			if locale.DoubleSurnames {
+				assert.Len(t, lastNames, 2)
+				assert.NotEqual(t, lastNames[0], lastNames[1])
+			} else {
+				assert.Len(t, lastNames, 1)
+			}
+			address := locale.Address()
+			assert.NotContains(t, address.Street, "{")
+			if code == "es_CO" {
+				assert.Regexp(t, `^\S.* \d+[A-Z]? # \d+[A-Z]? - \d+$`, address.Street)
+				house, _ := strconv.Atoi(address.Street[strings.LastIndex(address.Street, " ")+1:])
+				assert.True(t, house >= 1 && house <= 99, address.Street)
+			} else {
+				assert.NotContains(t, address.Street, "#")
+			}
+			assert.Len(t, address.PostalCode, len(locale.PostalCodeFormat))
+			assert.Equal(t, locale.Country, address.Country)
+			assert.NotContains(t, locale.Phone(), "#")
+			assert.NotEmpty(t, locale.CompanyName())
+		}
+	}
+}
+
+func TestLocalePostalCode(t *testing.T) {
+	locale, _ := GetLocale("pt_BR")
+	for test := 0; test < 100; test++ {
+		code := locale.PostalCode(LocaleCity{"Recife", "PE", "50"})
+		assert.Regexp(t, `^50\d{3}-\d{3}$`, code)
+	}
+}
+
+func TestLocaleSeededNames(t *testing.T) {
+	locale, _ := GetLocale("es_CO")
+	first, second := NewGenerator(7), NewGenerator(7)
+	for test := 0; test < 20; test++ {
+		assert.Equal(t, locale.firstName(first, GenderAny), locale.firstName(second, GenderAny))
+		name := locale.lastName(first)
+		assert.Equal(t, name, locale.lastName(second))
+		assert.Len(t, strings.Split(name, " "), 2)
+	}
+}
+
+func TestLocaleDuplicateLastNames(t *testing.T) {
+	locale, _ := GetLocale("es_CO")
+	locale.LastNames = []string{"Rojas", "Rojas"}
+	assert.Equal(t, "Rojas", locale.LastName())
+	locale.LastNames = []string{"Rojas", "Rojas", "Ospina"}
+	for test := 0; test < 50; test++ {
+		assert.Contains(t, []string{"Rojas Ospina", "Ospina Rojas"}, locale.LastName())
+	}
+}
+
+func TestLocaleRegistryConcurrency(t *testing.T) {
+	locale, _ := GetLocale("es_CO")
+	locale.Code = "es_XX"
+	defer func() {
+		localesMutex.Lock()
+		delete(locales, "es_XX")
+		localesMutex.Unlock()
+	}()
+	done := make(chan bool)
+	go func() {
+		for i := 0; i < 1000; i++ {
+			assert.Nil(t, RegisterLocale(locale))
+		}
+		done <- true
+	}()
+	for i := 0; i < 1000; i++ {
+		GetLocale("es_CO")
+		LocaleCodes()
+	}
+	<-done
+}
+
+func TestRandomLocaleFunctions(t *testing.T) {
+	name, err := RandomFullName("es_CO", GenderAny)
+	assert.Nil(t, err)
+	assert.Len(t, strings.Split(name, " "), 3)
+	_, err = RandomFirstName("xx_XX", GenderAny)
+	assert.NotNil(t, err)
+	_, err = RandomLastName("xx_XX")
+	assert.NotNil(t, err)
+	_, err = RandomLocaleAddress("xx_XX")
+	assert.NotNil(t, err)
+	_, err = RandomLocalePhone("xx_XX")
+	assert.NotNil(t, err)
+	_, err = RandomCompanyName("xx_XX")
+	assert.NotNil(t, err)
+	phone, err := RandomLocalePhone("es_CO")
+	assert.Nil(t, err)
+	assert.Regexp(t, `^\+57 `, phone)
+}
+
+func TestRegisterLocaleFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "locale")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	custom := localeEnUS
+	custom.Code = "en_GB"
+	custom.Country = "GB"
+	custom.PostalCodeFormat = "##"
+	data, _ := json.Marshal(custom)
+	path := filepath.Join(dir, "en_GB.json")
+	assert.Nil(t, ioutil.WriteFile(path, data, 0644))
+	assert.Nil(t, RegisterLocaleFile(path))
+	defer func() {
+		localesMutex.Lock()
+		delete(locales, "en_GB")
+		localesMutex.Unlock()
+	}()
+
+	locale, err := GetLocale("en_GB")
+	assert.Nil(t, err)
+	assert.Equal(t, "GB", locale.Address().Country)
+
+	invalid := filepath.Join(dir, "invalid.json")
+	assert.Nil(t, ioutil.WriteFile(invalid, []byte(`{"code": "xx_XX"}`), 0644))
+	assert.NotNil(t, RegisterLocaleFile(invalid))
+	assert.NotNil(t, RegisterLocaleFile(filepath.Join(dir, "missing.json")))
+	assert.Nil(t, ioutil.WriteFile(invalid, []byte(`{`), 0644))
+	assert.NotNil(t, RegisterLocaleFile(invalid))
+}
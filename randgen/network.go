@@ -0,0 +1,348 @@
+package randgen
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+)
+
+// Kind of IP address returned by RandomIPv4Of and RandomIPv6Of
+type IPKind int
+
+const (
+	IPAny IPKind = iota
+	IPPrivate
+	IPPublic
+	IPReserved
+)
+
+// Well-known ports (0-1023) are never returned by RandomPort
+const (
+	firstRegisteredPort = 1024
+	firstEphemeralPort  = 49152
+	lastPort            = 65535
+)
+
+// Private ranges of RFC 1918 and RFC 4193 (unique local addresses)
+var privateIPv4Ranges = parseCIDRs("10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16")
+var privateIPv6Ranges = parseCIDRs("fc00::/7")
+
+// Special purpose ranges of RFC 6890: this network, loopback, shared address space, link local,
+// documentation, benchmarking, multicast and reserved
+var reservedIPv4Ranges = parseCIDRs("0.0.0.0/8", "127.0.0.0/8", "100.64.0.0/10", "169.254.0.0/16",
+	"192.0.0.0/24", "192.0.2.0/24", "198.18.0.0/15", "198.51.100.0/24", "203.0.113.0/24", "224.0.0.0/4",
+	"240.0.0.0/4")
+var reservedIPv6Ranges = parseCIDRs("::1/128", "fe80::/10", "2001:db8::/32", "ff00::/8", "64:ff9b::/96", "100::/64")
+
+// Global unicast IPv6 addresses are allocated from 2000::/3
+var globalIPv6Range = parseCIDRs("2000::/3")
+
+// Organizationally unique identifiers of some well known vendors
+var KnownOUIs = map[string]string{
+	"Apple":        "F0:18:98",
+	"Cisco":        "00:1B:54",
+	"Dell":         "F8:BC:12",
+	"Intel":        "3C:97:0E",
+	"Raspberry Pi": "B8:27:EB",
+	"VMware":       "00:50:56",
+	"Samsung":      "5C:0A:5B",
+	"Huawei":       "00:E0:FC",
+	"TP-Link":      "50:C7:BF",
+	"Xerox":        "00:00:AA",
+}
+
+// Top level domains used by RandomDomainName
+var topLevelDomains = []string{"com", "org", "net", "io", "co", "dev", "app", "info", "com.co", "edu.co", "gov.co", "es", "mx", "com.br"}
+
+// Words used to build host names and URL paths
+var networkWords = []string{
+	"api", "app", "web", "cdn", "mail", "auth", "shop", "blog", "data", "files", "static", "admin", "docs",
+	"portal", "store", "news", "media", "search", "users", "orders", "products", "v1", "v2", "images",
+}
+
+// Templates of user agents, {v} is replaced by a major version and {b} by a build number
+var userAgentTemplates = []string{
+	"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/{v}.0.{b}.{p} Safari/537.36",
+	"Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/{v}.0.{b}.{p} Safari/537.36",
+	"Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/{v}.0.{b}.{p} Safari/537.36",
+	"Mozilla/5.0 (Linux; Android 13; SM-S918B) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/{v}.0.{b}.{p} Mobile Safari/537.36",
+	"Mozilla/5.0 (Windows NT 10.0; Win64; x64; rv:{v}.0) Gecko/20100101 Firefox/{v}.0",
+	"Mozilla/5.0 (X11; Ubuntu; Linux x86_64; rv:{v}.0) Gecko/20100101 Firefox/{v}.0",
+	"Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/{s}.1 Safari/605.1.15",
+	"Mozilla/5.0 (iPhone; CPU iPhone OS {s}_1 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/{s}.1 Mobile/15E148 Safari/604.1",
+	"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/{v}.0.{b}.{p} Safari/537.36 Edg/{v}.0.{b}.{p}",
+	"curl/7.{c}.0",
+	"Go-http-client/1.1",
+}
+
+// Parses the given CIDRs, panic if one of them is invalid
+func parseCIDRs(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks = append(networks, network)
+	}
+	return networks
+}
+
+// Returns true if one of the networks contains the IP
+func containsIP(networks []*net.IPNet, ip net.IP) bool {
+	for _, network := range networks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns a pseudo random address of the network, every address has the same probability
+func randomIPInNetwork(network *net.IPNet) net.IP {
+	ip := make(net.IP, len(network.IP))
+	for i := range ip {
+		ip[i] = network.IP[i] | (byte(RandomInt(0, 255)) &^ network.Mask[i])
+	}
+	return ip
+}
+
+// Returns a pseudo random address of one of the networks, each network has the same probability
+func randomIPInNetworks(networks []*net.IPNet) net.IP {
+	return randomIPInNetwork(networks[RandomInt(0, len(networks)-1)])
+}
+
+// Returns a pseudo random address inside the given CIDR, like "10.1.0.0/16" or "2001:db8::/48"
+func RandomIPIn(cidr string) (net.IP, error) {
+	_, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil, err
+	}
+	return randomIPInNetwork(network), nil
+}
+
+// Returns a pseudo random IPv4 address, any of the 2^32
+func RandomIPv4() net.IP {
+	ip, _ := RandomIPIn("0.0.0.0/0")
+	return ip
+}
+
+// Returns a pseudo random IPv4 address of the given kind: private (RFC 1918), public or reserved
+// (loopback, link local, documentation, multicast...)
+func RandomIPv4Of(kind IPKind) (net.IP, error) {
+	switch kind {
+	case IPAny:
+		return RandomIPv4(), nil
+	case IPPrivate:
+		return randomIPInNetworks(privateIPv4Ranges), nil
+	case IPReserved:
+		return randomIPInNetworks(reservedIPv4Ranges), nil
+	case IPPublic:
+		for {
+			ip := RandomIPv4()
+			if !containsIP(privateIPv4Ranges, ip) && !containsIP(reservedIPv4Ranges, ip) {
+				return ip, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("error, invalid arguments in RandomIPv4Of(kind = %d)", kind)
+}
+
+// Returns a pseudo random IPv6 address, any of the 2^128
+func RandomIPv6() net.IP {
+	ip, _ := RandomIPIn("::/0")
+	return ip
+}
+
+// Returns a pseudo random IPv6 address of the given kind: private (unique local fc00::/7), public (global
+// unicast outside the documentation range) or reserved (loopback, link local, documentation, multicast...)
+func RandomIPv6Of(kind IPKind) (net.IP, error) {
+	switch kind {
+	case IPAny:
+		return RandomIPv6(), nil
+	case IPPrivate:
+		return randomIPInNetworks(privateIPv6Ranges), nil
+	case IPReserved:
+		return randomIPInNetworks(reservedIPv6Ranges), nil
+	case IPPublic:
+		for {
+			ip := randomIPInNetworks(globalIPv6Range)
+			if !containsIP(reservedIPv6Ranges, ip) {
+				return ip, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("error, invalid arguments in RandomIPv6Of(kind = %d)", kind)
+}
+
+// Returns a pseudo random subnet of the given prefix length inside the parent CIDR,
+// like 10.20.30.0/24 inside 10.0.0.0/8
+func RandomSubnet(parent string, prefixLength int) (*net.IPNet, error) {
+	_, network, err := net.ParseCIDR(parent)
+	if err != nil {
+		return nil, err
+	}
+	ones, bits := network.Mask.Size()
+	if prefixLength < ones || prefixLength > bits {
+		return nil, fmt.Errorf("error, invalid arguments in RandomSubnet(parent = %s, prefixLength = %d)", parent, prefixLength)
+	}
+	mask := net.CIDRMask(prefixLength, bits)
+	return &net.IPNet{IP: randomIPInNetwork(network).Mask(mask), Mask: mask}, nil
+}
+
+// Returns a map with 'size' different addresses of the given CIDR as its keys, in their string form
+func RandomIPSet(size int, cidr string) (map[string]bool, error) {
+	_, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil, err
+	}
+	ones, bits := network.Mask.Size()
+	if size < 1 || (bits-ones < 62 && int64(size) > int64(1)<<uint(bits-ones)) {
+		return nil, fmt.Errorf("error, invalid arguments in RandomIPSet(size = %d, cidr = %s)", size, cidr)
+	}
+	set := make(map[string]bool)
+	for len(set) < size {
+		set[randomIPInNetwork(network).String()] = true
+	}
+	return set, nil
+}
+
+// Returns a pseudo random MAC address, it is a locally administered unicast address, so it never
+// collides with the address of a real device
+func RandomMAC() net.HardwareAddr {
+	mac := make(net.HardwareAddr, 6)
+	for i := range mac {
+		mac[i] = byte(RandomInt(0, 255))
+	}
+	mac[0] = mac[0]&^0x01 | 0x02
+	return mac
+}
+
+// Returns a pseudo random MAC address with the given OUI (the first three bytes), like "00:50:56"
+func RandomMACWithOUI(oui string) (net.HardwareAddr, error) {
+	mac, err := net.ParseMAC(oui + ":00:00:00")
+	if err != nil || len(mac) != 6 {
+		return nil, fmt.Errorf("error, invalid arguments in RandomMACWithOUI(oui = %s)", oui)
+	}
+	for i := 3; i < 6; i++ {
+		mac[i] = byte(RandomInt(0, 255))
+	}
+	return mac, nil
+}
+
+// Returns a pseudo random MAC address of one of the vendors of KnownOUIs, like "VMware"
+func RandomMACOfVendor(vendor string) (net.HardwareAddr, error) {
+	oui, ok := KnownOUIs[vendor]
+	if !ok {
+		return nil, fmt.Errorf("error, invalid arguments in RandomMACOfVendor(vendor = %s)", vendor)
+	}
+	return RandomMACWithOUI(oui)
+}
+
+// Returns a map with 'size' different locally administered MAC addresses as its keys
+func RandomMACSet(size int) (map[string]bool, error) {
+	if size < 1 {
+		return nil, fmt.Errorf("error, invalid arguments in RandomMACSet(size = %d)", size)
+	}
+	set := make(map[string]bool)
+	for len(set) < size {
+		set[RandomMAC().String()] = true
+	}
+	return set, nil
+}
+
+// Returns a pseudo random port in the interval [1024,65535], well-known ports are never returned
+func RandomPort() int {
+	return RandomInt(firstRegisteredPort, lastPort)
+}
+
+// Returns a pseudo random port of the dynamic/ephemeral range [49152,65535]
+func RandomEphemeralPort() int {
+	return RandomInt(firstEphemeralPort, lastPort)
+}
+
+// Returns a map with 'size' different ports as its keys, none of them well-known
+func RandomPortSet(size int) (map[int]bool, error) {
+	err, set := RandomIntSet(size, firstRegisteredPort, lastPort)
+	return set, err
+}
+
+// Returns a pseudo random label of a domain name, lower case letters, digits and inner hyphens
+func randomDomainLabel() string {
+	if RandomInt(0, 2) == 0 {
+		word, _ := ChooseString(networkWords)
+		return word
+	}
+	label := RandomStringExactLength(1, lowerAll) + RandomString(1, 10, lowerAll+digitsAll)
+	if RandomInt(0, 4) == 0 {
+		label += "-" + RandomString(1, 6, lowerAll+digitsAll)
+	}
+	return label
+}
+
+// Returns a pseudo random registrable domain name, like "shop-42.com.co"
+func RandomDomainName() string {
+	tld, _ := ChooseString(topLevelDomains)
+	return randomDomainLabel() + "." + tld
+}
+
+// Returns a pseudo random fully qualified host name, like "api.eu-3.example.org"
+func RandomHostname() string {
+	labels := make([]string, 0, 3)
+	for i := RandomInt(1, 2); i > 0; i-- {
+		labels = append(labels, randomDomainLabel())
+	}
+	return strings.Join(labels, ".") + "." + RandomDomainName()
+}
+
+// Returns a map with 'size' different host names as its keys
+func RandomHostnameSet(size int) (map[string]bool, error) {
+	if size < 1 {
+		return nil, fmt.Errorf("error, invalid arguments in RandomHostnameSet(size = %d)", size)
+	}
+	set := make(map[string]bool)
+	for len(set) < size {
+		set[RandomHostname()] = true
+	}
+	return set, nil
+}
+
+// Returns a pseudo random URL with a random host, path and query string,
+// like https://api.shop.co/v1/orders/42?page=3&q=abc
+func RandomURL() string {
+	scheme, _ := ChooseString([]string{"https", "https", "https", "http"})
+	address := url.URL{Scheme: scheme, Host: RandomHostname()}
+	if RandomInt(0, 4) == 0 {
+		address.Host += fmt.Sprintf(":%d", RandomPort())
+	}
+	segments := make([]string, 0, 4)
+	for i := RandomInt(0, 4); i > 0; i-- {
+		if RandomInt(0, 3) == 0 {
+			segments = append(segments, fmt.Sprintf("%d", RandomInt(1, 99999)))
+		} else {
+			word, _ := ChooseString(networkWords)
+			segments = append(segments, word)
+		}
+	}
+	address.Path = "/" + strings.Join(segments, "/")
+	query := url.Values{}
+	for i := RandomInt(0, 3); i > 0; i-- {
+		key, _ := ChooseString([]string{"q", "page", "limit", "sort", "id", "lang", "ref", "utm_source"})
+		query.Add(key, RandomRuneString(1, 8, alphaDigits+" áñ&=/"))
+	}
+	address.RawQuery = query.Encode()
+	return address.String()
+}
+
+// Returns a realistic HTTP user agent of a common browser or client with random versions
+func RandomUserAgent() string {
+	template, _ := ChooseString(userAgentTemplates)
+	return strings.NewReplacer(
+		"{v}", fmt.Sprintf("%d", RandomInt(100, 125)),
+		"{b}", fmt.Sprintf("%d", RandomInt(4000, 6500)),
+		"{p}", fmt.Sprintf("%d", RandomInt(0, 200)),
+		"{s}", fmt.Sprintf("%d", RandomInt(15, 17)),
+		"{c}", fmt.Sprintf("%d", RandomInt(60, 88)),
+	).Replace(template)
+}
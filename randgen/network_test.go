@@ -0,0 +1,132 @@
+package randgen
+
+import (
+	"net"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRandomIPv4Of(t *testing.T) {
+	for test := 0; test < 1000; test++ {
+		private, err := RandomIPv4Of(IPPrivate)
+		assert.Nil(t, err)
+		assert.True(t, containsIP(privateIPv4Ranges, private), private.String())
+		reserved, err := RandomIPv4Of(IPReserved)
+		assert.Nil(t, err)
+		assert.True(t, containsIP(reservedIPv4Ranges, reserved), reserved.String())
+		public, err := RandomIPv4Of(IPPublic)
+		assert.Nil(t, err)
+		assert.False(t, containsIP(privateIPv4Ranges, public) || containsIP(reservedIPv4Ranges, public), public.String())
+		assert.NotNil(t, RandomIPv4().To4())
+	}
+	_, err := RandomIPv4Of(IPKind(9))
+	assert.NotNil(t, err)
+}
+
+func TestRandomIPv6Of(t *testing.T) {
+	for test := 0; test < 1000; test++ {
+		private, err := RandomIPv6Of(IPPrivate)
+		assert.Nil(t, err)
+		assert.True(t, containsIP(privateIPv6Ranges, private), private.String())
+		reserved, err := RandomIPv6Of(IPReserved)
+		assert.Nil(t, err)
+		assert.True(t, containsIP(reservedIPv6Ranges, reserved), reserved.String())
+		public, err := RandomIPv6Of(IPPublic)
+		assert.Nil(t, err)
+		assert.True(t, public.IsGlobalUnicast())
+		assert.False(t, containsIP(reservedIPv6Ranges, public), public.String())
+		assert.Len(t, RandomIPv6(), net.IPv6len)
+	}
+	_, err := RandomIPv6Of(IPKind(9))
+	assert.NotNil(t, err)
+}
+
+func TestRandomIPIn(t *testing.T) {
+	for _, cidr := range []string{"10.1.0.0/16", "192.168.1.7/32", "2001:db8::/48", "0.0.0.0/0"} {
+		_, network, _ := net.ParseCIDR(cidr)
+		for test := 0; test < 100; test++ {
+			ip, err := RandomIPIn(cidr)
+			assert.Nil(t, err)
+			assert.True(t, network.Contains(ip))
+		}
+	}
+	_, err := RandomIPIn("10.0.0.0/33")
+	assert.NotNil(t, err)
+}
+
+func TestRandomSubnet(t *testing.T) {
+	_, parent, _ := net.ParseCIDR("10.0.0.0/8")
+	for test := 0; test < 100; test++ {
+		subnet, err := RandomSubnet("10.0.0.0/8", 24)
+		assert.Nil(t, err)
+		ones, _ := subnet.Mask.Size()
+		assert.Equal(t, 24, ones)
+		assert.True(t, parent.Contains(subnet.IP))
+		assert.True(t, strings.HasSuffix(subnet.String(), ".0/24"))
+	}
+	_, err := RandomSubnet("10.0.0.0/8", 4)
+	assert.NotNil(t, err)
+}
+
+func TestRandomIPSet(t *testing.T) {
+	set, err := RandomIPSet(256, "192.168.1.0/24")
+	assert.Nil(t, err)
+	assert.Len(t, set, 256)
+	set, err = RandomIPSet(1000, "fd00::/8")
+	assert.Nil(t, err)
+	assert.Len(t, set, 1000)
+	_, err = RandomIPSet(257, "192.168.1.0/24")
+	assert.NotNil(t, err)
+	_, err = RandomIPSet(1, "nonsense")
+	assert.NotNil(t, err)
+}
+
+func TestRandomMAC(t *testing.T) {
+	for test := 0; test < 1000; test++ {
+		mac := RandomMAC()
+		assert.Len(t, mac, 6)
+		assert.Equal(t, byte(0x02), mac[0]&0x03)
+		vmware, err := RandomMACOfVendor("VMware")
+		assert.Nil(t, err)
+		assert.True(t, strings.HasPrefix(vmware.String(), "00:50:56:"))
+	}
+	_, err := RandomMACWithOUI("zz:zz")
+	assert.NotNil(t, err)
+	_, err = RandomMACOfVendor("Nobody")
+	assert.NotNil(t, err)
+	set, err := RandomMACSet(1000)
+	assert.Nil(t, err)
+	assert.Len(t, set, 1000)
+}
+
+func TestRandomPorts(t *testing.T) {
+	for test := 0; test < 1000; test++ {
+		assert.GreaterOrEqual(t, RandomPort(), 1024)
+		assert.LessOrEqual(t, RandomPort(), 65535)
+		assert.GreaterOrEqual(t, RandomEphemeralPort(), 49152)
+	}
+	set, err := RandomPortSet(100)
+	assert.Nil(t, err)
+	assert.Len(t, set, 100)
+}
+
+func TestRandomHostnamesAndURLs(t *testing.T) {
+	label := `[a-z0-9]+(-[a-z0-9]+)?`
+	for test := 0; test < 1000; test++ {
+		assert.Regexp(t, `^`+label+`(\.`+label+`){2,4}$`, RandomHostname())
+		address, err := url.Parse(RandomURL())
+		assert.Nil(t, err)
+		assert.Contains(t, []string{"http", "https"}, address.Scheme)
+		assert.NotEmpty(t, address.Hostname())
+		assert.True(t, strings.HasPrefix(address.Path, "/"))
+		userAgent := RandomUserAgent()
+		assert.Regexp(t, `^(Mozilla/5\.0|curl/|Go-http-client/)`, userAgent)
+		assert.NotContains(t, userAgent, "{")
+	}
+	set, err := RandomHostnameSet(500)
+	assert.Nil(t, err)
+	assert.Len(t, set, 500)
+}
@@ -0,0 +1,312 @@
+package randgen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"sort"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// Methods of the operations of an OpenAPI path item, in the order they are listed
+var openAPIMethods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// An operation of an OpenAPI document and the JSON schemas of its bodies
+type OpenAPIOperation struct {
+	Method      string                 // upper case, like GET
+	Path        string                 // path template, like /users/{id}
+	ID          string                 // operationId, empty if the operation has none
+	RequestBody interface{}            // schema of the JSON request body, nil if the operation has none
+	Responses   map[string]interface{} // schema of the JSON body of every response status, nil if it has no body
+}
+
+// An OpenAPI 3.0 or 3.1 document, JSON or YAML, whose operations produce random request and response
+// bodies. The schemas are read as JSON schemas, nullable and the boolean exclusiveMinimum and
+// exclusiveMaximum of OpenAPI 3.0 are translated to their JSON Schema equivalents.
+type OpenAPISpec struct {
+	Title      string
+	Version    string // version of the OpenAPI specification, like 3.0.3
+	BasePath   string // path of the first server URL, like /v1
+	Operations []OpenAPIOperation
+	Schema     *JSONSchema // the whole document, so $ref like #/components/schemas/User resolve, its Options tune the bodies
+}
+
+// Returns the decoded YAML value with the types encoding/json uses: string keys and float64 numbers
+func fromYAML(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case map[interface{}]interface{}:
+		object := make(map[string]interface{}, len(typed))
+		for key, member := range typed {
+			object[fmt.Sprint(key)] = fromYAML(member)
+		}
+		return object
+	case []interface{}:
+		array := make([]interface{}, len(typed))
+		for i, item := range typed {
+			array[i] = fromYAML(item)
+		}
+		return array
+	case int:
+		return float64(typed)
+	case int64:
+		return float64(typed)
+	case uint64:
+		return float64(typed)
+	}
+	return value
+}
+
+// Rewrites the OpenAPI 3.0 keywords that JSON Schema does not have: nullable: true adds "null" to the
+// type, and a boolean exclusiveMinimum or exclusiveMaximum turns the minimum or maximum exclusive
+func normalizeOpenAPI30(value interface{}) {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		if nullable, _ := typed["nullable"].(bool); nullable {
+			if kind, ok := typed["type"].(string); ok {
+				typed["type"] = []interface{}{kind, "null"}
+			}
+			if enum, ok := typed["enum"].([]interface{}); ok && !containsJSON(enum, nil) {
+				typed["enum"] = append(enum, nil)
+			}
+		}
+		for _, bound := range [][2]string{{"exclusiveMinimum", "minimum"}, {"exclusiveMaximum", "maximum"}} {
+			if exclusive, ok := typed[bound[0]].(bool); ok {
+				delete(typed, bound[0])
+				if limit, ok := typed[bound[1]]; ok && exclusive {
+					typed[bound[0]] = limit
+					delete(typed, bound[1])
+				}
+			}
+		}
+		for _, member := range typed {
+			normalizeOpenAPI30(member)
+		}
+	case []interface{}:
+		for _, item := range typed {
+			normalizeOpenAPI30(item)
+		}
+	}
+}
+
+// Returns the document of the OpenAPI specification, JSON or YAML
+func ParseOpenAPISpec(data []byte) (*OpenAPISpec, error) {
+	var document interface{}
+	var err error
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		err = json.Unmarshal(trimmed, &document)
+	} else {
+		var decoded interface{}
+		err = yaml.Unmarshal(data, &decoded)
+		document = fromYAML(decoded)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error, the OpenAPI document is not valid JSON or YAML: %v", err)
+	}
+	root, _ := document.(map[string]interface{})
+	version, _ := root["openapi"].(string)
+	if !strings.HasPrefix(version, "3.") {
+		return nil, fmt.Errorf("error, the document is not an OpenAPI 3 specification, its openapi field is %q", version)
+	}
+	if strings.HasPrefix(version, "3.0") {
+		normalizeOpenAPI30(root)
+	}
+	spec := &OpenAPISpec{Version: version, Schema: &JSONSchema{Options: DefaultJSONSchemaOptions, root: root}}
+	if info, ok := root["info"].(map[string]interface{}); ok {
+		spec.Title, _ = info["title"].(string)
+	}
+	if servers, ok := root["servers"].([]interface{}); ok && len(servers) > 0 {
+		if server, ok := servers[0].(map[string]interface{}); ok {
+			address, _ := server["url"].(string)
+			if parsed, err := url.Parse(address); err == nil {
+				spec.BasePath = strings.TrimSuffix(parsed.Path, "/")
+			}
+		}
+	}
+	paths, _ := root["paths"].(map[string]interface{})
+	for _, path := range sortedKeys(paths) {
+		item, err := spec.object(paths[path])
+		if err != nil {
+			return nil, err
+		}
+		for _, method := range openAPIMethods {
+			if _, ok := item[method]; !ok {
+				continue
+			}
+			operation, err := spec.operation(strings.ToUpper(method), path, item[method])
+			if err != nil {
+				return nil, err
+			}
+			spec.Operations = append(spec.Operations, operation)
+		}
+	}
+	return spec, nil
+}
+
+// Returns the OpenAPI document of the JSON or YAML file
+func LoadOpenAPISpec(path string) (*OpenAPISpec, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseOpenAPISpec(data)
+}
+
+// Returns the object, or the one its $ref points to, like a response of #/components/responses
+func (s *OpenAPISpec) object(value interface{}) (map[string]interface{}, error) {
+	for depth := 0; depth < maxJSONSchemaRefDepth; depth++ {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("error, %s is not an OpenAPI object", canonicalJSON(value))
+		}
+		ref, ok := object["$ref"].(string)
+		if !ok {
+			return object, nil
+		}
+		var err error
+		if value, err = s.Schema.resolve(ref); err != nil {
+			return nil, err
+		}
+	}
+	return nil, fmt.Errorf("error, more than %d nested $ref in the OpenAPI document", maxJSONSchemaRefDepth)
+}
+
+// Returns the schema of the JSON media type of the content of a request body or a response, nil if it has
+// no JSON content. application/json is preferred to the other JSON types like application/problem+json.
+func (s *OpenAPISpec) contentSchema(holder map[string]interface{}) interface{} {
+	content, _ := holder["content"].(map[string]interface{})
+	chosen := ""
+	for _, media := range sortedKeys(content) {
+		if media == "application/json" || (chosen == "" && strings.Contains(media, "json")) {
+			chosen = media
+		}
+	}
+	if chosen == "" {
+		return nil
+	}
+	media, _ := content[chosen].(map[string]interface{})
+	if schema, ok := media["schema"]; ok {
+		return schema
+	}
+	return true
+}
+
+// Returns the operation of the path item
+func (s *OpenAPISpec) operation(method, path string, value interface{}) (OpenAPIOperation, error) {
+	object, err := s.object(value)
+	if err != nil {
+		return OpenAPIOperation{}, err
+	}
+	operation := OpenAPIOperation{Method: method, Path: path, Responses: make(map[string]interface{})}
+	operation.ID, _ = object["operationId"].(string)
+	if body, ok := object["requestBody"]; ok {
+		request, err := s.object(body)
+		if err != nil {
+			return OpenAPIOperation{}, err
+		}
+		operation.RequestBody = s.contentSchema(request)
+	}
+	responses, _ := object["responses"].(map[string]interface{})
+	for status, value := range responses {
+		response, err := s.object(value)
+		if err != nil {
+			return OpenAPIOperation{}, err
+		}
+		operation.Responses[status] = s.contentSchema(response)
+	}
+	return operation, nil
+}
+
+// Returns the status of the successful response: the first 2XX status, or default, or the first one
+func (o OpenAPIOperation) SuccessStatus() string {
+	statuses := make([]string, 0, len(o.Responses))
+	for status := range o.Responses {
+		statuses = append(statuses, status)
+	}
+	sort.Strings(statuses)
+	for _, status := range statuses {
+		if strings.HasPrefix(status, "2") {
+			return status
+		}
+	}
+	if _, ok := o.Responses["default"]; ok || len(statuses) == 0 {
+		return "default"
+	}
+	return statuses[0]
+}
+
+// Returns a pseudo random JSON request body of the operation drawn from the generator
+func (s *OpenAPISpec) RequestBody(g *Generator, operation OpenAPIOperation) (interface{}, error) {
+	if operation.RequestBody == nil {
+		return nil, fmt.Errorf("error, the operation %s %s has no JSON request body", operation.Method, operation.Path)
+	}
+	return s.Schema.instanceOf(g, operation.RequestBody)
+}
+
+// Returns a JSON request body of the operation that violates exactly one keyword of its schema
+func (s *OpenAPISpec) InvalidRequestBody(g *Generator, operation OpenAPIOperation) (InvalidJSONInstance, error) {
+	if operation.RequestBody == nil {
+		return InvalidJSONInstance{}, fmt.Errorf("error, the operation %s %s has no JSON request body",
+			operation.Method, operation.Path)
+	}
+	return s.Schema.invalidInstanceOf(g, operation.RequestBody)
+}
+
+// Returns a pseudo random JSON body of the response of the operation with the status, nil if the response
+// has no body
+func (s *OpenAPISpec) ResponseBody(g *Generator, operation OpenAPIOperation, status string) (interface{}, error) {
+	schema, ok := operation.Responses[status]
+	if !ok {
+		return nil, fmt.Errorf("error, the operation %s %s has no response %s", operation.Method, operation.Path, status)
+	}
+	if schema == nil {
+		return nil, nil
+	}
+	return s.Schema.instanceOf(g, schema)
+}
+
+// Returns the number of segments of the path that match the template, like /users/42 and /users/{id},
+// or -1 if it does not match. Literal segments count, so /users/me is preferred to /users/{id}.
+func matchPathTemplate(template, path string) int {
+	templateSegments := strings.Split(strings.Trim(template, "/"), "/")
+	pathSegments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(templateSegments) != len(pathSegments) {
+		return -1
+	}
+	literals := 0
+	for i, segment := range templateSegments {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") && pathSegments[i] != "" {
+			continue
+		}
+		if segment != pathSegments[i] {
+			return -1
+		}
+		literals++
+	}
+	return literals
+}
+
+// Returns the operation of the method whose path template matches the request path, which may start with
+// the base path. The second result is false if no operation, of any method, matches the path.
+func (s *OpenAPISpec) FindOperation(method, path string) (*OpenAPIOperation, bool) {
+	if s.BasePath != "" && strings.HasPrefix(path, s.BasePath+"/") {
+		path = strings.TrimPrefix(path, s.BasePath)
+	}
+	var found *OpenAPIOperation
+	pathFound := false
+	best := -1
+	for i := range s.Operations {
+		literals := matchPathTemplate(s.Operations[i].Path, path)
+		if literals < 0 {
+			continue
+		}
+		pathFound = true
+		if s.Operations[i].Method == strings.ToUpper(method) && literals > best {
+			found, best = &s.Operations[i], literals
+		}
+	}
+	return found, pathFound
+}
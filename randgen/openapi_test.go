@@ -0,0 +1,205 @@
+package randgen
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// An OpenAPI 3.0 document in YAML, with nullable, a boolean exclusiveMinimum and shared components
+const testOpenAPI30 = `
+openapi: 3.0.3
+info:
+  title: Users
+  version: "1.0"
+servers:
+  - url: https://api.example.com/v1
+paths:
+  /users:
+    get:
+      operationId: listUsers
+      responses:
+        200:
+          description: the users
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/User'
+    post:
+      operationId: createUser
+      requestBody:
+        $ref: '#/components/requestBodies/NewUser'
+      responses:
+        "201":
+          description: created
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/User'
+        "400":
+          $ref: '#/components/responses/Error'
+  /users/{id}:
+    get:
+      operationId: getUser
+      responses:
+        "200":
+          description: the user
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/User'
+        "404":
+          $ref: '#/components/responses/Error'
+    delete:
+      responses:
+        "204":
+          description: deleted
+  /users/me:
+    get:
+      operationId: me
+      responses:
+        default:
+          description: the current user
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/User'
+components:
+  schemas:
+    User:
+      type: object
+      required: [id, email, phone, balance]
+      properties:
+        id: {type: integer, minimum: 0, exclusiveMinimum: true}
+        email: {type: string, format: email}
+        phone: {type: string, format: phone}
+        nickname: {type: string, nullable: true, maxLength: 8}
+        balance: {type: number, minimum: 0, maximum: 1000}
+  requestBodies:
+    NewUser:
+      content:
+        application/json:
+          schema:
+            type: object
+            required: [email]
+            additionalProperties: false
+            properties:
+              email: {type: string, format: email}
+              age: {type: integer, minimum: 18}
+  responses:
+    Error:
+      description: an error
+      content:
+        application/problem+json:
+          schema:
+            type: object
+            required: [message]
+            properties:
+              message: {type: string, minLength: 1}
+`
+
+func TestParseOpenAPISpec(t *testing.T) {
+	spec, err := ParseOpenAPISpec([]byte(testOpenAPI30))
+	assert.Nil(t, err)
+	assert.Equal(t, "Users", spec.Title)
+	assert.Equal(t, "3.0.3", spec.Version)
+	assert.Equal(t, "/v1", spec.BasePath)
+	assert.Len(t, spec.Operations, 5)
+	names := make([]string, 0)
+	for _, operation := range spec.Operations {
+		names = append(names, operation.Method+" "+operation.Path+" "+operation.ID+" "+operation.SuccessStatus())
+	}
+	assert.Equal(t, []string{"GET /users listUsers 200", "POST /users createUser 201", "GET /users/me me default",
+		"GET /users/{id} getUser 200", "DELETE /users/{id}  204"}, names)
+	user := spec.Schema.root.(map[string]interface{})["components"].(map[string]interface{})["schemas"].(map[string]interface{})["User"].(map[string]interface{})
+	properties := user["properties"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"type": "integer", "exclusiveMinimum": 0.0}, properties["id"])
+	assert.Equal(t, []interface{}{"string", "null"}, properties["nickname"].(map[string]interface{})["type"])
+	for _, text := range []string{`openapi: 2.0`, `{"swagger": "2.0"}`, `: :`,
+		`{"openapi": "3.1.0", "paths": {"/a": {"get": {"responses": {"200": {"$ref": "#/missing"}}}}}}`} {
+		_, err := ParseOpenAPISpec([]byte(text))
+		assert.NotNil(t, err, text)
+	}
+}
+
+func TestOpenAPIBodies(t *testing.T) {
+	spec, _ := ParseOpenAPISpec([]byte(testOpenAPI30))
+	g := NewGenerator(17)
+	for test := 0; test < 50; test++ {
+		for _, operation := range spec.Operations {
+			for status, schema := range operation.Responses {
+				body, err := spec.ResponseBody(g, operation, status)
+				assert.Nil(t, err)
+				if schema == nil {
+					assert.Nil(t, body)
+					continue
+				}
+				assert.Empty(t, spec.Schema.validate(schema, body, "", 0))
+			}
+			if operation.RequestBody == nil {
+				_, err := spec.RequestBody(g, operation)
+				assert.NotNil(t, err)
+				continue
+			}
+			body, err := spec.RequestBody(g, operation)
+			assert.Nil(t, err)
+			assert.Empty(t, spec.Schema.validate(operation.RequestBody, body, "", 0))
+			invalid, err := spec.InvalidRequestBody(g, operation)
+			assert.Nil(t, err)
+			assert.Len(t, spec.Schema.validate(operation.RequestBody, invalid.Instance, "", 0), 1)
+		}
+	}
+	operation, _ := spec.FindOperation("GET", "/users/7")
+	user, err := spec.ResponseBody(g, *operation, "200")
+	assert.Nil(t, err)
+	object := user.(map[string]interface{})
+	assert.True(t, object["id"].(float64) > 0)
+	assert.Regexp(t, `^\+57\d{10}$`, object["phone"])
+	assert.Contains(t, object["email"], "@")
+	first, _ := spec.ResponseBody(NewGenerator(3), *operation, "200")
+	second, _ := spec.ResponseBody(NewGenerator(3), *operation, "200")
+	assert.Equal(t, first, second)
+	_, err = spec.ResponseBody(g, *operation, "500")
+	assert.NotNil(t, err)
+}
+
+func TestFindOperation(t *testing.T) {
+	spec, _ := ParseOpenAPISpec([]byte(testOpenAPI30))
+	for path, expected := range map[string]string{"/users": "listUsers", "/v1/users": "listUsers",
+		"/users/42": "getUser", "/v1/users/me": "me", "/users/me/": "me"} {
+		operation, found := spec.FindOperation("get", path)
+		assert.True(t, found, path)
+		if assert.NotNil(t, operation, path) {
+			assert.Equal(t, expected, operation.ID)
+		}
+	}
+	operation, found := spec.FindOperation("PUT", "/users/42")
+	assert.Nil(t, operation)
+	assert.True(t, found)
+	operation, found = spec.FindOperation("GET", "/orders")
+	assert.Nil(t, operation)
+	assert.False(t, found)
+}
+
+func TestLoadOpenAPISpec(t *testing.T) {
+	directory, err := ioutil.TempDir("", "openapi")
+	assert.Nil(t, err)
+	defer os.RemoveAll(directory)
+	path := filepath.Join(directory, "openapi.json")
+	document := `{"openapi": "3.1.0", "info": {"title": "Ping"}, "paths": {"/ping": {"get": {"responses":
+		{"200": {"content": {"application/json": {"schema": {"const": "pong"}}}}}}}}}`
+	assert.Nil(t, ioutil.WriteFile(path, []byte(document), 0644))
+	spec, err := LoadOpenAPISpec(path)
+	assert.Nil(t, err)
+	assert.Equal(t, "Ping", spec.Title)
+	body, err := spec.ResponseBody(NewGenerator(1), spec.Operations[0], "200")
+	assert.Nil(t, err)
+	assert.Equal(t, "pong", body)
+	_, err = LoadOpenAPISpec(filepath.Join(directory, "missing.yaml"))
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,259 @@
+package randgen
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+)
+
+// Returns an error if the probabilities are negative or do not add up to 1
+func checkProbabilities(probabilities []float64) error {
+	if len(probabilities) < 1 {
+		return fmt.Errorf("error, there must be at least one probability")
+	}
+	sum := 0.0
+	for _, probability := range probabilities {
+		if probability < 0 {
+			return fmt.Errorf("error, negative probability %v", probability)
+		}
+		sum += probability
+	}
+	if math.Abs(sum-1) > 1e-9 {
+		return fmt.Errorf("error, the probabilities %v add up to %v instead of 1", probabilities, sum)
+	}
+	return nil
+}
+
+// Returns the sizes of the parts of n items split by the fractions, they add up to n. Each size is the
+// fraction of n rounded down, the items left go to the parts with the largest remainders plus carry,
+// the items a part is owed from previous splits (nil if there are none).
+func sizesFromFractions(n int, fractions []float64, carry []float64) []int {
+	sizes := make([]int, len(fractions))
+	priorities := make([]float64, len(fractions))
+	order := make([]int, len(fractions))
+	assigned := 0
+	for i, fraction := range fractions {
+		sizes[i] = int(math.Floor(fraction * float64(n)))
+		assigned += sizes[i]
+		priorities[i] = fraction*float64(n) - float64(sizes[i])
+		if carry != nil {
+			priorities[i] += carry[i]
+		}
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return priorities[order[a]] > priorities[order[b]] })
+	for i := 0; assigned < n; i++ {
+		sizes[order[i%len(order)]]++
+		assigned++
+	}
+	return sizes
+}
+
+// Returns the indices [0,n) split in len(probabilities) parts, each index goes to part i with
+// probability probabilities[i], independently of the others, so the sizes of the parts vary.
+// The indices of every part are sorted.
+func (g *Generator) PartitionIndices(n int, probabilities []float64) ([][]int, error) {
+	if err := checkProbabilities(probabilities); err != nil {
+		return nil, err
+	}
+	if n < 0 {
+		return nil, fmt.Errorf("error, invalid arguments in PartitionIndices(n = %d)", n)
+	}
+	parts := make([][]int, len(probabilities))
+	for index := 0; index < n; index++ {
+		draw := g.Float64(0, 1)
+		part := len(probabilities) - 1
+		for i, probability := range probabilities {
+			if draw < probability {
+				part = i
+				break
+			}
+			draw -= probability
+		}
+		parts[part] = append(parts[part], index)
+	}
+	return parts, nil
+}
+
+// Returns the indices [0,n) split in parts of exactly the given sizes, which must add up to n.
+// The indices of every part are sorted.
+func (g *Generator) PartitionIndicesBySize(n int, sizes []int) ([][]int, error) {
+	total := 0
+	for _, size := range sizes {
+		if size < 0 {
+			return nil, fmt.Errorf("error, negative part size %d", size)
+		}
+		total += size
+	}
+	if len(sizes) < 1 || total != n {
+		return nil, fmt.Errorf("error, the part sizes %v must add up to %d", sizes, n)
+	}
+	permutation := g.Perm(n)
+	parts := make([][]int, len(sizes))
+	start := 0
+	for i, size := range sizes {
+		parts[i] = append([]int(nil), permutation[start:start+size]...)
+		sort.Ints(parts[i])
+		start += size
+	}
+	return parts, nil
+}
+
+// Returns the indices of the keys split in parts with the given fractions of every stratum, the
+// indices with the same key. So every part keeps the proportions of the keys, as a train, validation
+// and test split of a dataset with unbalanced classes should. The indices of every part are sorted.
+func (g *Generator) StratifiedPartitionIndices(keys []string, fractions []float64) ([][]int, error) {
+	if err := checkProbabilities(fractions); err != nil {
+		return nil, err
+	}
+	strata := make(map[string][]int)
+	names := make([]string, 0)
+	for index, key := range keys {
+		if _, ok := strata[key]; !ok {
+			names = append(names, key)
+		}
+		strata[key] = append(strata[key], index)
+	}
+	sort.Strings(names)
+	parts := make([][]int, len(fractions))
+	// the items every part is owed, so the rounding of small strata does not always favour the same part
+	carry := make([]float64, len(fractions))
+	for _, name := range names {
+		stratum := strata[name]
+		sizes := sizesFromFractions(len(stratum), fractions, carry)
+		for i, size := range sizes {
+			carry[i] += fractions[i]*float64(len(stratum)) - float64(size)
+		}
+		split, err := g.PartitionIndicesBySize(len(stratum), sizes)
+		if err != nil {
+			return nil, err
+		}
+		for i, indices := range split {
+			for _, index := range indices {
+				parts[i] = append(parts[i], stratum[index])
+			}
+		}
+	}
+	for _, part := range parts {
+		sort.Ints(part)
+	}
+	return parts, nil
+}
+
+// Returns the items of the slice selected by every part of indices, each as a slice of the same type
+// as the given one
+func sliceParts(slice interface{}, parts [][]int) []interface{} {
+	value := reflect.ValueOf(slice)
+	result := make([]interface{}, 0, len(parts))
+	for _, indices := range parts {
+		part := reflect.MakeSlice(value.Type(), 0, len(indices))
+		for _, index := range indices {
+			part = reflect.Append(part, value.Index(index))
+		}
+		result = append(result, part.Interface())
+	}
+	return result
+}
+
+// Returns the length of the slice, or an error if it is not a slice
+func sliceLength(slice interface{}) (int, error) {
+	value := reflect.ValueOf(slice)
+	if value.Kind() != reflect.Slice {
+		return 0, fmt.Errorf("error, %T is not a slice", slice)
+	}
+	return value.Len(), nil
+}
+
+// Splits the slice, of any type, in parts as PartitionIndices does. Every returned part is a slice of
+// the same type as the given one, so it can be converted back, like parts[0].([]string).
+// The items keep their order.
+func (g *Generator) PartitionSlice(slice interface{}, probabilities []float64) ([]interface{}, error) {
+	length, err := sliceLength(slice)
+	if err != nil {
+		return nil, err
+	}
+	parts, err := g.PartitionIndices(length, probabilities)
+	if err != nil {
+		return nil, err
+	}
+	return sliceParts(slice, parts), nil
+}
+
+// Splits the slice, of any type, in parts of exactly the given sizes as PartitionIndicesBySize does.
+// Every returned part is a slice of the same type as the given one. The items keep their order.
+func (g *Generator) PartitionSliceBySize(slice interface{}, sizes []int) ([]interface{}, error) {
+	length, err := sliceLength(slice)
+	if err != nil {
+		return nil, err
+	}
+	parts, err := g.PartitionIndicesBySize(length, sizes)
+	if err != nil {
+		return nil, err
+	}
+	return sliceParts(slice, parts), nil
+}
+
+// Splits the slice, of any type, keeping the proportions of the strata given by key(i), the key of the
+// item in position i, as StratifiedPartitionIndices does. Every returned part is a slice of the same type
+// as the given one. The items keep their order.
+func (g *Generator) StratifiedPartitionSlice(slice interface{}, key func(i int) string, fractions []float64) ([]interface{}, error) {
+	length, err := sliceLength(slice)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]string, length)
+	for i := range keys {
+		keys[i] = key(i)
+	}
+	parts, err := g.StratifiedPartitionIndices(keys, fractions)
+	if err != nil {
+		return nil, err
+	}
+	return sliceParts(slice, parts), nil
+}
+
+// Returns the elements of the set sorted, so the partitions of a set do not depend on the iteration order
+// of the map
+func sortedSetElements(set map[int]bool) []int {
+	elements := make([]int, 0, len(set))
+	for element := range set {
+		elements = append(elements, element)
+	}
+	sort.Ints(elements)
+	return elements
+}
+
+// Returns the sets with the elements of every part of indices
+func setParts(elements []int, parts [][]int) []map[int]bool {
+	sets := make([]map[int]bool, 0, len(parts))
+	for _, indices := range parts {
+		set := make(map[int]bool)
+		for _, index := range indices {
+			set[elements[index]] = true
+		}
+		sets = append(sets, set)
+	}
+	return sets
+}
+
+// Returns disjoint sets whose union is the given set, each element goes to set i with probability
+// probabilities[i]
+func (g *Generator) PartitionSet(set map[int]bool, probabilities []float64) ([]map[int]bool, error) {
+	elements := sortedSetElements(set)
+	parts, err := g.PartitionIndices(len(elements), probabilities)
+	if err != nil {
+		return nil, err
+	}
+	return setParts(elements, parts), nil
+}
+
+// Returns disjoint sets of exactly the given sizes whose union is the given set
+func (g *Generator) PartitionSetBySize(set map[int]bool, sizes []int) ([]map[int]bool, error) {
+	elements := sortedSetElements(set)
+	parts, err := g.PartitionIndicesBySize(len(elements), sizes)
+	if err != nil {
+		return nil, err
+	}
+	return setParts(elements, parts), nil
+}
@@ -0,0 +1,121 @@
+package randgen
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Checks that the parts are sorted, disjoint and cover [0,n)
+func assertIndexPartition(t *testing.T, n int, parts [][]int) {
+	all := make([]int, 0, n)
+	for _, part := range parts {
+		assert.True(t, sort.IntsAreSorted(part))
+		all = append(all, part...)
+	}
+	sort.Ints(all)
+	assert.Len(t, all, n)
+	for i, index := range all {
+		assert.Equal(t, i, index)
+	}
+}
+
+func TestPartitionIndices(t *testing.T) {
+	g := NewGenerator(1)
+	parts, err := g.PartitionIndices(10000, []float64{0.7, 0.2, 0.1})
+	assert.Nil(t, err)
+	assertIndexPartition(t, 10000, parts)
+	assert.InDelta(t, 7000, len(parts[0]), 300)
+	assert.InDelta(t, 2000, len(parts[1]), 300)
+	again, _ := NewGenerator(1).PartitionIndices(10000, []float64{0.7, 0.2, 0.1})
+	assert.Equal(t, parts, again)
+	_, err = g.PartitionIndices(10, []float64{0.5, 0.6})
+	assert.NotNil(t, err)
+	_, err = g.PartitionIndices(10, []float64{-0.5, 1.5})
+	assert.NotNil(t, err)
+}
+
+func TestPartitionIndicesBySize(t *testing.T) {
+	g := NewGenerator(2)
+	parts, err := g.PartitionIndicesBySize(100, []int{60, 25, 15, 0})
+	assert.Nil(t, err)
+	assertIndexPartition(t, 100, parts)
+	assert.Len(t, parts[0], 60)
+	assert.Len(t, parts[2], 15)
+	assert.Empty(t, parts[3])
+	_, err = g.PartitionIndicesBySize(100, []int{60, 30})
+	assert.NotNil(t, err)
+}
+
+func TestStratifiedPartitionIndices(t *testing.T) {
+	keys := make([]string, 0, 1000)
+	for i := 0; i < 1000; i++ {
+		if i%10 == 0 {
+			keys = append(keys, "fraud")
+		} else {
+			keys = append(keys, "ok")
+		}
+	}
+	parts, err := NewGenerator(3).StratifiedPartitionIndices(keys, []float64{0.8, 0.1, 0.1})
+	assert.Nil(t, err)
+	assertIndexPartition(t, 1000, parts)
+	for i, expected := range []int{80, 10, 10} {
+		fraud := 0
+		for _, index := range parts[i] {
+			if keys[index] == "fraud" {
+				fraud++
+			}
+		}
+		assert.Equal(t, expected, fraud)
+		assert.Len(t, parts[i], expected*10)
+	}
+	assert.Equal(t, []int{34, 33, 33}, sizesFromFractions(100, []float64{1.0 / 3, 1.0 / 3, 1.0 / 3}, nil))
+	assert.Equal(t, []int{3, 4, 3}, sizesFromFractions(10, []float64{1.0 / 3, 1.0 / 3, 1.0 / 3}, []float64{-2.0 / 3, 1.0 / 3, 1.0 / 3}))
+}
+
+func TestPartitionSlice(t *testing.T) {
+	items := make([]string, 0, 100)
+	for i := 0; i < 100; i++ {
+		items = append(items, fmt.Sprintf("item%02d", i))
+	}
+	g := NewGenerator(4)
+	parts, err := g.PartitionSliceBySize(items, []int{80, 20})
+	assert.Nil(t, err)
+	train, test := parts[0].([]string), parts[1].([]string)
+	assert.Len(t, train, 80)
+	assert.Len(t, test, 20)
+	assert.True(t, sort.StringsAreSorted(train))
+	for _, item := range test {
+		assert.NotContains(t, train, item)
+	}
+	parts, err = g.PartitionSlice([]int{1, 2, 3, 4, 5}, []float64{0.5, 0.5})
+	assert.Nil(t, err)
+	assert.Len(t, parts[0].([]int), 5-len(parts[1].([]int)))
+	parts, err = g.StratifiedPartitionSlice(items, func(i int) string { return fmt.Sprint(i % 2) }, []float64{0.5, 0.5})
+	assert.Nil(t, err)
+	assert.Len(t, parts[0].([]string), 50)
+	_, err = g.PartitionSlice(map[int]bool{}, []float64{1})
+	assert.NotNil(t, err)
+}
+
+func TestPartitionSet(t *testing.T) {
+	_, set := RandomIntSet(500, 0, 10000)
+	sets, err := NewGenerator(5).PartitionSet(set, []float64{0.25, 0.25, 0.5})
+	assert.Nil(t, err)
+	union := make(map[int]bool)
+	for _, part := range sets {
+		for element := range part {
+			assert.False(t, union[element])
+			union[element] = true
+		}
+	}
+	assert.Equal(t, set, union)
+	again, _ := NewGenerator(5).PartitionSet(set, []float64{0.25, 0.25, 0.5})
+	assert.Equal(t, sets, again)
+	sets, err = NewGenerator(6).PartitionSetBySize(set, []int{100, 400})
+	assert.Nil(t, err)
+	assert.Len(t, sets[0], 100)
+	assert.Len(t, sets[1], 400)
+}
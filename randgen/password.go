@@ -0,0 +1,282 @@
+package randgen
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"fmt"
+	"math"
+	"math/big"
+)
+
+var digitsAll = "0123456789"
+var digitsUnambiguous = "23456789"
+var upperAll = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+var lowerAll = "abcdefghijklmnopqrstuvwxyz"
+var passwordSymbols = "!#$%&*+-=?@^_~"
+var base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// How many times GeneratePassword retries when the NoRepeatedRuns rule is violated
+const maxPasswordAttempts = 1000
+
+// PasswordPolicy describes the passwords returned by GeneratePassword.
+// Upper case letters, lower case letters and digits are always part of the pool of characters,
+// symbols are only used when AllowSymbols is set or MinSymbols is positive.
+type PasswordPolicy struct {
+	Length           int
+	MinUpper         int
+	MinLower         int
+	MinDigits        int
+	MinSymbols       int
+	AllowSymbols     bool
+	ExcludeAmbiguous bool // use the same unambiguous letters and digits as alphaDigits (no 0/O/1/l/I)
+	NoRepeatedRuns   bool // two consecutive characters are never the same
+}
+
+// A reasonable default policy: 16 characters, at least one of each class, unambiguous characters only
+var DefaultPasswordPolicy = PasswordPolicy{
+	Length:           16,
+	MinUpper:         1,
+	MinLower:         1,
+	MinDigits:        1,
+	MinSymbols:       1,
+	AllowSymbols:     true,
+	ExcludeAmbiguous: true,
+	NoRepeatedRuns:   true,
+}
+
+// Returns the upper, lower, digit and symbol alphabets used by the policy, the symbol alphabet is empty
+// when symbols are not allowed
+func (p PasswordPolicy) classes() (string, string, string, string) {
+	upper, lower, digits, symbols := upperAll, lowerAll, digitsAll, ""
+	if p.ExcludeAmbiguous {
+		upper, lower, digits = alphaUpper, alphaLower, digitsUnambiguous
+	}
+	if p.AllowSymbols || p.MinSymbols > 0 {
+		symbols = passwordSymbols
+	}
+	return upper, lower, digits, symbols
+}
+
+// Returns an error if the policy can not produce any password
+func (p PasswordPolicy) Validate() error {
+	if p.Length < 1 || p.MinUpper < 0 || p.MinLower < 0 || p.MinDigits < 0 || p.MinSymbols < 0 ||
+		p.MinUpper+p.MinLower+p.MinDigits+p.MinSymbols > p.Length {
+		return fmt.Errorf("error, invalid password policy %+v", p)
+	}
+	return nil
+}
+
+// Returns an estimate of the bits of entropy of a password generated with the policy. The estimate
+// assumes every character is drawn uniformly from the whole pool, so it is an upper bound when the
+// policy has minimum counts per class.
+func (p PasswordPolicy) EntropyBits() float64 {
+	if p.Validate() != nil {
+		return 0
+	}
+	upper, lower, digits, symbols := p.classes()
+	poolSize := float64(len(upper) + len(lower) + len(digits) + len(symbols))
+	if p.NoRepeatedRuns {
+		return math.Log2(poolSize) + float64(p.Length-1)*math.Log2(poolSize-1)
+	}
+	return float64(p.Length) * math.Log2(poolSize)
+}
+
+// Returns a cryptographically secure random integer in the interval [0,n)
+func secureIntn(n int) (int, error) {
+	if n < 1 {
+		return 0, fmt.Errorf("error, invalid arguments in secureIntn(n = %d)", n)
+	}
+	value, err := rand.Int(rand.Reader, big.NewInt(int64(n)))
+	if err != nil {
+		return 0, err
+	}
+	return int(value.Int64()), nil
+}
+
+// Returns a cryptographically secure random integer in the interval [minValue,maxValue].
+// Same contract as RandomInt, but an error is returned instead of a panic.
+func SecureRandomInt(minValue int, maxValue int) (int, error) {
+	if minValue < 0 || maxValue < 0 || maxValue < minValue {
+		return 0, fmt.Errorf("error, invalid arguments in SecureRandomInt(%d,%d) function.", minValue, maxValue)
+	}
+	value, err := secureIntn(maxValue - minValue + 1)
+	return minValue + value, err
+}
+
+// Returns a cryptographically secure random string of characters from the given alphabet, it length
+// will be exactly 'length'. Same contract as RandomStringExactLength.
+func SecureRandomStringExactLength(length int, alphabet string) (string, error) {
+	if len(alphabet) <= 0 || length < 0 {
+		return "", fmt.Errorf("error, invalid arguments in SecureRandomStringExactLength(length = %d, alphabet = %s)",
+			length, alphabet)
+	}
+	buffer := make([]byte, length)
+	for i := range buffer {
+		index, err := secureIntn(len(alphabet))
+		if err != nil {
+			return "", err
+		}
+		buffer[i] = alphabet[index]
+	}
+	return string(buffer), nil
+}
+
+// Shuffles the given bytes in place using Fisher-Yates with a cryptographically secure source
+func secureShuffle(buffer []byte) error {
+	for i := len(buffer) - 1; i > 0; i-- {
+		j, err := secureIntn(i + 1)
+		if err != nil {
+			return err
+		}
+		buffer[i], buffer[j] = buffer[j], buffer[i]
+	}
+	return nil
+}
+
+// Returns true if the given bytes have two consecutive equal characters
+func hasRepeatedRun(buffer []byte) bool {
+	for i := 1; i < len(buffer); i++ {
+		if buffer[i] == buffer[i-1] {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns a cryptographically secure password that satisfies the given policy
+func GeneratePassword(policy PasswordPolicy) (string, error) {
+	if err := policy.Validate(); err != nil {
+		return "", err
+	}
+	upper, lower, digits, symbols := policy.classes()
+	pool := upper + lower + digits + symbols
+	required := []struct {
+		count    int
+		alphabet string
+	}{
+		{policy.MinUpper, upper},
+		{policy.MinLower, lower},
+		{policy.MinDigits, digits},
+		{policy.MinSymbols, symbols},
+	}
+	for attempt := 0; attempt < maxPasswordAttempts; attempt++ {
+		buffer := make([]byte, 0, policy.Length)
+		for _, class := range required {
+			if class.count == 0 {
+				continue
+			}
+			part, err := SecureRandomStringExactLength(class.count, class.alphabet)
+			if err != nil {
+				return "", err
+			}
+			buffer = append(buffer, part...)
+		}
+		rest, err := SecureRandomStringExactLength(policy.Length-len(buffer), pool)
+		if err != nil {
+			return "", err
+		}
+		buffer = append(buffer, rest...)
+		if err := secureShuffle(buffer); err != nil {
+			return "", err
+		}
+		if !policy.NoRepeatedRuns || !hasRepeatedRun(buffer) {
+			return string(buffer), nil
+		}
+	}
+	return "", fmt.Errorf("error, could not generate a password for policy %+v", policy)
+}
+
+// Returns a map with 'size' different passwords as its keys, all of them generated with the given policy
+func GeneratePasswordSet(size int, policy PasswordPolicy) (map[string]bool, error) {
+	if size < 1 {
+		return nil, fmt.Errorf("error, invalid arguments in GeneratePasswordSet(size = %d)", size)
+	}
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+	if policy.EntropyBits() < math.Log2(float64(size))+1 {
+		return nil, fmt.Errorf("error, policy %+v can not produce %d different passwords", policy, size)
+	}
+	set := make(map[string]bool)
+	for len(set) < size {
+		password, err := GeneratePassword(policy)
+		if err != nil {
+			return nil, err
+		}
+		set[password] = true
+	}
+	return set, nil
+}
+
+// Returns 'numBytes' cryptographically secure random bytes
+func secureBytes(numBytes int) ([]byte, error) {
+	if numBytes < 1 {
+		return nil, fmt.Errorf("error, invalid arguments in secureBytes(numBytes = %d)", numBytes)
+	}
+	buffer := make([]byte, numBytes)
+	if _, err := rand.Read(buffer); err != nil {
+		return nil, err
+	}
+	return buffer, nil
+}
+
+// Returns an API key like "prefix_k3H9...", the random part has 'length' characters of alphaDigits,
+// so it is easy to read aloud or copy by hand. The prefix is optional.
+// The random part carries length*log2(len(alphaDigits)) bits of entropy, see APIKeyEntropyBits.
+func GenerateAPIKey(prefix string, length int) (string, error) {
+	if length < 1 {
+		return "", fmt.Errorf("error, invalid arguments in GenerateAPIKey(prefix = %s, length = %d)", prefix, length)
+	}
+	key, err := SecureRandomStringExactLength(length, alphaDigits)
+	if err != nil {
+		return "", err
+	}
+	if prefix == "" {
+		return key, nil
+	}
+	return prefix + "_" + key, nil
+}
+
+// Returns the bits of entropy of the random part of a key returned by GenerateAPIKey
+func APIKeyEntropyBits(length int) float64 {
+	return float64(length) * math.Log2(float64(len(alphaDigits)))
+}
+
+// Returns 'numBytes' cryptographically secure random bytes encoded in base32 (RFC 4648) without padding.
+// The token carries exactly 8*numBytes bits of entropy.
+func GenerateBase32Token(numBytes int) (string, error) {
+	buffer, err := secureBytes(numBytes)
+	if err != nil {
+		return "", err
+	}
+	return base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(buffer), nil
+}
+
+// Returns 'numBytes' cryptographically secure random bytes encoded in base58 (the bitcoin alphabet,
+// without 0/O/I/l). The token carries exactly 8*numBytes bits of entropy.
+func GenerateBase58Token(numBytes int) (string, error) {
+	buffer, err := secureBytes(numBytes)
+	if err != nil {
+		return "", err
+	}
+	return encodeBase58(buffer), nil
+}
+
+// Encodes the given bytes in base58, every leading zero byte is encoded as a leading '1'
+func encodeBase58(buffer []byte) string {
+	value := new(big.Int).SetBytes(buffer)
+	base := big.NewInt(int64(len(base58Alphabet)))
+	remainder := new(big.Int)
+	encoded := make([]byte, 0, len(buffer)*138/100+1)
+	for value.Sign() > 0 {
+		value.DivMod(value, base, remainder)
+		encoded = append(encoded, base58Alphabet[remainder.Int64()])
+	}
+	for i := 0; i < len(buffer) && buffer[i] == 0; i++ {
+		encoded = append(encoded, base58Alphabet[0])
+	}
+	for i, j := 0, len(encoded)-1; i < j; i, j = i+1, j-1 {
+		encoded[i], encoded[j] = encoded[j], encoded[i]
+	}
+	return string(encoded)
+}
@@ -0,0 +1,106 @@
+package randgen
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func countInAlphabet(str string, alphabet string) int {
+	count := 0
+	for i := 0; i < len(str); i++ {
+		if strings.IndexByte(alphabet, str[i]) >= 0 {
+			count++
+		}
+	}
+	return count
+}
+
+func TestGeneratePassword(t *testing.T) {
+	for test := 0; test < 1000; test++ {
+		policy := PasswordPolicy{
+			Length:           RandomInt(8, 40),
+			MinUpper:         RandomInt(0, 2),
+			MinLower:         RandomInt(0, 2),
+			MinDigits:        RandomInt(0, 2),
+			MinSymbols:       RandomInt(0, 2),
+			ExcludeAmbiguous: RandomInt(0, 1) == 1,
+			NoRepeatedRuns:   RandomInt(0, 1) == 1,
+		}
+		password, err := GeneratePassword(policy)
+		assert.Nil(t, err)
+		assert.Len(t, password, policy.Length)
+		upper, lower, digits, symbols := policy.classes()
+		assert.GreaterOrEqual(t, countInAlphabet(password, upper), policy.MinUpper)
+		assert.GreaterOrEqual(t, countInAlphabet(password, lower), policy.MinLower)
+		assert.GreaterOrEqual(t, countInAlphabet(password, digits), policy.MinDigits)
+		assert.GreaterOrEqual(t, countInAlphabet(password, symbols), policy.MinSymbols)
+		assert.Equal(t, len(password), countInAlphabet(password, upper+lower+digits+symbols),
+			fmt.Sprintf("%v has characters out of the policy %+v", password, policy))
+		if policy.ExcludeAmbiguous {
+			assert.Equal(t, 0, countInAlphabet(password, "0O1lI"))
+		}
+		if policy.NoRepeatedRuns {
+			assert.False(t, hasRepeatedRun([]byte(password)), password)
+		}
+	}
+}
+
+func TestGeneratePasswordInvalidPolicy(t *testing.T) {
+	policies := []PasswordPolicy{
+		{Length: 0},
+		{Length: 3, MinUpper: 2, MinDigits: 2},
+		{Length: 10, MinLower: -1},
+	}
+	for _, policy := range policies {
+		password, err := GeneratePassword(policy)
+		assert.NotNil(t, err)
+		assert.Equal(t, "", password)
+		assert.Equal(t, 0.0, policy.EntropyBits())
+	}
+}
+
+func TestPasswordEntropyBits(t *testing.T) {
+	policy := PasswordPolicy{Length: 10}
+	assert.InDelta(t, 10*5.954196310386876, policy.EntropyBits(), 1e-9)
+	policy.NoRepeatedRuns = true
+	assert.Less(t, policy.EntropyBits(), 10*5.954196310386876)
+	assert.Greater(t, DefaultPasswordPolicy.EntropyBits(), 90.0)
+}
+
+func TestGeneratePasswordSet(t *testing.T) {
+	set, err := GeneratePasswordSet(500, DefaultPasswordPolicy)
+	assert.Nil(t, err)
+	assert.Len(t, set, 500)
+	_, err = GeneratePasswordSet(500, PasswordPolicy{Length: 1})
+	assert.NotNil(t, err)
+}
+
+func TestGenerateAPIKey(t *testing.T) {
+	for test := 0; test < 100; test++ {
+		length := RandomInt(1, 64)
+		key, err := GenerateAPIKey("test", length)
+		assert.Nil(t, err)
+		assert.True(t, strings.HasPrefix(key, "test_"))
+		assert.Len(t, key, length+5)
+		assert.Equal(t, length, countInAlphabet(key[5:], alphaDigits))
+	}
+	_, err := GenerateAPIKey("", 0)
+	assert.NotNil(t, err)
+}
+
+func TestGenerateTokens(t *testing.T) {
+	for numBytes := 1; numBytes < 64; numBytes++ {
+		token, err := GenerateBase32Token(numBytes)
+		assert.Nil(t, err)
+		assert.Len(t, token, (numBytes*8+4)/5)
+		token, err = GenerateBase58Token(numBytes)
+		assert.Nil(t, err)
+		assert.Equal(t, len(token), countInAlphabet(token, base58Alphabet))
+	}
+	assert.Equal(t, "11", encodeBase58([]byte{0, 0}))
+	assert.Equal(t, "5Q", encodeBase58([]byte{255}))
+	assert.Equal(t, "2NEpo7TZRRrLZSi2U", encodeBase58([]byte("Hello World!")))
+}
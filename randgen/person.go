@@ -0,0 +1,280 @@
+package randgen
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Ages of the people returned by RandomPerson and RandomPersonIn
+const (
+	DefaultPersonMinAge = 18
+	DefaultPersonMaxAge = 80
+)
+
+// A person whose fields are consistent with each other: the email is derived from the name, the age
+// matches the birth date, and the national ID, phone and address match the locale
+type Person struct {
+	Locale     string
+	FirstName  string
+	LastName   string
+	Gender     Gender
+	BirthDate  time.Time
+	Age        int
+	NationalID string
+	Email      string
+	Phone      string
+	Address    LocaleAddress
+}
+
+// Generators of national identification numbers by country, countries without one get 10 random digits
+var nationalIDGenerators = map[string]func(p Person) string{
+	"CO": colombianNationalID,
+	"MX": mexicanCURP,
+	"US": usSSN,
+	"BR": brazilianCPF,
+}
+
+// Accented letters and their plain ASCII version, used to build emails from names
+var emailNameReplacer = strings.NewReplacer(
+	"á", "a", "é", "e", "í", "i", "ó", "o", "ú", "u", "à", "a", "â", "a", "ã", "a", "ê", "e", "ô", "o",
+	"õ", "o", "ü", "u", "ç", "c", "ñ", "n", "'", "", " ", "",
+)
+
+// Returns the full name, like "Camila Rojas Ospina"
+func (p Person) FullName() string {
+	return p.FirstName + " " + p.LastName
+}
+
+// Returns the age of the person at the given time
+func (p Person) AgeAt(t time.Time) int {
+	return ageAt(p.BirthDate, t)
+}
+
+// Returns the complete years between the birth date and the given time
+func ageAt(birthDate time.Time, t time.Time) int {
+	age := t.Year() - birthDate.Year()
+	if t.Month() < birthDate.Month() || (t.Month() == birthDate.Month() && t.Day() < birthDate.Day()) {
+		age--
+	}
+	return age
+}
+
+// Returns a pseudo random birth date of someone whose age today is in the interval [minAge,maxAge]
+func randomBirthDate(now time.Time, minAge, maxAge int) time.Time {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	latest := today.AddDate(-minAge, 0, 0)
+	earliest := today.AddDate(-maxAge-1, 0, 1)
+	days := int(latest.Sub(earliest).Hours() / 24)
+	return earliest.AddDate(0, 0, RandomInt(0, days))
+}
+
+// Returns the name in lower case ASCII letters, ready to be part of an email address
+func emailName(name string) string {
+	return emailNameReplacer.Replace(strings.ToLower(name))
+}
+
+// Returns an email derived from the names with one of the usual patterns, like camila.rojas@gmail.com,
+// crojas87@hotmail.com or camila_rojas12@outlook.com
+func personEmail(firstName, lastName string, birthDate time.Time, domains []string) string {
+	first := emailName(firstName)
+	last := emailName(strings.Split(lastName, " ")[0])
+	year := fmt.Sprintf("%02d", birthDate.Year()%100)
+	local := ""
+	switch RandomInt(0, 5) {
+	case 0:
+		local = first + "." + last
+	case 1:
+		local = first[:1] + last + year
+	case 2:
+		local = first + "_" + last + fmt.Sprintf("%d", RandomInt(1, 99))
+	case 3:
+		local = first + last + year
+	case 4:
+		local = first[:1] + "." + last
+	default:
+		local = last + "." + first + fmt.Sprintf("%d", RandomInt(1, 999))
+	}
+	domain, _ := ChooseString(domains)
+	return local + "@" + domain
+}
+
+// Returns a cédula issued when the person turned 18, or a tarjeta de identidad for minors
+func colombianNationalID(p Person) string {
+	if p.Age < 18 {
+		return RandomTarjetaIdentidad()
+	}
+	issueYear := p.BirthDate.Year() + 18
+	switch {
+	case issueYear < 1990:
+		return RandomCedula(CedulaBefore1990)
+	case issueYear < 2004:
+		return RandomCedula(Cedula1990To2003)
+	}
+	return RandomCedula(CedulaSince2004)
+}
+
+// Returns the first vowel of the word after its first letter, X if there is none
+func firstInnerVowel(word string) string {
+	for i := 1; i < len(word); i++ {
+		if strings.IndexByte("AEIOU", word[i]) >= 0 {
+			return word[i : i+1]
+		}
+	}
+	return "X"
+}
+
+// Returns the first consonant of the word after its first letter, X if there is none
+func firstInnerConsonant(word string) string {
+	for i := 1; i < len(word); i++ {
+		if strings.IndexByte("BCDFGHJKLMNPQRSTVWXYZ", word[i]) >= 0 {
+			return word[i : i+1]
+		}
+	}
+	return "X"
+}
+
+// Returns a CURP built from the names, birth date and gender, with a correct check digit
+func mexicanCURP(p Person) string {
+	paternal := strings.ToUpper(emailName(strings.Split(p.LastName, " ")[0]))
+	maternal := "X"
+	if parts := strings.Split(p.LastName, " "); len(parts) > 1 {
+		maternal = strings.ToUpper(emailName(parts[1]))
+	}
+	first := strings.ToUpper(emailName(p.FirstName))
+	sex := "H"
+	if p.Gender == GenderFemale {
+		sex = "M"
+	}
+	states := []string{"AS", "BC", "CH", "DF", "GR", "JC", "MC", "NL", "OC", "PL", "QT", "VZ", "YN"}
+	state, _ := ChooseString(states)
+	homoclave := RandomStringExactLength(1, "0123456789")
+	if p.BirthDate.Year() >= 2000 {
+		homoclave = RandomStringExactLength(1, upperAll)
+	}
+	curp := paternal[:1] + firstInnerVowel(paternal) + maternal[:1] + first[:1] + p.BirthDate.Format("060102") +
+		sex + state + firstInnerConsonant(paternal) + firstInnerConsonant(maternal) + firstInnerConsonant(first) +
+		homoclave
+	return curp + fmt.Sprintf("%d", curpCheckDigit(curp))
+}
+
+// Returns the check digit of the first 17 characters of a CURP
+func curpCheckDigit(curp string) int {
+	dictionary := "0123456789ABCDEFGHIJKLMN&OPQRSTUVWXYZ"
+	sum := 0
+	for i := 0; i < 17 && i < len(curp); i++ {
+		sum += strings.IndexByte(dictionary, curp[i]) * (18 - i)
+	}
+	return (10 - sum%10) % 10
+}
+
+// Returns a social security number like 123-45-6789, areas 000, 666 and 900-999 are never issued
+func usSSN(p Person) string {
+	area := RandomInt(1, 899)
+	for area == 666 {
+		area = RandomInt(1, 899)
+	}
+	return fmt.Sprintf("%03d-%02d-%04d", area, RandomInt(1, 99), RandomInt(1, 9999))
+}
+
+// Returns a CPF like 123.456.789-09 with its two check digits
+func brazilianCPF(p Person) string {
+	digits := make([]int, 9, 11)
+	for i := range digits {
+		digits[i] = RandomInt(0, 9)
+	}
+	for len(digits) < 11 {
+		sum := 0
+		for i, digit := range digits {
+			sum += digit * (len(digits) + 1 - i)
+		}
+		check := sum * 10 % 11
+		if check == 10 {
+			check = 0
+		}
+		digits = append(digits, check)
+	}
+	cpf := ""
+	for _, digit := range digits {
+		cpf += fmt.Sprintf("%d", digit)
+	}
+	return cpf[:3] + "." + cpf[3:6] + "." + cpf[6:9] + "-" + cpf[9:]
+}
+
+// Returns a pseudo random adult person of the es_CO locale
+func RandomPerson() Person {
+	person, err := RandomPersonIn("es_CO")
+	if err != nil {
+		panic(err)
+	}
+	return person
+}
+
+// Returns a pseudo random adult person of the registered locale with the given code
+func RandomPersonIn(localeCode string) (Person, error) {
+	return RandomPersonWithAge(localeCode, DefaultPersonMinAge, DefaultPersonMaxAge)
+}
+
+// Returns a pseudo random person of the registered locale with the given code, its age today is in
+// the interval [minAge,maxAge]
+func RandomPersonWithAge(localeCode string, minAge, maxAge int) (Person, error) {
+	if minAge < 0 || maxAge < 0 || maxAge < minAge {
+		return Person{}, fmt.Errorf("error, invalid arguments in RandomPersonWithAge(localeCode = %s, minAge = %d, maxAge = %d)",
+			localeCode, minAge, maxAge)
+	}
+	locale, err := GetLocale(localeCode)
+	if err != nil {
+		return Person{}, err
+	}
+	now := time.Now().UTC()
+	gender := Gender(RandomInt(int(GenderMale), int(GenderFemale)))
+	person := Person{
+		Locale:    locale.Code,
+		FirstName: locale.FirstName(gender),
+		LastName:  locale.LastName(),
+		Gender:    gender,
+		BirthDate: randomBirthDate(now, minAge, maxAge),
+		Phone:     locale.Phone(),
+		Address:   locale.Address(),
+	}
+	person.Age = person.AgeAt(now)
+	person.Email = personEmail(person.FirstName, person.LastName, person.BirthDate, locale.EmailDomains)
+	if generator, ok := nationalIDGenerators[locale.Country]; ok {
+		person.NationalID = generator(person)
+	} else {
+		person.NationalID = randomDigitsNoLeadingZero(10)
+	}
+	return person, nil
+}
+
+// Returns 'size' adult people of the es_CO locale, no two of them share an email or a national ID
+func PersonSet(size int) ([]Person, error) {
+	return PersonSetIn(size, "es_CO")
+}
+
+// Returns 'size' adult people of the registered locale with the given code, no two of them share an
+// email or a national ID
+func PersonSetIn(size int, localeCode string) ([]Person, error) {
+	if size < 1 {
+		return nil, fmt.Errorf("error, invalid arguments in PersonSetIn(size = %d, localeCode = %s)", size, localeCode)
+	}
+	emails := make(map[string]bool)
+	ids := make(map[string]bool)
+	people := make([]Person, 0, size)
+	for attempts := 0; len(people) < size; attempts++ {
+		if attempts > 100*size {
+			return nil, fmt.Errorf("error, could not generate %d different people for locale %s", size, localeCode)
+		}
+		person, err := RandomPersonIn(localeCode)
+		if err != nil {
+			return nil, err
+		}
+		if emails[person.Email] || ids[person.NationalID] {
+			continue
+		}
+		emails[person.Email] = true
+		ids[person.NationalID] = true
+		people = append(people, person)
+	}
+	return people, nil
+}
@@ -0,0 +1,99 @@
+package randgen
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRandomPerson(t *testing.T) {
+	now := time.Now().UTC()
+	for test := 0; test < 1000; test++ {
+		person := RandomPerson()
+		assert.Equal(t, "es_CO", person.Locale)
+		assert.Equal(t, person.Age, person.AgeAt(now))
+		assert.GreaterOrEqual(t, person.Age, DefaultPersonMinAge)
+		assert.LessOrEqual(t, person.Age, DefaultPersonMaxAge)
+		assert.True(t, IsValidCedula(person.NationalID), person.NationalID)
+		if person.BirthDate.Year()+18 >= 2004 {
+			assert.Len(t, person.NationalID, 10)
+		}
+		local := person.Email[:strings.Index(person.Email, "@")]
+		lastName := emailName(strings.Split(person.LastName, " ")[0])
+		assert.Contains(t, local, lastName, fmt.Sprintf("%v must be derived from %v", person.Email, person.FullName()))
+		assert.Regexp(t, `^\+57 `, person.Phone)
+		assert.Equal(t, "CO", person.Address.Country)
+		assert.Regexp(t, `^\S.* \d+[A-Z]? # \d+[A-Z]? - \d+$`, person.Address.Street)
+	}
+}
+
+func TestRandomPersonIn(t *testing.T) {
+	idPatterns := map[string]string{
+		"es_CO": `^\d{6,10}$`,
+		"es_MX": `^[A-Z]{4}\d{6}[HM][A-Z]{5}[0-9A-Z]\d$`,
+		"en_US": `^\d{3}-\d{2}-\d{4}$`,
+		"pt_BR": `^\d{3}\.\d{3}\.\d{3}-\d{2}$`,
+	}
+	for code, pattern := range idPatterns {
+		for test := 0; test < 200; test++ {
+			person, err := RandomPersonIn(code)
+			assert.Nil(t, err)
+			assert.Regexp(t, pattern, person.NationalID)
+			assert.Regexp(t, `^[a-z0-9._]+@[a-z.]+$`, person.Email)
+		}
+	}
+	_, err := RandomPersonIn("xx_XX")
+	assert.NotNil(t, err)
+	_, err = RandomPersonWithAge("es_CO", 10, 5)
+	assert.NotNil(t, err)
+}
+
+func TestRandomPersonWithAge(t *testing.T) {
+	for test := 0; test < 1000; test++ {
+		minAge := RandomInt(0, 90)
+		maxAge := minAge + RandomInt(0, 5)
+		person, err := RandomPersonWithAge("es_CO", minAge, maxAge)
+		assert.Nil(t, err)
+		assert.GreaterOrEqual(t, person.Age, minAge)
+		assert.LessOrEqual(t, person.Age, maxAge)
+		if person.Age < 18 {
+			assert.True(t, IsValidTarjetaIdentidad(person.NationalID))
+		}
+	}
+}
+
+func TestNationalIDCheckDigits(t *testing.T) {
+	for test := 0; test < 100; test++ {
+		person, _ := RandomPersonIn("es_MX")
+		assert.Equal(t, fmt.Sprintf("%d", curpCheckDigit(person.NationalID)), person.NationalID[17:])
+		cpf := strings.NewReplacer(".", "", "-", "").Replace(brazilianCPF(person))
+		for _, length := range []int{9, 10} {
+			sum := 0
+			for i := 0; i < length; i++ {
+				sum += int(cpf[i]-'0') * (length + 1 - i)
+			}
+			assert.Equal(t, int(cpf[length]-'0'), sum*10%11%10)
+		}
+	}
+}
+
+func TestPersonSet(t *testing.T) {
+	people, err := PersonSet(500)
+	assert.Nil(t, err)
+	assert.Len(t, people, 500)
+	emails := make(map[string]bool)
+	ids := make(map[string]bool)
+	for _, person := range people {
+		emails[person.Email] = true
+		ids[person.NationalID] = true
+	}
+	assert.Len(t, emails, 500)
+	assert.Len(t, ids, 500)
+	_, err = PersonSetIn(0, "es_CO")
+	assert.NotNil(t, err)
+	_, err = PersonSetIn(10, "xx_XX")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,208 @@
+package randgen
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Kind of line of a phone number
+type PhoneLineType int
+
+const (
+	PhoneAnyLine PhoneLineType = iota
+	PhoneMobile
+	PhoneFixed
+)
+
+// A phone number split in its parts, use E164, National or International to render it
+type PhoneNumber struct {
+	Country    string // ISO 3166-1 alpha-2 code: CO, US, MX, ES or AR
+	AreaCode   string // area code, Colombian indicative or mobile operator prefix
+	Subscriber string // the rest of the national number
+	LineType   PhoneLineType
+}
+
+// Country calling codes of the supported countries
+var phoneCountryCodes = map[string]string{"CO": "57", "US": "1", "MX": "52", "ES": "34", "AR": "54"}
+
+// Colombian mobile prefixes assigned to the operators (Claro, Movistar, Tigo, WOM, Avantel)
+var colombianMobilePrefixes = []string{
+	"300", "301", "302", "303", "304", "305", "310", "311", "312", "313", "314", "315", "316", "317",
+	"318", "319", "320", "321", "322", "323", "324", "333", "350",
+}
+
+// Colombian landline indicatives since the 2021 numbering change: 601 Bogotá and Cundinamarca,
+// 602 Cauca, Nariño and Valle, 604 Antioquia, Córdoba and Chocó, 605 Caribbean coast,
+// 606 Caldas, Quindío and Risaralda, 607 Arauca, Norte de Santander and Santander,
+// 608 Amazonía, Boyacá, Casanare, Caquetá, Huila, Meta, Tolima and the rest of the country
+var colombianLandlineIndicatives = []string{"601", "602", "604", "605", "606", "607", "608"}
+
+// Some real US area codes
+var usAreaCodes = []string{
+	"201", "202", "206", "212", "213", "214", "305", "312", "404", "415", "503", "512", "602", "617",
+	"702", "713", "718", "786", "808", "917",
+}
+
+// Mexican area codes, Mexico City, Guadalajara and Monterrey have two digits, the rest three
+var mexicanAreaCodes = []string{"55", "33", "81", "222", "442", "477", "614", "664", "686", "722", "844", "998", "999"}
+
+// Spanish fixed line prefixes of some provinces, mobile numbers start by 6 or 7 instead
+var spanishFixedPrefixes = []string{"910", "911", "913", "914", "915", "917", "918", "932", "933", "934", "935", "954", "955", "961", "963", "965", "976", "981", "985"}
+
+// Argentinian area codes: Buenos Aires, Córdoba, Rosario, Mendoza, La Plata, Tucumán, Mar del Plata
+var argentinianAreaCodes = []string{"11", "351", "341", "261", "221", "381", "223"}
+
+// Returns a string of 'length' digits, the first one is not zero
+func randomDigitsNoLeadingZero(length int) string {
+	return RandomStringExactLength(1, "123456789") + RandomStringExactLength(length-1, "0123456789")
+}
+
+// Returns a plausible phone number of the given country and kind of line. The supported countries are
+// CO, US, MX, ES and AR, PhoneAnyLine chooses mobile or fixed randomly.
+func RandomPhone(country string, lineType PhoneLineType) (PhoneNumber, error) {
+	return defaultGenerator.Phone(country, lineType)
+}
+
+// Returns a plausible phone number of the given country and kind of line, same as RandomPhone
+func (g *Generator) Phone(country string, lineType PhoneLineType) (PhoneNumber, error) {
+	if _, ok := phoneCountryCodes[country]; !ok || lineType < PhoneAnyLine || lineType > PhoneFixed {
+		return PhoneNumber{}, fmt.Errorf("error, invalid arguments in RandomPhone(country = %s, lineType = %d)",
+			country, lineType)
+	}
+	if lineType == PhoneAnyLine {
+		lineType = PhoneLineType(g.Int(int(PhoneMobile), int(PhoneFixed)))
+	}
+	phone := PhoneNumber{Country: country, LineType: lineType}
+	switch country {
+	case "CO":
+		if lineType == PhoneMobile {
+			phone.AreaCode, _ = g.ChooseString(colombianMobilePrefixes)
+			phone.Subscriber = g.StringExactLength(7, "0123456789")
+		} else {
+			phone.AreaCode, _ = g.ChooseString(colombianLandlineIndicatives)
+			phone.Subscriber = g.StringExactLength(1, "2345678") + g.StringExactLength(6, "0123456789")
+		}
+	case "US":
+		// NANP: the exchange can not start with 0 or 1 and can not be N11, 555 is reserved for fiction
+		phone.AreaCode, _ = g.ChooseString(usAreaCodes)
+		exchange := "211"
+		for strings.HasSuffix(exchange, "11") {
+			exchange = g.StringExactLength(1, "2346789") + g.StringExactLength(2, "0123456789")
+		}
+		phone.Subscriber = exchange + g.StringExactLength(4, "0123456789")
+	case "MX":
+		phone.AreaCode, _ = g.ChooseString(mexicanAreaCodes)
+		phone.Subscriber = g.StringExactLength(1, "123456789") + g.StringExactLength(9-len(phone.AreaCode), "0123456789")
+	case "ES":
+		if lineType == PhoneMobile {
+			phone.AreaCode = g.StringExactLength(1, "67") + g.StringExactLength(2, "0123456789")
+			if phone.AreaCode[0] == '7' {
+				phone.AreaCode = "7" + g.StringExactLength(1, "1234") + g.StringExactLength(1, "0123456789")
+			}
+		} else {
+			phone.AreaCode, _ = g.ChooseString(spanishFixedPrefixes)
+		}
+		phone.Subscriber = g.StringExactLength(6, "0123456789")
+	case "AR":
+		phone.AreaCode, _ = g.ChooseString(argentinianAreaCodes)
+		phone.Subscriber = g.StringExactLength(1, "2345") + g.StringExactLength(9-len(phone.AreaCode), "0123456789")
+	}
+	return phone, nil
+}
+
+// Returns a string of 10 digits, same as RandomPhoneNumber
+func (g *Generator) PhoneNumber() string {
+	return g.StringExactLength(10, "0123456789")
+}
+
+// Returns a Colombian mobile number with a real operator prefix, like 310 555 1234
+func RandomColombianMobile() PhoneNumber {
+	phone, _ := RandomPhone("CO", PhoneMobile)
+	return phone
+}
+
+// Returns a Colombian landline number with its area indicative, like (601) 555 1234
+func RandomColombianLandline() PhoneNumber {
+	phone, _ := RandomPhone("CO", PhoneFixed)
+	return phone
+}
+
+// Returns true if the number is a plausible mobile number, false if it is a plausible fixed line.
+// US and MX numbers do not tell mobiles apart, the line type chosen at generation is returned.
+func (p PhoneNumber) IsMobile() bool {
+	return p.LineType == PhoneMobile
+}
+
+// Returns the country calling code, like "57" for Colombia
+func (p PhoneNumber) CountryCode() string {
+	return phoneCountryCodes[p.Country]
+}
+
+// Returns the number in E.164 format, like +573105551234
+func (p PhoneNumber) E164() string {
+	mobileToken := ""
+	if p.Country == "AR" && p.LineType == PhoneMobile {
+		mobileToken = "9"
+	}
+	return "+" + p.CountryCode() + mobileToken + p.AreaCode + p.Subscriber
+}
+
+// Returns the number as it is dialed and written inside the country, like 310 555 1234 or (601) 555 1234
+func (p PhoneNumber) National() string {
+	switch p.Country {
+	case "CO":
+		if p.LineType == PhoneFixed {
+			return fmt.Sprintf("(%s) %s %s", p.AreaCode, p.Subscriber[:3], p.Subscriber[3:])
+		}
+		return fmt.Sprintf("%s %s %s", p.AreaCode, p.Subscriber[:3], p.Subscriber[3:])
+	case "US":
+		return fmt.Sprintf("(%s) %s-%s", p.AreaCode, p.Subscriber[:3], p.Subscriber[3:])
+	case "MX":
+		split := len(p.Subscriber) - 4
+		return fmt.Sprintf("%s %s %s", p.AreaCode, p.Subscriber[:split], p.Subscriber[split:])
+	case "ES":
+		return fmt.Sprintf("%s %s %s", p.AreaCode, p.Subscriber[:3], p.Subscriber[3:])
+	case "AR":
+		split := len(p.Subscriber) - 4
+		if p.LineType == PhoneMobile {
+			return fmt.Sprintf("0%s 15-%s-%s", p.AreaCode, p.Subscriber[:split], p.Subscriber[split:])
+		}
+		return fmt.Sprintf("0%s %s-%s", p.AreaCode, p.Subscriber[:split], p.Subscriber[split:])
+	}
+	return p.AreaCode + p.Subscriber
+}
+
+// Returns the number in international display format, like +57 310 555 1234
+func (p PhoneNumber) International() string {
+	national := p.National()
+	switch p.Country {
+	case "CO":
+		national = strings.NewReplacer("(", "", ")", "").Replace(national)
+	case "US":
+		national = fmt.Sprintf("%s-%s-%s", p.AreaCode, p.Subscriber[:3], p.Subscriber[3:])
+	case "AR":
+		split := len(p.Subscriber) - 4
+		national = fmt.Sprintf("%s %s-%s", p.AreaCode, p.Subscriber[:split], p.Subscriber[split:])
+		if p.LineType == PhoneMobile {
+			national = "9 " + national
+		}
+	}
+	return "+" + p.CountryCode() + " " + national
+}
+
+// Returns a map with 'size' different phone numbers of the given country and kind of line as its keys,
+// the keys are in E.164 format
+func RandomE164Set(size int, country string, lineType PhoneLineType) (map[string]bool, error) {
+	if size < 1 {
+		return nil, fmt.Errorf("error, invalid arguments in RandomE164Set(size = %d, country = %s)", size, country)
+	}
+	set := make(map[string]bool)
+	for len(set) < size {
+		phone, err := RandomPhone(country, lineType)
+		if err != nil {
+			return nil, err
+		}
+		set[phone.E164()] = true
+	}
+	return set, nil
+}
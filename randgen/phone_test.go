@@ -0,0 +1,98 @@
+package randgen
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var e164Patterns = map[string]*regexp.Regexp{
+	"CO": regexp.MustCompile(`^\+57(3\d{9}|60[124-8][2-8]\d{6})$`),
+	"US": regexp.MustCompile(`^\+1[2-9]\d{2}[2-9]\d{6}$`),
+	"MX": regexp.MustCompile(`^\+52[1-9]\d{9}$`),
+	"ES": regexp.MustCompile(`^\+34[6-9]\d{8}$`),
+	"AR": regexp.MustCompile(`^\+549?[1-9]\d{9}$`),
+}
+
+func TestRandomPhoneByCountry(t *testing.T) {
+	for country, pattern := range e164Patterns {
+		for test := 0; test < 300; test++ {
+			phone, err := RandomPhone(country, PhoneAnyLine)
+			assert.Nil(t, err)
+			assert.True(t, pattern.MatchString(phone.E164()), fmt.Sprintf("%v is not a valid %v number", phone.E164(), country))
+			assert.NotEqual(t, PhoneAnyLine, phone.LineType)
+			assert.Regexp(t, `^\+`+phone.CountryCode()+` `, phone.International())
+			assert.NotEmpty(t, phone.National())
+		}
+	}
+	_, err := RandomPhone("XX", PhoneMobile)
+	assert.NotNil(t, err)
+	_, err = RandomPhone("CO", PhoneLineType(7))
+	assert.NotNil(t, err)
+}
+
+func TestRandomColombianPhones(t *testing.T) {
+	for test := 0; test < 1000; test++ {
+		mobile := RandomColombianMobile()
+		assert.True(t, mobile.IsMobile())
+		assert.Contains(t, colombianMobilePrefixes, mobile.AreaCode)
+		assert.Regexp(t, `^3\d{2} \d{3} \d{4}$`, mobile.National())
+		assert.Regexp(t, `^\+57 3\d{2} \d{3} \d{4}$`, mobile.International())
+
+		landline := RandomColombianLandline()
+		assert.False(t, landline.IsMobile())
+		assert.Contains(t, colombianLandlineIndicatives, landline.AreaCode)
+		assert.Regexp(t, `^\(60\d\) \d{3} \d{4}$`, landline.National())
+	}
+}
+
+func TestArgentinianMobileFormats(t *testing.T) {
+	for test := 0; test < 100; test++ {
+		phone, _ := RandomPhone("AR", PhoneMobile)
+		assert.Regexp(t, `^\+549`, phone.E164())
+		assert.Regexp(t, `^0\d+ 15-\d+-\d{4}$`, phone.National())
+		assert.Regexp(t, `^\+54 9 \d+ \d+-\d{4}$`, phone.International())
+	}
+}
+
+func TestRandomE164Set(t *testing.T) {
+	set, err := RandomE164Set(1000, "CO", PhoneMobile)
+	assert.Nil(t, err)
+	assert.Len(t, set, 1000)
+	_, err = RandomE164Set(0, "CO", PhoneMobile)
+	assert.NotNil(t, err)
+	_, err = RandomE164Set(10, "XX", PhoneMobile)
+	assert.NotNil(t, err)
+}
+
+func TestGeneratorPhone(t *testing.T) {
+	first, second := NewGenerator(3), NewGenerator(3)
+	for _, country := range []string{"CO", "US", "MX", "ES", "AR"} {
+		phone, err := first.Phone(country, PhoneAnyLine)
+		assert.Nil(t, err)
+		again, _ := second.Phone(country, PhoneAnyLine)
+		assert.Equal(t, phone, again)
+		assert.Equal(t, country, phone.Country)
+	}
+	_, err := first.Phone("XX", PhoneMobile)
+	assert.NotNil(t, err)
+	assert.Equal(t, NewGenerator(8).PhoneNumber(), NewGenerator(8).PhoneNumber())
+	assert.Regexp(t, `^\d{10}$`, first.PhoneNumber())
+}
+
+func TestUSExchange(t *testing.T) {
+	g := NewGenerator(12)
+	seconds := make(map[byte]bool)
+	for test := 0; test < 3000; test++ {
+		phone, err := g.Phone("US", PhoneAnyLine)
+		assert.Nil(t, err)
+		exchange := phone.Subscriber[:3]
+		assert.Regexp(t, `^[2-9]\d\d$`, exchange)
+		assert.NotEqual(t, "11", exchange[1:], exchange)
+		assert.NotEqual(t, "555", exchange)
+		seconds[exchange[1]] = true
+	}
+	assert.Len(t, seconds, 10)
+}
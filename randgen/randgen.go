@@ -1,13 +1,11 @@
-package main
+// Package randgen generates pseudo random numbers, strings, sets and test data like emails, phone numbers,
+// documents, addresses, people, IDs and text. The functions draw from a generator seeded once with the
+// current time, use a Generator created with NewGenerator for reproducible values.
+package randgen
 
 import (
-	"bytes"
 	"fmt"
 	"math"
-	"math/rand"
-	"os"
-	"text/tabwriter"
-	"time"
 )
 
 var alphaDigits = "abcdefghijkmnpqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ23456789"
@@ -17,31 +15,27 @@ var alphaLower = "abcdefghijkmnpqrstuvwxyz"
 // Returns a random integer 32bit number in the interval [minValue,maxValue].
 // Panic if one or more of the params is negative or maxValue < minValue
 func RandomInt(minValue int, maxValue int) int {
-	rand.Seed(time.Now().UTC().UnixNano())
 	if minValue < 0 || maxValue < 0 || maxValue < minValue {
 		panic(fmt.Sprintf("Error, invalid arguments in RandomInt(%d,%d) function.",minValue,maxValue))
 	}
-	return minValue + rand.Intn(maxValue-minValue+1)
+	return defaultGenerator.Int(minValue, maxValue)
 }
 
 // Returns a random float64 number in the interval [minValue,maxValue).
 func RandomFloat64(minValue float64, maxValue float64) float64{
-	rand.Seed(time.Now().UTC().UnixNano())
 	if minValue < 0 || maxValue < 0 || maxValue <= minValue {
 		panic(fmt.Sprintf("Error, invalid arguments in RandomFLoat64(%v,%v) function.",minValue,maxValue))
 	}
-	//rand.Float64() returns, as a float64, a pseudo-random number in [0.0,1.0) from the default Source.
-	return minValue + (maxValue-minValue)*rand.Float64()
+	return defaultGenerator.Float64(minValue, maxValue)
 }
 
 // Returns a random integer 64bit signed number in the interval [minValue,maxValue].
 // Panic if one or more of the params is negative or maxValue < minValue
 func RandomInt64(minValue int64, maxValue int64) int64 {
-	rand.Seed(time.Now().UTC().UnixNano())
 	if minValue < 0 || maxValue < 0 || maxValue < minValue {
 		panic("Error, invalid arguments in RandomInt64 function.")
 	}
-	return minValue + rand.Int63n(maxValue-minValue+1)
+	return defaultGenerator.Int64(minValue, maxValue)
 }
 
 //Returns a pseudo random digit or letter of the english alphabet
@@ -107,16 +101,10 @@ func RandomEnglishLowerCaseStringExactLength(length int) string {
 //Returns a pseudo random string of character from the given alphabet, it length will be exactly 'length'
 //Each character in the 'alphabet' string will have the same probability to appear in the resulting
 //random string, if all characters in alphabet are the same, those character will have the same probability,
-//the more occurrences a character has in alphabet, the more likely appear in the returning string
+//the more occurrences a character has in alphabet, the more likely appear in the returning string.
+//Alphabets with non ASCII characters are taken rune by rune, and then the length counts runes
 func RandomStringExactLength(length int, alphabet string) string {
-	if len(alphabet) <= 0 {
-		panic("Error, alphabet with no positive length")
-	}
-	var buffer bytes.Buffer
-	for i := 0; i < length; i++ {
-		buffer.WriteByte(alphabet[RandomInt(0, len(alphabet)-1)])
-	}
-	return buffer.String()
+	return defaultGenerator.StringExactLength(length, alphabet)
 }
 
 //Returns a map with 'size' different integers as its keys
@@ -229,39 +217,18 @@ func RandomStringSet(size int, minLength int, maxLength int, alphabet string) (e
 
 //Return a valid pseudo random email address
 func RandomEmail() string {
-	alphabet := alphaLower + "0123456789_0123456789.0123456789"
-	end := RandomStringExactLength(RandomInt(2, 8), alphaLower) +
-		"." + RandomStringExactLength(RandomInt(2, 8), alphaLower)
-	end += RandomStringExactLength(1, alphaLower)
-	return RandomStringExactLength(1, alphaLower) +
-		RandomStringExactLength(RandomInt(2, 8), alphabet) +
-		RandomStringExactLength(1, alphaLower) + "@" +
-		RandomStringExactLength(1, alphaLower) + end
+	return defaultGenerator.Email()
 }
 
 //Return a valid phone number, just a 10 symbols string formed only by digits 0 to 9
 func RandomPhoneNumber() string {
-
-	return RandomStringExactLength(RandomInt(10, 10), "0123456789")
+	return defaultGenerator.PhoneNumber()
 }
 
-//Simple pseudoRandom Colombian Address Generator
+//Simple pseudoRandom Colombian Address Generator, just the street line of RandomColombianAddress,
+//like "Calle 45B # 12A - 30"
 func RandomAddressCOL() string {
-	var first = [5]string{"Calle ", "Carrera ", "Avenida ", "Diagonal ", "Transversal "}
-	answer := first[RandomInt(0, len(first)-1)]
-	answer += fmt.Sprintf("%d", RandomInt(0, 250))
-	if RandomInt(0, 10) < 4 {
-		answer += string(alphaUpper[RandomInt(0, len(alphaUpper)-1)])
-	}
-	answer += " # "
-	answer += fmt.Sprintf("%d", RandomInt(0, 250))
-	if RandomInt(0, 10) < 4 {
-		answer += string(alphaUpper[RandomInt(0, 10)])
-	}
-	answer += " - "
-	answer += fmt.Sprintf("%d", RandomInt(0, 100))
-
-	return answer
+	return RandomColombianAddress().StreetLine()
 }
 
 //Returns a map with 'size' different phones as its keys. A random phone here is just a string
@@ -342,64 +309,12 @@ func ChooseString(elements []string) (string, error) {
 //will be the original set. Parameter P (must be in the interval (0,1) indicates the probability that a
 //element in the original set ends up in the first returned set
 func GetTwoDisjointSets( originalSet map[int]bool , p float64 ) (map[int]bool,map[int]bool) {
-	r := rand.New(rand.NewSource(99))
 	if originalSet == nil || len(originalSet) == 0 || p <= 0 || p >= 1 {
 		return nil,nil
 	}
-	first  := make(map[int]bool)
-	second := make(map[int]bool)
-	for k := range originalSet {
-		if r.Float64() <= p {
-			first[k] = true
-		} else {
-			second[k] = true
-		}
-	}
-	return first,second
-}
-
-//Show use of essential random function in go
-func showEssentials() {
-	// Create and seed the generator.
-	// Typically a non-fixed seed should be used, such as time.Now().UnixNano().
-	// Using a fixed seed will produce the same output on every run.
-	r := rand.New(rand.NewSource(99))
-
-	// The tabwriter here helps us generate aligned output.
-	w := tabwriter.NewWriter(os.Stdout, 1, 1, 1, ' ', 0)
-	defer w.Flush()
-	show := func(name string, v1, v2, v3 interface{}) {
-		fmt.Fprintf(w, "%s\t%v\t%v\t%v\n", name, v1, v2, v3)
+	sets, err := defaultGenerator.PartitionSet(originalSet, []float64{p, 1-p})
+	if err != nil {
+		return nil,nil
 	}
-
-	// Float32 and Float64 values are in [0, 1).
-	show("Float32", r.Float32(), r.Float32(), r.Float32())
-	show("Float64", r.Float64(), r.Float64(), r.Float64())
-
-	// ExpFloat64 values have an average of 1 but decay exponentially.
-	show("ExpFloat64", r.ExpFloat64(), r.ExpFloat64(), r.ExpFloat64())
-
-	// NormFloat64 values have an average of 0 and a standard deviation of 1.
-	show("NormFloat64", r.NormFloat64(), r.NormFloat64(), r.NormFloat64())
-
-	// Int31, Int63, and Uint32 generate values of the given width.
-	// The Int method (not shown) is like either Int31 or Int63
-	// depending on the size of 'int'.
-	show("Int31", r.Int31(), r.Int31(), r.Int31())
-	show("Int63", r.Int63(), r.Int63(), r.Int63())
-	show("Uint32", r.Uint32(), r.Uint32(), r.Uint32())
-
-	// Intn, Int31n, and Int63n limit their output to be < n.
-	// They do so more carefully than using r.Int()%n.
-	show("Intn(10)", r.Intn(10), r.Intn(10), r.Intn(10))
-	show("Int31n(10)", r.Int31n(10), r.Int31n(10), r.Int31n(10))
-	show("Int63n(10)", r.Int63n(10), r.Int63n(10), r.Int63n(10))
-
-	// Perm generates a random permutation of the numbers [0, n).
-	show("Perm", r.Perm(5), r.Perm(5), r.Perm(5))
-}
-
-func main() {
-	//showEssentials()
-
+	return sets[0],sets[1]
 }
@@ -1,7 +1,8 @@
-package main
+package randgen
 
 import (
 	"fmt"
+	"github.com/niquefa/gominirandgen/stats"
 	"github.com/stretchr/testify/assert"
 	"strings"
 	"testing"
@@ -107,34 +108,23 @@ func TestDistributionTwoNumbers(t *testing.T){
 	auxTestDistribution(12,13, 1000 , t)
 }
 
+// Checks with a chi-square test that RandomInt(min,max) is uniform, with the default generator seeded with qualityTestSeed
 func auxTestDistribution( min int, max int, expectedRepetitions int , t *testing.T){
+	defer seedDefaultGenerator(qualityTestSeed)()
 	var options = max-min+1
 	totalTest := options*expectedRepetitions
 
-	var maxError = expectedRepetitions/5
-	frequency := make(map[int]int)
-	for k:=min ; k <=max; k++{
-		frequency[k] = 0
-	}
+	frequency := make([]int, options)
 	for i:=0 ; i < totalTest; i++ {
-		frequency[RandomInt(min,max)]++
+		frequency[RandomInt(min,max)-min]++
 	}
-
-	minAccepted := expectedRepetitions - maxError
-	maxAccepted := expectedRepetitions + maxError
-
-	//fmt.Printf("\nFrequencies: %v\n",frequency)
-	//fmt.Printf(  "expected     : %v\n",expectedRepetitions)
-	//fmt.Printf(  "maxError     : %v\n",maxError)
-	//fmt.Printf(  "minAccepted  : %v\n",minAccepted)
-	//fmt.Printf(  "maxAccepted  : %v\n",maxAccepted)
-	//fmt.Printf("option -> frequency-expected\n")
-
-	for option:=min ; option <=max; option++{
-		assert.GreaterOrEqual(t,frequency[option], minAccepted)
-		assert.LessOrEqual(t,frequency[option], maxAccepted)
-		//fmt.Printf("%d -> %d\n",option,frequency[option]-expectedRepetitions)
+	if options == 1 {
+		assert.Equal(t, totalTest, frequency[0])
+		return
 	}
+	result, err := stats.ChiSquareUniform(frequency)
+	assert.Nil(t, err)
+	assert.True(t, result.Passed(stats.DefaultAlpha), result.String())
 }
 
 func TestRandomIntTwoDigits(t *testing.T) {
@@ -182,6 +172,15 @@ func TestRandomFloat64(t *testing.T){
 	}
 }
 
+func TestRandomFloat64KolmogorovSmirnov(t *testing.T){
+	defer seedDefaultGenerator(qualityTestSeed)()
+	err, sample := RandomFloat64Slice(10000, 5, 15)
+	assert.Nil(t, err)
+	result, err := stats.KolmogorovSmirnov(sample, stats.UniformCDF(5, 15))
+	assert.Nil(t, err)
+	assert.True(t, result.Passed(stats.DefaultAlpha), result.String())
+}
+
 func auxCasesTestRandomFloat64( from float64, to float64, testCount int , t *testing.T )  {
 
 	var minValueFound float64
@@ -267,8 +266,8 @@ func TestRandomEmail( t *testing.T ){
 func TestRandomPhone( t *testing.T ){
 	for i := 0 ; i < 100 ; i++ {
 		phone := RandomPhoneNumber()
-		assert.GreaterOrEqual(t,len(phone),6)
-		assert.LessOrEqual(t,len(phone),15)
+		assert.Len(t,phone,10)
+		assert.Regexp(t,"^[0-9]+$",phone)
 	}
 }
 
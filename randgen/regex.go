@@ -0,0 +1,235 @@
+package randgen
+
+import (
+	"fmt"
+	"regexp"
+	"regexp/syntax"
+	"unicode"
+)
+
+// Default cap for the unbounded repetitions *, + and {n,}
+const DefaultRegexMaxRepeat = 10
+
+// How many strings are tried before giving up, word boundaries may produce strings that do not match
+const maxRegexAttempts = 1000
+
+// Printable ASCII, characters classes and '.' prefer these code points when they are allowed
+var printableASCII = []UnicodeRange{{0x20, 0x7E}}
+
+// RegexOptions tunes the strings produced by RandomFromRegexWithOptions
+type RegexOptions struct {
+	MaxRepeat int  // cap for *, + and {n,} repetitions, DefaultRegexMaxRepeat when zero
+	Negate    bool // produce strings that do NOT match the pattern, for negative tests
+}
+
+// A parsed pattern ready to produce random strings
+type regexGenerator struct {
+	tree      *syntax.Regexp
+	full      *regexp.Regexp
+	maxRepeat int
+	random    *Generator
+}
+
+// Parses the pattern with Perl syntax, as regexp.Compile does
+func newRegexGenerator(pattern string, options RegexOptions, random *Generator) (*regexGenerator, error) {
+	if options.MaxRepeat < 0 {
+		return nil, fmt.Errorf("error, invalid arguments in RandomFromRegex(pattern = %s, maxRepeat = %d)",
+			pattern, options.MaxRepeat)
+	}
+	tree, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return nil, err
+	}
+	full, err := regexp.Compile(`^(?:` + pattern + `)$`)
+	if err != nil {
+		return nil, err
+	}
+	maxRepeat := options.MaxRepeat
+	if maxRepeat == 0 {
+		maxRepeat = DefaultRegexMaxRepeat
+	}
+	return &regexGenerator{tree: tree.Simplify(), full: full, maxRepeat: maxRepeat, random: random}, nil
+}
+
+// Returns a random number of repetitions for the given node
+func (g *regexGenerator) repetitions(node *syntax.Regexp) int {
+	switch node.Op {
+	case syntax.OpStar:
+		return g.random.Int(0, g.maxRepeat)
+	case syntax.OpPlus:
+		if g.maxRepeat < 1 {
+			return 1
+		}
+		return g.random.Int(1, g.maxRepeat)
+	case syntax.OpQuest:
+		return g.random.Int(0, 1)
+	}
+	if node.Max < 0 {
+		return g.random.Int(node.Min, node.Min+g.maxRepeat)
+	}
+	return g.random.Int(node.Min, node.Max)
+}
+
+// Returns a random rune of the character class, printable ASCII characters are preferred
+func (g *regexGenerator) runeFromClass(class []rune) rune {
+	ranges := make([]UnicodeRange, 0, len(class)/2)
+	printable := make([]UnicodeRange, 0, len(class)/2)
+	for i := 0; i+1 < len(class); i += 2 {
+		ranges = append(ranges, UnicodeRange{class[i], class[i+1]})
+		from, to := class[i], class[i+1]
+		if from < printableASCII[0].From {
+			from = printableASCII[0].From
+		}
+		if to > printableASCII[0].To {
+			to = printableASCII[0].To
+		}
+		if from <= to {
+			printable = append(printable, UnicodeRange{from, to})
+		}
+	}
+	if len(printable) > 0 {
+		return g.random.RuneFromRanges(printable)
+	}
+	return g.random.RuneFromRanges(ranges)
+}
+
+// Appends to 'result' a random string matching the node
+func (g *regexGenerator) generate(node *syntax.Regexp, result []rune) ([]rune, error) {
+	switch node.Op {
+	case syntax.OpEmptyMatch, syntax.OpBeginLine, syntax.OpEndLine, syntax.OpBeginText, syntax.OpEndText,
+		syntax.OpWordBoundary, syntax.OpNoWordBoundary:
+		return result, nil
+	case syntax.OpNoMatch:
+		return nil, fmt.Errorf("error, the pattern %v does not match any string", g.tree)
+	case syntax.OpLiteral:
+		for _, r := range node.Rune {
+			if node.Flags&syntax.FoldCase != 0 && g.random.Int(0, 1) == 1 {
+				r = unicode.SimpleFold(r)
+			}
+			result = append(result, r)
+		}
+		return result, nil
+	case syntax.OpCharClass:
+		if len(node.Rune) == 0 {
+			return nil, fmt.Errorf("error, the pattern %v has an empty character class", g.tree)
+		}
+		return append(result, g.runeFromClass(node.Rune)), nil
+	case syntax.OpAnyCharNotNL, syntax.OpAnyChar:
+		return append(result, g.random.RuneFromRanges(printableASCII)), nil
+	case syntax.OpCapture:
+		return g.generate(node.Sub[0], result)
+	case syntax.OpStar, syntax.OpPlus, syntax.OpQuest, syntax.OpRepeat:
+		var err error
+		for count := g.repetitions(node); count > 0 && err == nil; count-- {
+			result, err = g.generate(node.Sub[0], result)
+		}
+		return result, err
+	case syntax.OpConcat:
+		var err error
+		for i := 0; i < len(node.Sub) && err == nil; i++ {
+			result, err = g.generate(node.Sub[i], result)
+		}
+		return result, err
+	case syntax.OpAlternate:
+		return g.generate(node.Sub[g.random.Int(0, len(node.Sub)-1)], result)
+	}
+	return nil, fmt.Errorf("error, unsupported regular expression operator %v", node.Op)
+}
+
+// Returns a random string that fully matches the pattern
+func (g *regexGenerator) matching() (string, error) {
+	for attempt := 0; attempt < maxRegexAttempts; attempt++ {
+		runes, err := g.generate(g.tree, nil)
+		if err != nil {
+			return "", err
+		}
+		if g.full.MatchString(string(runes)) {
+			return string(runes), nil
+		}
+	}
+	return "", fmt.Errorf("error, could not generate a string matching %v", g.tree)
+}
+
+// Returns a random string that does not fully match the pattern. It starts from a matching string and
+// replaces, deletes or inserts a character, so the result is usually "almost" valid.
+func (g *regexGenerator) notMatching() (string, error) {
+	for attempt := 0; attempt < maxRegexAttempts; attempt++ {
+		runes, err := g.generate(g.tree, nil)
+		if err != nil {
+			runes = nil
+		}
+		position := g.random.Int(0, len(runes))
+		mutation := g.random.RuneFromRanges(printableASCII)
+		switch operation := g.random.Int(0, 3); {
+		case operation == 0 && position < len(runes):
+			runes[position] = mutation
+		case operation == 1 && position < len(runes):
+			runes = append(runes[:position], runes[position+1:]...)
+		case operation == 2:
+			runes = []rune(g.random.String(0, g.maxRepeat, alphaDigits))
+		default:
+			runes = append(runes[:position], append([]rune{mutation}, runes[position:]...)...)
+		}
+		if !g.full.MatchString(string(runes)) {
+			return string(runes), nil
+		}
+	}
+	return "", fmt.Errorf("error, could not generate a string not matching %v", g.tree)
+}
+
+// Returns a pseudo random string that fully matches the given pattern, for example
+// RandomFromRegex(`[A-Z]{3}-\d{4}`) could return "QWE-0391". Unbounded repetitions are capped to
+// DefaultRegexMaxRepeat, anchors and word boundaries are honoured.
+func RandomFromRegex(pattern string) (string, error) {
+	return RandomFromRegexWithOptions(pattern, RegexOptions{})
+}
+
+// Returns a pseudo random string that does NOT fully match the given pattern, see RegexOptions.Negate
+func RandomNotMatchingRegex(pattern string) (string, error) {
+	return RandomFromRegexWithOptions(pattern, RegexOptions{Negate: true})
+}
+
+// Returns a pseudo random string for the given pattern according to the options
+func RandomFromRegexWithOptions(pattern string, options RegexOptions) (string, error) {
+	return defaultGenerator.FromRegex(pattern, options)
+}
+
+// Returns a pseudo random string for the given pattern according to the options, see
+// RandomFromRegexWithOptions
+func (g *Generator) FromRegex(pattern string, options RegexOptions) (string, error) {
+	generator, err := newRegexGenerator(pattern, options, g)
+	if err != nil {
+		return "", err
+	}
+	if options.Negate {
+		return generator.notMatching()
+	}
+	return generator.matching()
+}
+
+// Return a slice of the given size with strings generated from the pattern according to the options.
+// It could contain repeated elements
+func RandomFromRegexSlice(size int, pattern string, options RegexOptions) ([]string, error) {
+	if size < 1 {
+		return nil, fmt.Errorf("error, invalid arguments in RandomFromRegexSlice(size = %d, pattern = %s)",
+			size, pattern)
+	}
+	generator, err := newRegexGenerator(pattern, options, defaultGenerator)
+	if err != nil {
+		return nil, err
+	}
+	slice := make([]string, 0, size)
+	for i := 0; i < size; i++ {
+		var str string
+		if options.Negate {
+			str, err = generator.notMatching()
+		} else {
+			str, err = generator.matching()
+		}
+		if err != nil {
+			return nil, err
+		}
+		slice = append(slice, str)
+	}
+	return slice, nil
+}
@@ -0,0 +1,89 @@
+package randgen
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var regexTestPatterns = []string{
+	`[A-Z]{3}-\d{4}`,
+	`^\w+@[a-z]+\.(com|org|co)$`,
+	`a*b+c?`,
+	`(?i)hello|bye`,
+	`[^a-z0-9]{2,5}`,
+	`\bword\b`,
+	`x{3,}`,
+	`.\s\S`,
+	`[αβγ]+ñ`,
+	``,
+}
+
+func TestRandomFromRegex(t *testing.T) {
+	for _, pattern := range regexTestPatterns {
+		full := regexp.MustCompile(`^(?:` + pattern + `)$`)
+		for test := 0; test < 200; test++ {
+			str, err := RandomFromRegex(pattern)
+			assert.Nil(t, err)
+			assert.True(t, full.MatchString(str), fmt.Sprintf("%q must match %v", str, pattern))
+		}
+	}
+}
+
+func TestRandomFromRegexMaxRepeat(t *testing.T) {
+	for test := 0; test < 200; test++ {
+		maxRepeat := RandomInt(1, 20)
+		str, err := RandomFromRegexWithOptions(`a*`, RegexOptions{MaxRepeat: maxRepeat})
+		assert.Nil(t, err)
+		assert.LessOrEqual(t, len(str), maxRepeat)
+		str, err = RandomFromRegexWithOptions(`a+`, RegexOptions{MaxRepeat: maxRepeat})
+		assert.Nil(t, err)
+		assert.True(t, len(str) >= 1 && len(str) <= maxRepeat, str)
+	}
+	_, err := RandomFromRegexWithOptions(`a*`, RegexOptions{MaxRepeat: -1})
+	assert.NotNil(t, err)
+}
+
+func TestRandomNotMatchingRegex(t *testing.T) {
+	for _, pattern := range regexTestPatterns {
+		full := regexp.MustCompile(`^(?:` + pattern + `)$`)
+		for test := 0; test < 200; test++ {
+			str, err := RandomNotMatchingRegex(pattern)
+			assert.Nil(t, err)
+			assert.False(t, full.MatchString(str), fmt.Sprintf("%q must not match %v", str, pattern))
+		}
+	}
+}
+
+func TestRandomFromRegexInvalid(t *testing.T) {
+	_, err := RandomFromRegex(`[a-`)
+	assert.NotNil(t, err)
+	_, err = RandomNotMatchingRegex(`(?s).*`)
+	assert.NotNil(t, err)
+	_, err = RandomFromRegexSlice(0, `a`, RegexOptions{})
+	assert.NotNil(t, err)
+}
+
+func TestRandomFromRegexSlice(t *testing.T) {
+	slice, err := RandomFromRegexSlice(100, `[A-Z]{3}-\d{4}`, RegexOptions{})
+	assert.Nil(t, err)
+	assert.Len(t, slice, 100)
+	for _, item := range slice {
+		assert.Regexp(t, `^[A-Z]{3}-\d{4}$`, item)
+	}
+}
+
+func TestGeneratorFromRegex(t *testing.T) {
+	for _, pattern := range regexTestPatterns {
+		first, second := NewGenerator(11), NewGenerator(11)
+		for test := 0; test < 20; test++ {
+			str, err := first.FromRegex(pattern, RegexOptions{})
+			assert.Nil(t, err)
+			again, _ := second.FromRegex(pattern, RegexOptions{})
+			assert.Equal(t, str, again)
+			assert.Regexp(t, `^(?:`+pattern+`)$`, str)
+		}
+	}
+}
@@ -0,0 +1,1029 @@
+package randgen
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// How many times a row is generated again when it repeats the key of a PRIMARY KEY or UNIQUE constraint
+const maxSQLRowAttempts = 1000
+
+// A column of a CREATE TABLE statement
+type SQLColumn struct {
+	Name          string
+	Type          string   // upper case type without arguments, like VARCHAR or DOUBLE PRECISION
+	Arguments     []string // arguments of the type, like 255 of VARCHAR(255) or the values of an ENUM
+	NotNull       bool
+	AutoIncrement bool
+	Computed      bool // generated from other columns, it is never inserted
+	quote         byte // the quote of the identifier in the DDL, 0 if it was not quoted
+}
+
+// A FOREIGN KEY constraint, the columns of a table whose values are the ones of a row of another table
+type SQLForeignKey struct {
+	Columns    []string
+	Table      string
+	References []string // the referenced columns, the primary key of Table when the DDL does not name them
+}
+
+// A table of a CREATE TABLE statement and its constraints
+type SQLTable struct {
+	Name        string
+	Columns     []SQLColumn
+	PrimaryKey  []string
+	Unique      [][]string
+	ForeignKeys []SQLForeignKey
+	quote       byte
+}
+
+// Kinds of the tokens of SQL
+const (
+	sqlWord = iota
+	sqlQuotedName
+	sqlString
+	sqlNumber
+	sqlSymbol
+)
+
+// A token of SQL, the text of quoted names and strings is unquoted
+type sqlToken struct {
+	kind  int
+	text  string
+	quote byte
+}
+
+// Returns the tokens of the SQL text without its comments
+func tokenizeSQL(text string) ([]sqlToken, error) {
+	tokens := make([]sqlToken, 0)
+	isWord := func(c byte) bool {
+		return c == '_' || c == '$' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') || c >= 0x80
+	}
+	for i := 0; i < len(text); {
+		c := text[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case strings.HasPrefix(text[i:], "--") || c == '#':
+			for i < len(text) && text[i] != '\n' {
+				i++
+			}
+		case strings.HasPrefix(text[i:], "/*"):
+			end := strings.Index(text[i+2:], "*/")
+			if end < 0 {
+				return nil, fmt.Errorf("error, unclosed comment in the SQL")
+			}
+			i += end + 4
+		case c == '\'' || c == '"' || c == '`' || c == '[':
+			closing := c
+			if c == '[' {
+				closing = ']'
+			}
+			var builder strings.Builder
+			j := i + 1
+			for ; j < len(text); j++ {
+				if text[j] == closing {
+					if j+1 < len(text) && text[j+1] == closing && closing != ']' {
+						builder.WriteByte(closing)
+						j++
+						continue
+					}
+					break
+				}
+				builder.WriteByte(text[j])
+			}
+			if j >= len(text) {
+				return nil, fmt.Errorf("error, unclosed %c in the SQL", c)
+			}
+			if c == '\'' {
+				tokens = append(tokens, sqlToken{kind: sqlString, text: builder.String()})
+			} else {
+				tokens = append(tokens, sqlToken{kind: sqlQuotedName, text: builder.String(), quote: c})
+			}
+			i = j + 1
+		case c >= '0' && c <= '9':
+			j := i
+			for j < len(text) && ((text[j] >= '0' && text[j] <= '9') || text[j] == '.') {
+				j++
+			}
+			tokens = append(tokens, sqlToken{kind: sqlNumber, text: text[i:j]})
+			i = j
+		case isWord(c):
+			j := i
+			for j < len(text) && isWord(text[j]) {
+				j++
+			}
+			tokens = append(tokens, sqlToken{kind: sqlWord, text: text[i:j]})
+			i = j
+		default:
+			tokens = append(tokens, sqlToken{kind: sqlSymbol, text: string(c)})
+			i++
+		}
+	}
+	return tokens, nil
+}
+
+// Reads a list of tokens
+type sqlParser struct {
+	tokens   []sqlToken
+	position int
+}
+
+// Returns the current token, a symbol ";" at the end
+func (p *sqlParser) peek() sqlToken {
+	if p.position >= len(p.tokens) {
+		return sqlToken{kind: sqlSymbol, text: ";"}
+	}
+	return p.tokens[p.position]
+}
+
+// Returns true, and moves past them, if the next tokens are the keywords
+func (p *sqlParser) accept(keywords ...string) bool {
+	for i, keyword := range keywords {
+		if p.position+i >= len(p.tokens) {
+			return false
+		}
+		token := p.tokens[p.position+i]
+		if token.kind != sqlWord && token.kind != sqlSymbol || !strings.EqualFold(token.text, keyword) {
+			return false
+		}
+	}
+	p.position += len(keywords)
+	return true
+}
+
+// Moves past the next token, and past the whole group if it opens a parenthesis
+func (p *sqlParser) skip() {
+	depth := 0
+	for p.position < len(p.tokens) {
+		token := p.tokens[p.position]
+		p.position++
+		if token.kind == sqlSymbol && token.text == "(" {
+			depth++
+		} else if token.kind == sqlSymbol && token.text == ")" {
+			depth--
+		}
+		if depth <= 0 {
+			return
+		}
+	}
+}
+
+// Returns true if the next token ends an element of a CREATE TABLE: a comma or the closing parenthesis
+func (p *sqlParser) atElementEnd() bool {
+	token := p.peek()
+	return token.kind == sqlSymbol && (token.text == "," || token.text == ")" || token.text == ";")
+}
+
+// Returns a name, qualified names like public.users keep their schema
+func (p *sqlParser) name() (string, byte, error) {
+	parts := make([]string, 0, 2)
+	var quote byte
+	for {
+		token := p.peek()
+		if token.kind != sqlWord && token.kind != sqlQuotedName {
+			return "", 0, fmt.Errorf("error, expected a name in the SQL instead of %q", token.text)
+		}
+		p.position++
+		parts = append(parts, token.text)
+		quote = token.quote
+		if !p.accept(".") {
+			return strings.Join(parts, "."), quote, nil
+		}
+	}
+}
+
+// Returns the names of a parenthesized list, like (id, name)
+func (p *sqlParser) nameList() ([]string, error) {
+	if !p.accept("(") {
+		return nil, fmt.Errorf("error, expected a list of columns in the SQL instead of %q", p.peek().text)
+	}
+	names := make([]string, 0)
+	for {
+		name, _, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+		// index options of MySQL, like (name(10) DESC)
+		for !p.atElementEnd() {
+			p.skip()
+		}
+		if p.accept(")") {
+			return names, nil
+		}
+		if !p.accept(",") {
+			return nil, fmt.Errorf("error, unclosed list of columns in the SQL")
+		}
+	}
+}
+
+// Returns the tables of the CREATE TABLE statements of the SQL, the other statements are ignored
+func ParseSQLSchema(ddl string) ([]SQLTable, error) {
+	tokens, err := tokenizeSQL(ddl)
+	if err != nil {
+		return nil, err
+	}
+	p := &sqlParser{tokens: tokens}
+	tables := make([]SQLTable, 0)
+	for p.position < len(p.tokens) {
+		if !p.accept("CREATE") {
+			p.position++
+			continue
+		}
+		p.accept("OR", "REPLACE")
+		if !p.accept("TABLE") && !((p.accept("TEMPORARY") || p.accept("TEMP") || p.accept("UNLOGGED")) &&
+			p.accept("TABLE")) {
+			continue
+		}
+		p.accept("IF", "NOT", "EXISTS")
+		table := SQLTable{}
+		if table.Name, table.quote, err = p.name(); err != nil {
+			return nil, err
+		}
+		if !p.accept("(") {
+			// CREATE TABLE ... AS SELECT or LIKE
+			continue
+		}
+		if err := p.tableElements(&table); err != nil {
+			return nil, fmt.Errorf("%v, in table %s", err, table.Name)
+		}
+		for _, other := range tables {
+			if strings.EqualFold(other.Name, table.Name) {
+				return nil, fmt.Errorf("error, the table %s is created twice", table.Name)
+			}
+		}
+		tables = append(tables, table)
+	}
+	if err := resolveSQLReferences(tables); err != nil {
+		return nil, err
+	}
+	return tables, nil
+}
+
+// Returns the tables of the CREATE TABLE statements of the SQL file
+func LoadSQLSchema(path string) ([]SQLTable, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSQLSchema(string(data))
+}
+
+// Words that start a table constraint instead of a column
+var sqlTableConstraints = map[string]bool{
+	"CONSTRAINT": true, "PRIMARY": true, "UNIQUE": true, "FOREIGN": true, "CHECK": true, "KEY": true,
+	"INDEX": true, "FULLTEXT": true, "SPATIAL": true, "EXCLUDE": true,
+}
+
+// Reads the columns and constraints of a CREATE TABLE up to its closing parenthesis
+func (p *sqlParser) tableElements(table *SQLTable) error {
+	for {
+		token := p.peek()
+		var err error
+		if token.kind == sqlWord && sqlTableConstraints[strings.ToUpper(token.text)] {
+			err = p.tableConstraint(table)
+		} else {
+			err = p.column(table)
+		}
+		if err != nil {
+			return err
+		}
+		if p.accept(")") {
+			return nil
+		}
+		if !p.accept(",") {
+			return fmt.Errorf("error, unexpected %q in the SQL", p.peek().text)
+		}
+	}
+}
+
+// Reads a table constraint like PRIMARY KEY (a, b) or FOREIGN KEY (a) REFERENCES t (b)
+func (p *sqlParser) tableConstraint(table *SQLTable) error {
+	if p.accept("CONSTRAINT") {
+		if _, _, err := p.name(); err != nil {
+			return err
+		}
+	}
+	switch {
+	case p.accept("PRIMARY", "KEY"):
+		columns, err := p.nameList()
+		if err != nil {
+			return err
+		}
+		table.PrimaryKey = columns
+	case p.accept("UNIQUE"):
+		if !p.accept("KEY") {
+			p.accept("INDEX")
+		}
+		if token := p.peek(); token.kind == sqlWord || token.kind == sqlQuotedName {
+			p.position++
+		}
+		columns, err := p.nameList()
+		if err != nil {
+			return err
+		}
+		table.Unique = append(table.Unique, columns)
+	case p.accept("FOREIGN", "KEY"):
+		columns, err := p.nameList()
+		if err != nil {
+			return err
+		}
+		if !p.accept("REFERENCES") {
+			return fmt.Errorf("error, FOREIGN KEY without REFERENCES in the SQL")
+		}
+		key, err := p.references(columns)
+		if err != nil {
+			return err
+		}
+		table.ForeignKeys = append(table.ForeignKeys, key)
+	}
+	for !p.atElementEnd() {
+		p.skip()
+	}
+	return nil
+}
+
+// Reads the table and the optional columns after REFERENCES
+func (p *sqlParser) references(columns []string) (SQLForeignKey, error) {
+	key := SQLForeignKey{Columns: columns}
+	var err error
+	if key.Table, _, err = p.name(); err != nil {
+		return key, err
+	}
+	if token := p.peek(); token.kind == sqlSymbol && token.text == "(" {
+		if key.References, err = p.nameList(); err != nil {
+			return key, err
+		}
+	}
+	return key, nil
+}
+
+// Reads a column, its type and its constraints
+func (p *sqlParser) column(table *SQLTable) error {
+	column := SQLColumn{}
+	var err error
+	if column.Name, column.quote, err = p.name(); err != nil {
+		return err
+	}
+	words := make([]string, 0, 2)
+	for token := p.peek(); token.kind == sqlWord && !sqlColumnConstraints[strings.ToUpper(token.text)]; token = p.peek() {
+		words = append(words, strings.ToUpper(token.text))
+		p.position++
+		if p.accept("(") {
+			for !p.accept(")") {
+				if token := p.peek(); token.kind != sqlSymbol {
+					column.Arguments = append(column.Arguments, token.text)
+				} else if token.text == ";" {
+					return fmt.Errorf("error, unclosed arguments of the type of %s", column.Name)
+				}
+				p.position++
+			}
+		}
+	}
+	if len(words) == 0 {
+		return fmt.Errorf("error, the column %s has no type", column.Name)
+	}
+	column.Type = strings.Join(words, " ")
+	if column.Type == "SERIAL" || column.Type == "BIGSERIAL" || column.Type == "SMALLSERIAL" {
+		column.AutoIncrement = true
+	}
+	for !p.atElementEnd() {
+		switch {
+		case p.accept("NOT", "NULL"):
+			column.NotNull = true
+		case p.accept("PRIMARY", "KEY"):
+			column.NotNull = true
+			table.PrimaryKey = []string{column.Name}
+		case p.accept("UNIQUE"):
+			p.accept("KEY")
+			table.Unique = append(table.Unique, []string{column.Name})
+		case p.accept("REFERENCES"):
+			key, err := p.references([]string{column.Name})
+			if err != nil {
+				return err
+			}
+			table.ForeignKeys = append(table.ForeignKeys, key)
+		case p.accept("AUTO_INCREMENT") || p.accept("AUTOINCREMENT") || p.accept("IDENTITY"):
+			column.AutoIncrement = true
+		case p.accept("GENERATED"):
+			for !p.atElementEnd() && !p.accept("AS") {
+				p.position++
+			}
+			if p.accept("IDENTITY") {
+				column.AutoIncrement = true
+			} else if token := p.peek(); token.kind == sqlSymbol && token.text == "(" {
+				column.Computed = true
+			}
+		case p.accept("AS"):
+			// computed columns of MySQL and SQLite, like total INT AS (price * quantity)
+			column.Computed = true
+		default:
+			p.skip()
+		}
+	}
+	table.Columns = append(table.Columns, column)
+	return nil
+}
+
+// Words that end the type of a column and start its constraints
+var sqlColumnConstraints = map[string]bool{
+	"NOT": true, "NULL": true, "PRIMARY": true, "UNIQUE": true, "REFERENCES": true, "DEFAULT": true, "CHECK": true,
+	"CONSTRAINT": true, "AUTO_INCREMENT": true, "AUTOINCREMENT": true, "IDENTITY": true, "GENERATED": true,
+	"COLLATE": true, "COMMENT": true, "ON": true, "AS": true, "KEY": true,
+}
+
+// Returns the index of the table with the name, or -1. The schema of qualified names is optional.
+func sqlTableIndex(tables []SQLTable, name string) int {
+	for i, table := range tables {
+		if strings.EqualFold(table.Name, name) {
+			return i
+		}
+	}
+	for i, table := range tables {
+		if strings.EqualFold(table.Name[strings.LastIndex(table.Name, ".")+1:], name[strings.LastIndex(name, ".")+1:]) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Returns the index of the column with the name, or -1
+func (t SQLTable) columnIndex(name string) int {
+	for i, column := range t.Columns {
+		if strings.EqualFold(column.Name, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Checks that the constraints name columns of their tables and completes the foreign keys without
+// referenced columns with the primary key of the referenced table
+func resolveSQLReferences(tables []SQLTable) error {
+	for t := range tables {
+		table := &tables[t]
+		constraints := append([][]string{table.PrimaryKey}, table.Unique...)
+		for _, key := range table.ForeignKeys {
+			constraints = append(constraints, key.Columns)
+		}
+		for _, columns := range constraints {
+			for _, name := range columns {
+				if table.columnIndex(name) < 0 {
+					return fmt.Errorf("error, the table %s has no column %s", table.Name, name)
+				}
+			}
+		}
+		for _, name := range table.PrimaryKey {
+			table.Columns[table.columnIndex(name)].NotNull = true
+		}
+		for k := range table.ForeignKeys {
+			key := &table.ForeignKeys[k]
+			parent := sqlTableIndex(tables, key.Table)
+			if parent < 0 {
+				return fmt.Errorf("error, the table %s references the table %s, which is not created", table.Name, key.Table)
+			}
+			key.Table = tables[parent].Name
+			if len(key.References) == 0 {
+				key.References = tables[parent].PrimaryKey
+			}
+			if len(key.References) != len(key.Columns) {
+				return fmt.Errorf("error, the foreign key %v of %s does not match the columns it references in %s",
+					key.Columns, table.Name, key.Table)
+			}
+			for _, name := range key.References {
+				if tables[parent].columnIndex(name) < 0 {
+					return fmt.Errorf("error, the table %s has no column %s, referenced by %s", key.Table, name, table.Name)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Returns the tables sorted so every table comes after the tables it references, the tables without
+// dependencies between them keep their order. Returns an error if the references have a cycle,
+// references of a table to itself are allowed.
+func SortSQLTables(tables []SQLTable) ([]SQLTable, error) {
+	sorted := make([]SQLTable, 0, len(tables))
+	done := make([]bool, len(tables))
+	for len(sorted) < len(tables) {
+		progress := false
+		for i, table := range tables {
+			if done[i] {
+				continue
+			}
+			ready := true
+			for _, key := range table.ForeignKeys {
+				if parent := sqlTableIndex(tables, key.Table); parent != i && parent >= 0 && !done[parent] {
+					ready = false
+				}
+			}
+			if ready {
+				sorted = append(sorted, table)
+				done[i] = true
+				progress = true
+			}
+		}
+		if !progress {
+			pending := make([]string, 0)
+			for i, table := range tables {
+				if !done[i] {
+					pending = append(pending, table.Name)
+				}
+			}
+			return nil, fmt.Errorf("error, the foreign keys of the tables %s form a cycle", strings.Join(pending, ", "))
+		}
+	}
+	return sorted, nil
+}
+
+// Options of the rows generated by Generator.SeedSQL
+type SQLSeedOptions struct {
+	Rows            int            // rows of every table
+	TableRows       map[string]int // rows of some tables, instead of Rows
+	NullProbability float64        // probability of NULL in the nullable columns that are not part of a key
+	Locale          string         // locale of the names, cities and companies
+}
+
+// Options of 10 rows per table with some NULL values and Colombian names
+var DefaultSQLSeedOptions = SQLSeedOptions{Rows: 10, NullProbability: 0.1, Locale: "es_CO"}
+
+// The rows generated for some tables, every value is an SQL literal like 'Ana', 42 or NULL
+type SQLSeed struct {
+	Tables []SQLTable            // in dependency order, every table after the ones it references
+	Rows   map[string][][]string // rows of every table by its name, a value for every column
+}
+
+// Names of the integer SQL types, INTERVAL or POINT contain INT but are not integers
+var sqlIntegerTypes = map[string]bool{
+	"INT": true, "INTEGER": true, "TINYINT": true, "SMALLINT": true, "MEDIUMINT": true, "BIGINT": true,
+	"INT2": true, "INT4": true, "INT8": true, "SERIAL": true, "SMALLSERIAL": true, "BIGSERIAL": true,
+	"SERIAL2": true, "SERIAL4": true, "SERIAL8": true,
+}
+
+// Returns the kind of values of the SQL type: integer, decimal, float, boolean, date, timestamp, time,
+// interval, point, multipoint, uuid, json, enum or string
+func sqlTypeKind(sqlType string) string {
+	first := strings.Fields(sqlType)[0]
+	switch {
+	case sqlIntegerTypes[first]:
+		return "integer"
+	case first == "DECIMAL" || first == "NUMERIC" || first == "MONEY" || first == "DEC":
+		return "decimal"
+	case first == "FLOAT" || first == "REAL" || first == "DOUBLE" || strings.HasPrefix(first, "FLOAT"):
+		return "float"
+	case first == "BOOLEAN" || first == "BOOL" || first == "BIT":
+		return "boolean"
+	case first == "DATE":
+		return "date"
+	case strings.HasPrefix(first, "TIMESTAMP") || first == "DATETIME" || first == "DATETIME2":
+		return "timestamp"
+	case first == "TIME" || first == "TIMETZ":
+		return "time"
+	case first == "INTERVAL":
+		return "interval"
+	case first == "POINT":
+		return "point"
+	case first == "MULTIPOINT":
+		return "multipoint"
+	case first == "UUID" || first == "UNIQUEIDENTIFIER":
+		return "uuid"
+	case first == "JSON" || first == "JSONB":
+		return "json"
+	case first == "ENUM":
+		return "enum"
+	}
+	return "string"
+}
+
+// Largest values of the integer types, the other integers stay below a million
+var sqlIntegerMaximums = map[string]int64{"TINYINT": 127, "SMALLINT": 32767, "MEDIUMINT": 8388607, "INT2": 32767,
+	"SMALLSERIAL": 32767, "SERIAL2": 32767}
+
+// Returns the literal of the text, with its quotes doubled
+func sqlQuote(text string) string {
+	return "'" + strings.Replace(text, "'", "''", -1) + "'"
+}
+
+// Returns the text cut to the length of the column, like the 20 of VARCHAR(20)
+func fitSQLLength(text string, column SQLColumn) string {
+	if len(column.Arguments) == 0 {
+		return text
+	}
+	length, err := strconv.Atoi(column.Arguments[0])
+	if runes := []rune(text); err == nil && length > 0 && len(runes) > length {
+		return string(runes[:length])
+	}
+	return text
+}
+
+// Returns a literal for a text column, guessed from its name: emails, phones, names, cities, companies,
+// URLs, codes, or random letters and digits up to the length of the column
+func (g *Generator) sqlText(column SQLColumn, locale Locale) string {
+	gender := Gender(g.Int(int(GenderMale), int(GenderFemale)))
+	firstName := func() string {
+		if gender == GenderMale {
+			name, _ := g.ChooseString(locale.MaleFirstNames)
+			return name
+		}
+		name, _ := g.ChooseString(locale.FemaleFirstNames)
+		return name
+	}
+	var text string
+	switch GuessNameKind(column.Name) {
+	case "email":
+		text = g.Email()
+	case "phone":
+		text = g.PhoneNumber()
+	case "first_name":
+		text = firstName()
+	case "last_name":
+		text, _ = g.ChooseString(locale.LastNames)
+	case "city":
+		text = locale.Cities[g.Int(0, len(locale.Cities)-1)].Name
+	case "company":
+		text, _ = g.ChooseString(locale.LastNames)
+		suffix, _ := g.ChooseString(locale.CompanySuffixes)
+		text += " " + suffix
+	case "url":
+		text = "https://" + randomSchemaHostname(g) + "/" + g.String(1, 10, alphaLower)
+	case "uuid":
+		text = g.UUIDv4()
+	case "name":
+		lastName, _ := g.ChooseString(locale.LastNames)
+		text = firstName() + " " + lastName
+	case "text":
+		words := make([]string, g.Int(3, 12))
+		for i := range words {
+			words[i], _ = g.ChooseString(loremWords)
+		}
+		text = strings.Join(words, " ")
+	default:
+		maxLength := 16
+		if len(column.Arguments) > 0 {
+			if length, err := strconv.Atoi(column.Arguments[0]); err == nil && length < maxLength {
+				maxLength = length
+			}
+		}
+		text = g.String(1, maxLength, alphaDigits)
+	}
+	return sqlQuote(fitSQLLength(text, column))
+}
+
+// Returns a coordinate of a point between -180 and 180 with up to 6 decimals, like -74.081749
+func (g *Generator) sqlCoordinate() string {
+	return strconv.FormatFloat(math.Round(g.Uniform(-180, 180)*1e6)/1e6, 'f', -1, 64)
+}
+
+// Returns a literal of the type of the column, or an error if the arguments of its type are not numbers
+// where they should be
+func (g *Generator) sqlValue(column SQLColumn, locale Locale) (string, error) {
+	switch sqlTypeKind(column.Type) {
+	case "integer":
+		maximum, ok := sqlIntegerMaximums[strings.Fields(column.Type)[0]]
+		if !ok {
+			maximum = 1000000
+		}
+		return strconv.FormatInt(g.Int64(0, maximum), 10), nil
+	case "decimal":
+		precision, scale := 10, 2
+		if len(column.Arguments) > 0 {
+			var err error
+			if precision, err = strconv.Atoi(column.Arguments[0]); err != nil || precision < 1 {
+				return "", fmt.Errorf("error, the precision %s of the column %s is not a positive integer",
+					column.Arguments[0], column.Name)
+			}
+			scale = 0
+		}
+		if len(column.Arguments) > 1 {
+			var err error
+			if scale, err = strconv.Atoi(column.Arguments[1]); err != nil {
+				return "", fmt.Errorf("error, the scale %s of the column %s is not an integer", column.Arguments[1],
+					column.Name)
+			}
+		}
+		digits := precision - scale
+		if digits < 0 {
+			// a scale larger than the precision, like NUMERIC(2,4), only fits numbers below 10^(precision-scale)
+			digits = 0
+		} else if digits > 6 {
+			digits = 6
+		}
+		integer := g.Int64(0, int64(math.Pow10(digits))-1)
+		if scale <= 0 {
+			return strconv.FormatInt(integer, 10), nil
+		}
+		zeros := 0
+		if scale > precision {
+			zeros = scale - precision
+		}
+		return fmt.Sprintf("%d.%s%s", integer, strings.Repeat("0", zeros), g.StringExactLength(scale-zeros,
+			"0123456789")), nil
+	case "float":
+		return strconv.FormatFloat(math.Round(g.Float64(0, 1000)*1000)/1000, 'f', -1, 64), nil
+	case "boolean":
+		if g.Int(0, 1) == 0 {
+			return "FALSE", nil
+		}
+		return "TRUE", nil
+	case "date", "timestamp", "time":
+		moment := time.Unix(g.Int64(946684800, 1924991999), 0).UTC()
+		layout := map[string]string{"date": "2006-01-02", "timestamp": "2006-01-02 15:04:05", "time": "15:04:05"}
+		return sqlQuote(moment.Format(layout[sqlTypeKind(column.Type)])), nil
+	case "interval":
+		return sqlQuote(fmt.Sprintf("%d days %02d:%02d:%02d", g.Int(0, 365), g.Int(0, 23), g.Int(0, 59),
+			g.Int(0, 59))), nil
+	case "point":
+		return sqlQuote(fmt.Sprintf("(%s,%s)", g.sqlCoordinate(), g.sqlCoordinate())), nil
+	case "multipoint":
+		points := make([]string, g.Int(1, 4))
+		for i := range points {
+			points[i] = g.sqlCoordinate() + " " + g.sqlCoordinate()
+		}
+		return sqlQuote("MULTIPOINT(" + strings.Join(points, ", ") + ")"), nil
+	case "uuid":
+		return sqlQuote(g.UUIDv4()), nil
+	case "json":
+		return sqlQuote(fmt.Sprintf(`{"value": %d}`, g.Int(0, 1000))), nil
+	case "enum":
+		if len(column.Arguments) > 0 {
+			value, _ := g.ChooseString(column.Arguments)
+			return sqlQuote(value), nil
+		}
+	}
+	return g.sqlText(column, locale), nil
+}
+
+// Returns the index of every column of the names in the table
+func (t SQLTable) columnIndices(names []string) []int {
+	indices := make([]int, len(names))
+	for i, name := range names {
+		indices[i] = t.columnIndex(name)
+	}
+	return indices
+}
+
+// Returns true if the columns are exactly the ones of a PRIMARY KEY or UNIQUE constraint, so every row
+// has different values in them
+func (t SQLTable) isUnique(columns []string) bool {
+	for _, constraint := range append([][]string{t.PrimaryKey}, t.Unique...) {
+		if len(constraint) != len(columns) || len(columns) == 0 {
+			continue
+		}
+		same := true
+		for _, name := range columns {
+			found := false
+			for _, other := range constraint {
+				found = found || strings.EqualFold(name, other)
+			}
+			same = same && found
+		}
+		if same {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns pseudo random rows for the tables, in dependency order. The values are guessed from the types
+// and names of the columns, an integer primary key gets the values 1, 2, 3... The values of every PRIMARY
+// KEY and UNIQUE constraint are different in every row, kept in sets as the Random*Set functions do, and
+// the values of every FOREIGN KEY are the ones of a row of the referenced table.
+func (g *Generator) SeedSQL(tables []SQLTable, options SQLSeedOptions) (SQLSeed, error) {
+	locale, err := GetLocale(options.Locale)
+	if err != nil {
+		return SQLSeed{}, err
+	}
+	if options.NullProbability < 0 || options.NullProbability > 1 {
+		return SQLSeed{}, fmt.Errorf("error, invalid arguments in SeedSQL(NullProbability = %v)", options.NullProbability)
+	}
+	sorted, err := SortSQLTables(tables)
+	if err != nil {
+		return SQLSeed{}, err
+	}
+	seed := SQLSeed{Tables: sorted, Rows: make(map[string][][]string)}
+	for _, table := range sorted {
+		count := options.Rows
+		for name, rows := range options.TableRows {
+			if strings.EqualFold(name, table.Name) {
+				count = rows
+			}
+		}
+		if count < 0 {
+			return SQLSeed{}, fmt.Errorf("error, invalid number of rows %d of the table %s", count, table.Name)
+		}
+		rows, err := g.seedTable(table, count, seed, options.NullProbability, locale)
+		if err != nil {
+			return SQLSeed{}, err
+		}
+		seed.Rows[table.Name] = rows
+	}
+	return seed, nil
+}
+
+// Returns the rows of the table, the tables it references already have their rows in the seed
+func (g *Generator) seedTable(table SQLTable, count int, seed SQLSeed, nullProbability float64, locale Locale) ([][]string, error) {
+	keyColumns := make(map[int]bool)
+	constraints := make([][]int, 0)
+	for _, constraint := range append([][]string{table.PrimaryKey}, table.Unique...) {
+		if len(constraint) > 0 {
+			constraints = append(constraints, table.columnIndices(constraint))
+		}
+		for _, index := range table.columnIndices(constraint) {
+			keyColumns[index] = true
+		}
+	}
+	foreign := make(map[int]bool)
+	parents := make([][][]string, len(table.ForeignKeys))
+	referenced := make([][]int, len(table.ForeignKeys))
+	permutations := make([][]int, len(table.ForeignKeys))
+	for k, key := range table.ForeignKeys {
+		for _, index := range table.columnIndices(key.Columns) {
+			foreign[index] = true
+		}
+		parents[k] = seed.Rows[key.Table]
+		referenced[k] = seed.Tables[sqlTableIndex(seed.Tables, key.Table)].columnIndices(key.References)
+		if !strings.EqualFold(key.Table, table.Name) && table.isUnique(key.Columns) {
+			if count > len(parents[k]) {
+				return nil, fmt.Errorf("error, the table %s needs %d rows of %s for its unique foreign key, it has %d",
+					table.Name, count, key.Table, len(parents[k]))
+			}
+			permutations[k] = g.Perm(len(parents[k]))
+		}
+	}
+	sequence := -1
+	if len(table.PrimaryKey) == 1 {
+		index := table.columnIndex(table.PrimaryKey[0])
+		if sqlTypeKind(table.Columns[index].Type) == "integer" && !foreign[index] {
+			sequence = index
+		}
+	}
+	seen := make([]map[string]bool, len(constraints))
+	for i := range seen {
+		seen[i] = make(map[string]bool)
+	}
+	rows := make([][]string, 0, count)
+	for len(rows) < count {
+		var row []string
+		accepted := false
+		for attempt := 0; attempt < maxSQLRowAttempts && !accepted; attempt++ {
+			row = make([]string, len(table.Columns))
+			for c, column := range table.Columns {
+				switch {
+				case c == sequence:
+					row[c] = strconv.Itoa(len(rows) + 1)
+				case foreign[c] || column.Computed:
+				case !column.NotNull && !keyColumns[c] && g.Float64(0, 1) < nullProbability:
+					row[c] = "NULL"
+				default:
+					value, err := g.sqlValue(column, locale)
+					if err != nil {
+						return nil, err
+					}
+					row[c] = value
+				}
+			}
+			for k, key := range table.ForeignKeys {
+				if err := g.seedForeignKey(table, key, row, rows, parents[k], referenced[k], permutations[k],
+					nullProbability); err != nil {
+					return nil, err
+				}
+			}
+			accepted = true
+			for i, constraint := range constraints {
+				if key := sqlRowKey(row, constraint); key != "" && seen[i][key] {
+					accepted = false
+				}
+			}
+		}
+		if !accepted {
+			return nil, fmt.Errorf("error, could not generate %d rows of %s with unique keys, it got %d", count,
+				table.Name, len(rows))
+		}
+		for i, constraint := range constraints {
+			if key := sqlRowKey(row, constraint); key != "" {
+				seen[i][key] = true
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// Returns the values of the columns of the row joined, or an empty key if one of them is NULL, as NULL
+// values never repeat a key
+func sqlRowKey(row []string, columns []int) string {
+	values := make([]string, len(columns))
+	for i, index := range columns {
+		if row[index] == "NULL" {
+			return ""
+		}
+		values[i] = row[index]
+	}
+	return strings.Join(values, "\x00")
+}
+
+// Sets the columns of the foreign key of the row to the values of a row of the referenced table, referenced
+// holds the index of every referenced column in the rows of that table. A table that references itself
+// chooses one of the previous rows, or NULL, or the row itself if there is none.
+func (g *Generator) seedForeignKey(table SQLTable, key SQLForeignKey, row []string, previous [][]string,
+	parents [][]string, referenced []int, permutation []int, nullProbability float64) error {
+	columns := table.columnIndices(key.Columns)
+	nullable := true
+	for _, index := range columns {
+		nullable = nullable && !table.Columns[index].NotNull
+	}
+	self := strings.EqualFold(key.Table, table.Name)
+	if self {
+		parents = previous
+	}
+	if nullable && (len(parents) == 0 || g.Float64(0, 1) < nullProbability) {
+		for _, index := range columns {
+			row[index] = "NULL"
+		}
+		return nil
+	}
+	parent := row
+	switch {
+	case permutation != nil:
+		parent = parents[permutation[len(previous)]]
+	case len(parents) > 0:
+		parent = parents[g.Int(0, len(parents)-1)]
+	case !self:
+		return fmt.Errorf("error, the table %s references %s, which has no rows", table.Name, key.Table)
+	}
+	for i, index := range columns {
+		row[index] = parent[referenced[i]]
+	}
+	return nil
+}
+
+// Matches the identifiers that need no quotes
+var plainSQLName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Returns the name with the quotes it had in the DDL
+func quoteSQLName(name string, quote byte) string {
+	if quote == 0 && plainSQLName.MatchString(strings.Replace(name, ".", "_", -1)) {
+		return name
+	}
+	closing := quote
+	switch quote {
+	case '[':
+		closing = ']'
+	case 0:
+		quote, closing = '"', '"'
+	}
+	return string(quote) + name + string(closing)
+}
+
+// Writes INSERT statements of the rows of the seed, the tables in dependency order and batchSize rows per
+// statement. The computed columns are left out.
+func (s SQLSeed) WriteInserts(w io.Writer, batchSize int) error {
+	if batchSize < 1 {
+		return fmt.Errorf("error, invalid arguments in WriteInserts(batchSize = %d)", batchSize)
+	}
+	for _, table := range s.Tables {
+		rows := s.Rows[table.Name]
+		names := make([]string, 0, len(table.Columns))
+		for _, column := range table.Columns {
+			if !column.Computed {
+				names = append(names, quoteSQLName(column.Name, column.quote))
+			}
+		}
+		if _, err := fmt.Fprintf(w, "-- %s: %d rows\n", table.Name, len(rows)); err != nil {
+			return err
+		}
+		for start := 0; start < len(rows); start += batchSize {
+			end := start + batchSize
+			if end > len(rows) {
+				end = len(rows)
+			}
+			var builder strings.Builder
+			fmt.Fprintf(&builder, "INSERT INTO %s (%s) VALUES\n", quoteSQLName(table.Name, table.quote),
+				strings.Join(names, ", "))
+			for i, row := range rows[start:end] {
+				values := make([]string, 0, len(row))
+				for c, value := range row {
+					if !table.Columns[c].Computed {
+						values = append(values, value)
+					}
+				}
+				separator := ",\n"
+				if start+i == end-1 {
+					separator = ";\n"
+				}
+				builder.WriteString("  (" + strings.Join(values, ", ") + ")" + separator)
+			}
+			if _, err := io.WriteString(w, builder.String()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,286 @@
+package randgen
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// A schema whose tables are created before the tables they reference, with table and column constraints
+const testSQLSchema = `
+-- orders of the shop
+CREATE TABLE IF NOT EXISTS order_items (
+  order_id INT NOT NULL,
+  product_code VARCHAR(12) NOT NULL REFERENCES products (code),
+  quantity SMALLINT NOT NULL CHECK (quantity > 0),
+  price DECIMAL(8, 2),
+  total DECIMAL(10, 2) GENERATED ALWAYS AS (price * quantity) STORED,
+  PRIMARY KEY (order_id, product_code),
+  CONSTRAINT fk_order FOREIGN KEY (order_id) REFERENCES "orders" (id) ON DELETE CASCADE
+);
+CREATE INDEX items_by_product ON order_items (product_code);
+CREATE TABLE "orders" (
+  id BIGSERIAL PRIMARY KEY,
+  user_id INTEGER REFERENCES users,
+  status ENUM('new', 'paid', 'it''s shipped') NOT NULL DEFAULT 'new',
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+/* users and products */
+CREATE TABLE users (
+  id SERIAL PRIMARY KEY,
+  email VARCHAR(80) NOT NULL UNIQUE,
+  first_name VARCHAR(30) NOT NULL,
+  last_name VARCHAR(5) NOT NULL,
+  mobile_phone CHAR(10),
+  manager_id INT REFERENCES users (id),
+  active BOOLEAN NOT NULL DEFAULT TRUE,
+  birth_date DATE
+);
+CREATE TABLE products (
+  code VARCHAR(12) NOT NULL,
+  name TEXT NOT NULL,
+  profile_id UUID UNIQUE,
+  weight DOUBLE PRECISION,
+  CONSTRAINT pk_products PRIMARY KEY (code)
+);
+`
+
+func TestParseSQLSchema(t *testing.T) {
+	tables, err := ParseSQLSchema(testSQLSchema)
+	assert.Nil(t, err)
+	names := make([]string, len(tables))
+	for i, table := range tables {
+		names[i] = table.Name
+	}
+	assert.Equal(t, []string{"order_items", "orders", "users", "products"}, names)
+	items := tables[0]
+	assert.Equal(t, []string{"order_id", "product_code"}, items.PrimaryKey)
+	assert.Equal(t, []SQLForeignKey{{Columns: []string{"product_code"}, Table: "products", References: []string{"code"}},
+		{Columns: []string{"order_id"}, Table: "orders", References: []string{"id"}}}, items.ForeignKeys)
+	assert.Equal(t, "DECIMAL", items.Columns[3].Type)
+	assert.Equal(t, []string{"8", "2"}, items.Columns[3].Arguments)
+	assert.True(t, items.Columns[4].Computed)
+	orders := tables[1]
+	assert.True(t, orders.Columns[0].AutoIncrement)
+	assert.Equal(t, []string{"new", "paid", "it's shipped"}, orders.Columns[2].Arguments)
+	assert.Equal(t, "TIMESTAMP WITH TIME ZONE", orders.Columns[3].Type)
+	assert.Equal(t, []string{"id"}, orders.ForeignKeys[0].References)
+	users := tables[2]
+	assert.Equal(t, [][]string{{"email"}}, users.Unique)
+	assert.True(t, users.Columns[1].NotNull)
+	assert.False(t, users.Columns[4].NotNull)
+	assert.Equal(t, "DOUBLE PRECISION", tables[3].Columns[3].Type)
+	assert.Equal(t, []string{"code"}, tables[3].PrimaryKey)
+	for _, ddl := range []string{
+		"CREATE TABLE a (id INT REFERENCES b (id));",
+		"CREATE TABLE a (id INT, PRIMARY KEY (missing));",
+		"CREATE TABLE a (id INT); CREATE TABLE a (id INT);",
+		"CREATE TABLE a (id INT, name 'text');",
+		"CREATE TABLE a (id INT) /* unclosed",
+		"CREATE TABLE a (id INT PRIMARY KEY); CREATE TABLE b (a_id INT, x INT, FOREIGN KEY (a_id, x) REFERENCES a);",
+	} {
+		_, err := ParseSQLSchema(ddl)
+		assert.NotNil(t, err, ddl)
+	}
+}
+
+func TestSortSQLTables(t *testing.T) {
+	tables, _ := ParseSQLSchema(testSQLSchema)
+	sorted, err := SortSQLTables(tables)
+	assert.Nil(t, err)
+	names := make([]string, len(sorted))
+	for i, table := range sorted {
+		names[i] = table.Name
+	}
+	assert.Equal(t, []string{"users", "products", "orders", "order_items"}, names)
+	cycle, _ := ParseSQLSchema(`CREATE TABLE a (id INT PRIMARY KEY, b_id INT);
+		CREATE TABLE b (id INT PRIMARY KEY, a_id INT REFERENCES a);`)
+	cycle[0].ForeignKeys = append(cycle[0].ForeignKeys, SQLForeignKey{Columns: []string{"b_id"}, Table: "b",
+		References: []string{"id"}})
+	_, err = SortSQLTables(cycle)
+	assert.NotNil(t, err)
+}
+
+// Returns the values of the column in the rows
+func sqlColumnValues(table SQLTable, rows [][]string, name string) []string {
+	values := make([]string, len(rows))
+	for i, row := range rows {
+		values[i] = row[table.columnIndex(name)]
+	}
+	return values
+}
+
+func TestSeedSQL(t *testing.T) {
+	tables, _ := ParseSQLSchema(testSQLSchema)
+	options := DefaultSQLSeedOptions
+	options.Rows = 30
+	options.TableRows = map[string]int{"PRODUCTS": 8, "order_items": 60}
+	for test := 0; test < 20; test++ {
+		seed, err := NewGenerator(int64(test)).SeedSQL(tables, options)
+		assert.Nil(t, err)
+		users := seed.Tables[0]
+		assert.Len(t, seed.Rows["users"], 30)
+		assert.Len(t, seed.Rows["products"], 8)
+		assert.Len(t, seed.Rows["order_items"], 60)
+		ids := make(map[string]bool)
+		emails := make(map[string]bool)
+		for i, row := range seed.Rows["users"] {
+			assert.Equal(t, strconv.Itoa(i+1), row[0])
+			ids[row[0]] = true
+			assert.Regexp(t, `^'[^@']+@[^@']+'$`, row[1])
+			assert.False(t, emails[row[1]])
+			emails[row[1]] = true
+			assert.True(t, len([]rune(row[3])) <= 7, row[3])
+			if row[4] != "NULL" {
+				assert.Regexp(t, `^'\d{10}'$`, row[4])
+			}
+			if manager := row[5]; manager != "NULL" {
+				number, _ := strconv.Atoi(manager)
+				assert.True(t, number >= 1 && number <= i, manager)
+			}
+			assert.Contains(t, []string{"TRUE", "FALSE"}, row[6])
+			if row[7] != "NULL" {
+				assert.Regexp(t, `^'\d{4}-\d{2}-\d{2}'$`, row[7])
+			}
+		}
+		codes := make(map[string]bool)
+		for _, code := range sqlColumnValues(seed.Tables[1], seed.Rows["products"], "code") {
+			assert.False(t, codes[code])
+			codes[code] = true
+		}
+		for _, row := range seed.Rows["orders"] {
+			assert.True(t, row[1] == "NULL" || ids[row[1]], row[1])
+			assert.Contains(t, []string{"'new'", "'paid'", "'it''s shipped'"}, row[2])
+		}
+		keys := make(map[string]bool)
+		for _, row := range seed.Rows["order_items"] {
+			number, _ := strconv.Atoi(row[0])
+			assert.True(t, number >= 1 && number <= 30)
+			assert.True(t, codes[row[1]], row[1])
+			assert.False(t, keys[row[0]+row[1]])
+			keys[row[0]+row[1]] = true
+			assert.NotEqual(t, "NULL", row[2])
+			assert.Equal(t, "", row[4])
+		}
+		assert.Equal(t, "users", users.Name)
+	}
+	first, _ := NewGenerator(5).SeedSQL(tables, options)
+	second, _ := NewGenerator(5).SeedSQL(tables, options)
+	assert.Equal(t, first, second)
+}
+
+func TestSeedSQLErrors(t *testing.T) {
+	tables, _ := ParseSQLSchema(`CREATE TABLE flags (on_off BOOLEAN PRIMARY KEY);
+		CREATE TABLE people (id INT PRIMARY KEY); CREATE TABLE passports (person_id INT UNIQUE REFERENCES people);`)
+	g := NewGenerator(1)
+	_, err := g.SeedSQL(tables[:1], SQLSeedOptions{Rows: 3, Locale: "es_CO"})
+	assert.NotNil(t, err)
+	_, err = g.SeedSQL(tables[:1], SQLSeedOptions{Rows: 2, Locale: "es_CO"})
+	assert.Nil(t, err)
+	_, err = g.SeedSQL(tables[1:], SQLSeedOptions{Rows: 3, TableRows: map[string]int{"passports": 4}, Locale: "es_CO"})
+	assert.NotNil(t, err)
+	seed, err := g.SeedSQL(tables[1:], SQLSeedOptions{Rows: 3, Locale: "es_CO"})
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []string{"1", "2", "3"}, sqlColumnValues(seed.Tables[1], seed.Rows["passports"], "person_id"))
+	_, err = g.SeedSQL(tables, SQLSeedOptions{Rows: 1, Locale: "xx_XX"})
+	assert.NotNil(t, err)
+	_, err = g.SeedSQL(tables, SQLSeedOptions{Rows: -1, Locale: "es_CO"})
+	assert.NotNil(t, err)
+	_, err = g.SeedSQL(tables, SQLSeedOptions{Rows: 1, NullProbability: 2, Locale: "es_CO"})
+	assert.NotNil(t, err)
+	for _, ddl := range []string{"CREATE TABLE a (price NUMERIC(p, 2) NOT NULL);",
+		"CREATE TABLE a (price NUMERIC(8, s) NOT NULL);", "CREATE TABLE a (price NUMERIC(0) NOT NULL);"} {
+		tables, err := ParseSQLSchema(ddl)
+		assert.Nil(t, err, ddl)
+		_, err = g.SeedSQL(tables, SQLSeedOptions{Rows: 1, Locale: "es_CO"})
+		assert.NotNil(t, err, ddl)
+	}
+}
+
+func TestSeedSQLDecimals(t *testing.T) {
+	tables, err := ParseSQLSchema(`CREATE TABLE amounts (small NUMERIC(2, 4) NOT NULL, price DECIMAL(8, 2) NOT NULL,
+		whole NUMERIC(3) NOT NULL, plain DECIMAL NOT NULL);`)
+	assert.Nil(t, err)
+	seed, err := NewGenerator(3).SeedSQL(tables, SQLSeedOptions{Rows: 50, Locale: "es_CO"})
+	assert.Nil(t, err)
+	for _, row := range seed.Rows["amounts"] {
+		assert.Regexp(t, `^0\.00\d{2}$`, row[0])
+		assert.Regexp(t, `^\d{1,6}\.\d{2}$`, row[1])
+		assert.Regexp(t, `^\d{1,3}$`, row[2])
+		assert.Regexp(t, `^\d{1,6}\.\d{2}$`, row[3])
+	}
+}
+
+func TestSeedSQLTypes(t *testing.T) {
+	for sqlType, kind := range map[string]string{"INT": "integer", "INT8": "integer", "BIGSERIAL": "integer",
+		"INTERVAL": "interval", "POINT": "point", "MULTIPOINT": "multipoint", "PRINTABLE": "string"} {
+		assert.Equal(t, kind, sqlTypeKind(sqlType), sqlType)
+	}
+	tables, err := ParseSQLSchema(`CREATE TABLE places (id INT2 PRIMARY KEY, stay INTERVAL NOT NULL,
+		location POINT NOT NULL, stops MULTIPOINT NOT NULL);`)
+	assert.Nil(t, err)
+	seed, err := NewGenerator(6).SeedSQL(tables, SQLSeedOptions{Rows: 30, Locale: "es_CO"})
+	assert.Nil(t, err)
+	coordinate := `-?\d+(\.\d+)?`
+	for _, row := range seed.Rows["places"] {
+		assert.Regexp(t, `^'\d+ days \d{2}:\d{2}:\d{2}'$`, row[1])
+		assert.Regexp(t, `^'\(`+coordinate+`,`+coordinate+`\)'$`, row[2])
+		point := coordinate + ` ` + coordinate
+		assert.Regexp(t, `^'MULTIPOINT\(`+point+`(, `+point+`)*\)'$`, row[3])
+	}
+}
+
+func TestSQLTextGuesses(t *testing.T) {
+	g := NewGenerator(4)
+	locale, _ := GetLocale("es_CO")
+	text := func(name string) string {
+		return g.sqlText(SQLColumn{Name: name, Type: "VARCHAR", Arguments: []string{"40"}}, locale)
+	}
+	for test := 0; test < 20; test++ {
+		for _, name := range []string{"hotel", "hotel_name", "HotelName"} {
+			assert.NotRegexp(t, `^'\d{10}'$`, text(name), name)
+		}
+		for _, name := range []string{"tel", "home_tel", "phone_number", "customerPhone", "phones"} {
+			assert.Regexp(t, `^'\d{10}'$`, text(name), name)
+		}
+		assert.Contains(t, text("contactEmail"), "@")
+		assert.Contains(t, text("e_mail"), "@")
+		assert.Len(t, strings.Split(strings.Trim(text("hotel_name"), "'"), " "), 2)
+	}
+}
+
+func TestWriteInserts(t *testing.T) {
+	tables, _ := ParseSQLSchema(`CREATE TABLE [user list] (id INT PRIMARY KEY, "Full Name" VARCHAR(20) NOT NULL,
+		doubled INT AS (id * 2));`)
+	seed, err := NewGenerator(2).SeedSQL(tables, SQLSeedOptions{Rows: 5, Locale: "es_CO"})
+	assert.Nil(t, err)
+	var buffer bytes.Buffer
+	assert.Nil(t, seed.WriteInserts(&buffer, 2))
+	lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+	assert.Equal(t, "-- user list: 5 rows", lines[0])
+	assert.Equal(t, `INSERT INTO [user list] (id, "Full Name") VALUES`, lines[1])
+	assert.Equal(t, 3, strings.Count(buffer.String(), "INSERT INTO"))
+	assert.Regexp(t, `^  \(1, '[^']+'\),$`, lines[2])
+	assert.Regexp(t, `^  \(2, '[^']+'\);$`, lines[3])
+	assert.Regexp(t, `^  \(5, '[^']+'\);$`, lines[len(lines)-1])
+	assert.NotNil(t, seed.WriteInserts(&buffer, 0))
+}
+
+func TestLoadSQLSchema(t *testing.T) {
+	directory, err := ioutil.TempDir("", "sql")
+	assert.Nil(t, err)
+	defer os.RemoveAll(directory)
+	path := filepath.Join(directory, "schema.sql")
+	assert.Nil(t, ioutil.WriteFile(path, []byte(testSQLSchema), 0644))
+	tables, err := LoadSQLSchema(path)
+	assert.Nil(t, err)
+	assert.Len(t, tables, 4)
+	_, err = LoadSQLSchema(filepath.Join(directory, "missing.sql"))
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,213 @@
+package randgen
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"reflect"
+	"text/template"
+	"time"
+)
+
+// Locale and language of the template functions called without them
+const (
+	defaultTemplateLocale   = "es_CO"
+	defaultTemplateLanguage = "la"
+)
+
+// Returns the optional last argument of a template function, or the default value
+func optionalTemplateArgument(arguments []string, defaultValue string) (string, error) {
+	switch len(arguments) {
+	case 0:
+		return defaultValue, nil
+	case 1:
+		return arguments[0], nil
+	}
+	return "", fmt.Errorf("error, too many arguments %v", arguments)
+}
+
+// Returns the registered locale of the optional code, es_CO by default
+func templateLocale(codes []string) (Locale, error) {
+	code, err := optionalTemplateArgument(codes, defaultTemplateLocale)
+	if err != nil {
+		return Locale{}, err
+	}
+	return GetLocale(code)
+}
+
+// Returns a time between the dates, written like 2006-01-02
+func (g *Generator) templateTime(from, to string) (time.Time, error) {
+	bounds := make([]time.Time, 2)
+	for i, text := range []string{from, to} {
+		date, err := time.Parse("2006-01-02", text)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("error, %q is not a date like 2006-01-02", text)
+		}
+		bounds[i] = date
+	}
+	if bounds[1].Before(bounds[0]) {
+		return time.Time{}, fmt.Errorf("error, the date %s is before %s", to, from)
+	}
+	return bounds[0].Add(time.Duration(g.Int64(0, int64(bounds[1].Sub(bounds[0])/time.Second))) * time.Second), nil
+}
+
+// Returns the functions of the templates of RenderTemplate, every random one draws from the generator so
+// a seeded generator renders the same documents:
+//
+//	randInt min max, randFloat min max, randBool, randString min max [alphabet], randRegex pattern
+//	randEmail, randPhone, randAddressCOL, uuid, choose a b c... (or choose of a slice)
+//	randFirstName [locale], randLastName [locale], randFullName [locale], randCompany [locale], randCity [locale]
+//	randWord [language], randSentence min max [language], randParagraph min max [language]
+//	randDate from to, randTimestamp from to, with dates like 2006-01-02
+//	seq n, the numbers 0 to n-1 to range over, and json value, the value as JSON
+func (g *Generator) TemplateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"randInt": func(minValue, maxValue int) (int, error) {
+			if maxValue < minValue {
+				return 0, fmt.Errorf("error, invalid arguments in randInt(%d,%d)", minValue, maxValue)
+			}
+			return minValue + int(g.Int64(0, int64(maxValue)-int64(minValue))), nil
+		},
+		"randFloat": func(minValue, maxValue float64) (float64, error) {
+			if maxValue <= minValue {
+				return 0, fmt.Errorf("error, invalid arguments in randFloat(%v,%v)", minValue, maxValue)
+			}
+			return g.Uniform(minValue, maxValue), nil
+		},
+		"randBool": func() bool {
+			return g.Bernoulli(0.5)
+		},
+		"randString": func(minLength, maxLength int, alphabets ...string) (string, error) {
+			alphabet, err := optionalTemplateArgument(alphabets, alphaDigits)
+			if err != nil {
+				return "", err
+			}
+			if minLength < 0 || maxLength < minLength || alphabet == "" {
+				return "", fmt.Errorf("error, invalid arguments in randString(%d,%d,%q)", minLength, maxLength, alphabet)
+			}
+			return g.String(minLength, maxLength, alphabet), nil
+		},
+		"randRegex": func(pattern string) (string, error) {
+			return g.FromRegex(pattern, RegexOptions{})
+		},
+		"randEmail":      g.Email,
+		"randPhone":      g.PhoneNumber,
+		"randAddressCOL": g.ColombianAddress,
+		"uuid":           g.UUIDv4,
+		"choose": func(elements ...interface{}) (interface{}, error) {
+			if len(elements) == 1 {
+				if value := reflect.ValueOf(elements[0]); value.Kind() == reflect.Slice || value.Kind() == reflect.Array {
+					if value.Len() == 0 {
+						return nil, fmt.Errorf("error, choose of an empty list")
+					}
+					return value.Index(g.Int(0, value.Len()-1)).Interface(), nil
+				}
+			}
+			if len(elements) == 0 {
+				return nil, fmt.Errorf("error, choose needs at least one element")
+			}
+			return elements[g.Int(0, len(elements)-1)], nil
+		},
+		"randFirstName": func(codes ...string) (string, error) {
+			locale, err := templateLocale(codes)
+			return locale.firstName(g, GenderAny), err
+		},
+		"randLastName": func(codes ...string) (string, error) {
+			locale, err := templateLocale(codes)
+			return locale.lastName(g), err
+		},
+		"randFullName": func(codes ...string) (string, error) {
+			locale, err := templateLocale(codes)
+			return locale.firstName(g, GenderAny) + " " + locale.lastName(g), err
+		},
+		"randCompany": func(codes ...string) (string, error) {
+			locale, err := templateLocale(codes)
+			if err != nil {
+				return "", err
+			}
+			name, _ := g.ChooseString(locale.LastNames)
+			suffix, _ := g.ChooseString(locale.CompanySuffixes)
+			return name + " " + suffix, nil
+		},
+		"randCity": func(codes ...string) (string, error) {
+			locale, err := templateLocale(codes)
+			if err != nil {
+				return "", err
+			}
+			return locale.Cities[g.Int(0, len(locale.Cities)-1)].Name, nil
+		},
+		"randWord": func(languages ...string) (string, error) {
+			language, err := optionalTemplateArgument(languages, defaultTemplateLanguage)
+			if err != nil {
+				return "", err
+			}
+			return g.Word(language)
+		},
+		"randSentence": func(minWords, maxWords int, languages ...string) (string, error) {
+			language, err := optionalTemplateArgument(languages, defaultTemplateLanguage)
+			if err != nil {
+				return "", err
+			}
+			return g.Sentence(minWords, maxWords, language)
+		},
+		"randParagraph": func(minSentences, maxSentences int, languages ...string) (string, error) {
+			language, err := optionalTemplateArgument(languages, defaultTemplateLanguage)
+			if err != nil {
+				return "", err
+			}
+			return g.Paragraph(minSentences, maxSentences, language)
+		},
+		"randDate": func(from, to string) (string, error) {
+			moment, err := g.templateTime(from, to)
+			return moment.Format("2006-01-02"), err
+		},
+		"randTimestamp": func(from, to string) (string, error) {
+			moment, err := g.templateTime(from, to)
+			return moment.Format(time.RFC3339), err
+		},
+		"seq": func(count int) ([]int, error) {
+			if count < 0 {
+				return nil, fmt.Errorf("error, invalid arguments in seq(%d)", count)
+			}
+			numbers := make([]int, count)
+			for i := range numbers {
+				numbers[i] = i
+			}
+			return numbers, nil
+		},
+		"json": func(value interface{}) (string, error) {
+			encoded, err := json.Marshal(value)
+			return string(encoded), err
+		},
+	}
+}
+
+// Returns the text/template of the text with the functions of TemplateFuncs bound to the generator
+func (g *Generator) ParseTemplate(name, text string) (*template.Template, error) {
+	return template.New(name).Funcs(g.TemplateFuncs()).Option("missingkey=error").Parse(text)
+}
+
+// Renders the text/template with the data, its functions draw from the generator. Every rendering with
+// the same generator gives other values, and a generator with the same seed gives the same documents.
+func (g *Generator) RenderTemplate(w io.Writer, text string, data interface{}) error {
+	parsed, err := g.ParseTemplate("template", text)
+	if err != nil {
+		return err
+	}
+	return parsed.Execute(w, data)
+}
+
+// Renders the text/template with the data, its functions are the ones of Generator.TemplateFuncs
+func RenderTemplate(w io.Writer, text string, data interface{}) error {
+	return defaultGenerator.RenderTemplate(w, text, data)
+}
+
+// Renders the text/template file with the data, its functions draw from the generator
+func (g *Generator) RenderTemplateFile(w io.Writer, path string, data interface{}) error {
+	text, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return g.RenderTemplate(w, string(text), data)
+}
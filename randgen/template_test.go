@@ -0,0 +1,105 @@
+package randgen
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// A template of a JSON request body with a loop of random length
+const testTemplate = `{"users": [{{range $i := seq (randInt 1 4)}}{{if $i}}, {{end}}{
+  "id": {{randInt -5 5}},
+  "uuid": "{{uuid}}",
+  "name": {{json randFullName}},
+  "email": "{{randEmail}}",
+  "phone": "{{randPhone}}",
+  "address": {{json (randAddressCOL).FreeForm}},
+  "score": {{printf "%.2f" (randFloat 0 10)}},
+  "active": {{randBool}},
+  "role": "{{choose "admin" "user" "guest"}}",
+  "team": "{{choose $.teams}}",
+  "code": "{{randString 3 3 "XYZ"}}",
+  "plate": "{{randRegex "[A-Z]{3}[0-9]{3}"}}",
+  "since": "{{randDate "2020-01-01" "2020-12-31"}}",
+  "seen": "{{randTimestamp "2021-01-01" "2021-01-02"}}",
+  "bio": {{json (randSentence 3 6 "en")}},
+  "city": {{json (randCity "en_US")}}
+}{{end}}]}`
+
+func TestRenderTemplate(t *testing.T) {
+	data := map[string]interface{}{"teams": []string{"red", "blue"}}
+	for seed := int64(0); seed < 30; seed++ {
+		var buffer bytes.Buffer
+		assert.Nil(t, NewGenerator(seed).RenderTemplate(&buffer, testTemplate, data))
+		var document struct {
+			Users []map[string]interface{}
+		}
+		if !assert.Nil(t, json.Unmarshal(buffer.Bytes(), &document), buffer.String()) {
+			continue
+		}
+		assert.True(t, len(document.Users) >= 1 && len(document.Users) <= 4)
+		for _, user := range document.Users {
+			id := user["id"].(float64)
+			assert.True(t, id >= -5 && id <= 5)
+			assert.Regexp(t, `^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`, user["uuid"])
+			assert.Contains(t, user["email"], "@")
+			assert.Regexp(t, `^\d{10}$`, user["phone"])
+			assert.Contains(t, user["address"], " # ")
+			assert.True(t, user["score"].(float64) >= 0 && user["score"].(float64) <= 10)
+			assert.Contains(t, []string{"admin", "user", "guest"}, user["role"])
+			assert.Contains(t, []string{"red", "blue"}, user["team"])
+			assert.Regexp(t, `^[XYZ]{3}$`, user["code"])
+			assert.Regexp(t, `^[A-Z]{3}[0-9]{3}$`, user["plate"])
+			assert.Regexp(t, `^2020-\d{2}-\d{2}$`, user["since"])
+			assert.Regexp(t, `^2021-01-0[12]T`, user["seen"])
+			assert.Regexp(t, `^[A-Z].*\.$`, user["bio"])
+			assert.NotEmpty(t, user["city"])
+		}
+		var again bytes.Buffer
+		assert.Nil(t, NewGenerator(seed).RenderTemplate(&again, testTemplate, data))
+		assert.Equal(t, buffer.String(), again.String())
+	}
+	var first, second bytes.Buffer
+	g := NewGenerator(4)
+	assert.Nil(t, g.RenderTemplate(&first, "{{randString 20 20}}", nil))
+	assert.Nil(t, g.RenderTemplate(&second, "{{randString 20 20}}", nil))
+	assert.NotEqual(t, first.String(), second.String())
+	var accented bytes.Buffer
+	assert.Nil(t, g.RenderTemplate(&accented, `{{randString 5 5 "áéíóúñ"}}`, nil))
+	assert.Regexp(t, `^[áéíóúñ]{5}$`, accented.String())
+	var buffer bytes.Buffer
+	assert.Nil(t, RenderTemplate(&buffer, "{{range seq 3}}{{randWord}} {{end}}", nil))
+	assert.Len(t, strings.Fields(buffer.String()), 3)
+}
+
+func TestRenderTemplateErrors(t *testing.T) {
+	g := NewGenerator(1)
+	for _, text := range []string{
+		"{{randInt 5 1}}", "{{randFloat 1 1}}", "{{randString 2 1}}", "{{randString 1 2 \"\"}}", "{{choose}}",
+		"{{choose .empty}}", "{{randRegex \"[\"}}", "{{randFirstName \"xx_XX\"}}", "{{randWord \"xx\"}}",
+		"{{randWord \"en\" \"es\"}}", "{{randSentence 0 1}}", "{{randDate \"2020-02-01\" \"2020-01-01\"}}",
+		"{{randDate \"yesterday\" \"2020-01-01\"}}", "{{seq -1}}", "{{.missing}}", "{{unknown}}", "{{",
+	} {
+		var buffer bytes.Buffer
+		err := g.RenderTemplate(&buffer, text, map[string]interface{}{"empty": []string{}})
+		assert.NotNil(t, err, text)
+	}
+}
+
+func TestRenderTemplateFile(t *testing.T) {
+	directory, err := ioutil.TempDir("", "template")
+	assert.Nil(t, err)
+	defer os.RemoveAll(directory)
+	path := filepath.Join(directory, "config.tmpl")
+	assert.Nil(t, ioutil.WriteFile(path, []byte("name: {{.service}}-{{randInt 1 9}}\n"), 0644))
+	var buffer bytes.Buffer
+	assert.Nil(t, NewGenerator(1).RenderTemplateFile(&buffer, path, map[string]string{"service": "api"}))
+	assert.Regexp(t, `^name: api-[1-9]\n$`, buffer.String())
+	assert.NotNil(t, NewGenerator(1).RenderTemplateFile(&buffer, filepath.Join(directory, "missing"), nil))
+}
@@ -0,0 +1,299 @@
+package randgen
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Word lists by language code: "la" for the lorem ipsum Latin, "es" for Spanish and "en" for English
+var wordLists = map[string][]string{
+	"la": loremWords,
+	"es": spanishWords,
+	"en": englishWords,
+}
+
+// Returns the language codes with a word list, sorted
+func TextLanguages() []string {
+	languages := make([]string, 0, len(wordLists))
+	for language := range wordLists {
+		languages = append(languages, language)
+	}
+	sort.Strings(languages)
+	return languages
+}
+
+// Returns the word list of the language
+func wordListOf(language string) ([]string, error) {
+	words, ok := wordLists[language]
+	if !ok {
+		return nil, fmt.Errorf("error, there is no word list for language %s", language)
+	}
+	return words, nil
+}
+
+// Returns the text with its first letter in upper case
+func capitalize(text string) string {
+	first, size := utf8.DecodeRuneInString(text)
+	if first == utf8.RuneError {
+		return text
+	}
+	return string(unicode.ToUpper(first)) + text[size:]
+}
+
+// Returns a pseudo random word of the language, "la", "es" or "en"
+func RandomWord(language string) (string, error) {
+	return defaultGenerator.Word(language)
+}
+
+// Returns a pseudo random word of the language drawn from the generator, same as RandomWord
+func (g *Generator) Word(language string) (string, error) {
+	words, err := wordListOf(language)
+	if err != nil {
+		return "", err
+	}
+	return words[g.Int(0, len(words)-1)], nil
+}
+
+// Returns 'count' pseudo random words of the language, "la", "es" or "en"
+func RandomWords(count int, language string) ([]string, error) {
+	return defaultGenerator.Words(count, language)
+}
+
+// Returns 'count' pseudo random words of the language drawn from the generator, same as RandomWords
+func (g *Generator) Words(count int, language string) ([]string, error) {
+	words, err := wordListOf(language)
+	if err != nil {
+		return nil, err
+	}
+	if count < 0 {
+		return nil, fmt.Errorf("error, invalid arguments in RandomWords(count = %d, language = %s)", count, language)
+	}
+	chosen := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		chosen = append(chosen, words[g.Int(0, len(words)-1)])
+	}
+	return chosen, nil
+}
+
+// Returns a pseudo random sentence of the language with at least 'minWords' and at most 'maxWords' words.
+// It starts with an upper case letter and ends with a period, long sentences may have a comma.
+func RandomSentence(minWords, maxWords int, language string) (string, error) {
+	return defaultGenerator.Sentence(minWords, maxWords, language)
+}
+
+// Returns a pseudo random sentence of the language drawn from the generator, same as RandomSentence
+func (g *Generator) Sentence(minWords, maxWords int, language string) (string, error) {
+	if minWords < 1 || maxWords < minWords {
+		return "", fmt.Errorf("error, invalid arguments in RandomSentence(minWords = %d, maxWords = %d)", minWords, maxWords)
+	}
+	words, err := g.Words(g.Int(minWords, maxWords), language)
+	if err != nil {
+		return "", err
+	}
+	if len(words) > 6 && g.Int(0, 1) == 0 {
+		comma := g.Int(2, len(words)-3)
+		words[comma] += ","
+	}
+	return capitalize(strings.Join(words, " ")) + ".", nil
+}
+
+// Returns a pseudo random paragraph of the language with at least 'minSentences' and at most
+// 'maxSentences' sentences of 4 to 16 words
+func RandomParagraph(minSentences, maxSentences int, language string) (string, error) {
+	return defaultGenerator.Paragraph(minSentences, maxSentences, language)
+}
+
+// Returns a pseudo random paragraph of the language drawn from the generator, same as RandomParagraph
+func (g *Generator) Paragraph(minSentences, maxSentences int, language string) (string, error) {
+	if minSentences < 1 || maxSentences < minSentences {
+		return "", fmt.Errorf("error, invalid arguments in RandomParagraph(minSentences = %d, maxSentences = %d)",
+			minSentences, maxSentences)
+	}
+	count := g.Int(minSentences, maxSentences)
+	sentences := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		sentence, err := g.Sentence(4, 16, language)
+		if err != nil {
+			return "", err
+		}
+		sentences = append(sentences, sentence)
+	}
+	return strings.Join(sentences, " "), nil
+}
+
+// Returns 'count' pseudo random paragraphs of the language with 3 to 7 sentences each, separated by a
+// blank line
+func RandomParagraphs(count int, language string) (string, error) {
+	if count < 1 {
+		return "", fmt.Errorf("error, invalid arguments in RandomParagraphs(count = %d, language = %s)", count, language)
+	}
+	paragraphs := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		paragraph, err := RandomParagraph(3, 7, language)
+		if err != nil {
+			return "", err
+		}
+		paragraphs = append(paragraphs, paragraph)
+	}
+	return strings.Join(paragraphs, "\n\n"), nil
+}
+
+// Returns 'count' paragraphs of lorem ipsum, the first one starts with the classic
+// "Lorem ipsum dolor sit amet..."
+func LoremIpsum(count int) (string, error) {
+	text, err := RandomParagraphs(count, "la")
+	if err != nil {
+		return "", err
+	}
+	return loremIpsumOpening + " " + text, nil
+}
+
+// The tokens of a Markov chain: words or characters
+type MarkovUnit int
+
+const (
+	MarkovWords MarkovUnit = iota
+	MarkovCharacters
+)
+
+// A Markov chain text model trained from a corpus: the next token (word or character) depends only on
+// the previous 'order' tokens, so the generated text resembles the corpus. Train it with Train and
+// generate text with Generate or GenerateSentences.
+type MarkovChain struct {
+	order       int
+	unit        MarkovUnit
+	transitions map[string][]string // the tokens that follow a state, repeated as many times as in the corpus
+	starts      [][]string          // the states that begin a sentence
+}
+
+// Returns an untrained Markov chain of the given order (1 or more) and unit
+func NewMarkovChain(order int, unit MarkovUnit) (*MarkovChain, error) {
+	if order < 1 || (unit != MarkovWords && unit != MarkovCharacters) {
+		return nil, fmt.Errorf("error, invalid arguments in NewMarkovChain(order = %d, unit = %d)", order, unit)
+	}
+	return &MarkovChain{order: order, unit: unit, transitions: make(map[string][]string)}, nil
+}
+
+// Returns the tokens of the text, words or characters, the white space is normalized to single spaces
+func (m *MarkovChain) tokenize(text string) []string {
+	words := strings.Fields(text)
+	if m.unit == MarkovWords {
+		return words
+	}
+	tokens := make([]string, 0, len(text))
+	for _, char := range strings.Join(words, " ") {
+		tokens = append(tokens, string(char))
+	}
+	return tokens
+}
+
+// Returns true if the token closes a sentence
+func (m *MarkovChain) endsSentence(token string) bool {
+	return strings.HasSuffix(token, ".") || strings.HasSuffix(token, "!") || strings.HasSuffix(token, "?")
+}
+
+// Returns true if a sentence starts at the token in position i
+func (m *MarkovChain) startsSentence(tokens []string, i int) bool {
+	if i == 0 {
+		return true
+	}
+	if m.unit == MarkovWords {
+		return m.endsSentence(tokens[i-1])
+	}
+	return i >= 2 && tokens[i-1] == " " && m.endsSentence(tokens[i-2])
+}
+
+// Adds the transitions of the corpus to the model, it can be called several times with different texts
+func (m *MarkovChain) Train(corpus string) error {
+	tokens := m.tokenize(corpus)
+	if len(tokens) <= m.order {
+		return fmt.Errorf("error, the corpus needs more than %d tokens to train a Markov chain of order %d", m.order, m.order)
+	}
+	for i := 0; i+m.order <= len(tokens); i++ {
+		state := tokens[i : i+m.order]
+		if m.startsSentence(tokens, i) {
+			m.starts = append(m.starts, append([]string(nil), state...))
+		}
+		if i+m.order < len(tokens) {
+			key := strings.Join(state, "\x00")
+			m.transitions[key] = append(m.transitions[key], tokens[i+m.order])
+		}
+	}
+	return nil
+}
+
+// Returns the tokens joined as text, with spaces between words
+func (m *MarkovChain) join(tokens []string) string {
+	if m.unit == MarkovWords {
+		return strings.Join(tokens, " ")
+	}
+	return strings.Join(tokens, "")
+}
+
+// Walks the chain from a random start until 'done' returns true or there are 'limit' tokens, when the
+// walk reaches a state without transitions it continues from another random start
+func (m *MarkovChain) walk(limit int, done func(tokens []string) bool) ([]string, error) {
+	if len(m.starts) == 0 {
+		return nil, fmt.Errorf("error, the Markov chain is not trained")
+	}
+	tokens := make([]string, 0, limit)
+	for len(tokens) < limit && !done(tokens) {
+		if len(tokens) < m.order {
+			if len(tokens) > 0 && m.unit == MarkovCharacters {
+				tokens = append(tokens, " ")
+			}
+			tokens = append(tokens, m.starts[RandomInt(0, len(m.starts)-1)]...)
+			continue
+		}
+		next := m.transitions[strings.Join(tokens[len(tokens)-m.order:], "\x00")]
+		if len(next) == 0 {
+			if m.unit == MarkovCharacters {
+				tokens = append(tokens, " ")
+			}
+			tokens = append(tokens, m.starts[RandomInt(0, len(m.starts)-1)]...)
+			continue
+		}
+		tokens = append(tokens, next[RandomInt(0, len(next)-1)])
+	}
+	if len(tokens) > limit {
+		tokens = tokens[:limit]
+	}
+	return tokens, nil
+}
+
+// Returns a pseudo random text of 'length' tokens (words or characters) that resembles the corpus
+func (m *MarkovChain) Generate(length int) (string, error) {
+	if length < 1 {
+		return "", fmt.Errorf("error, invalid arguments in MarkovChain.Generate(length = %d)", length)
+	}
+	tokens, err := m.walk(length, func(tokens []string) bool { return false })
+	if err != nil {
+		return "", err
+	}
+	return m.join(tokens), nil
+}
+
+// Returns a pseudo random text of 'count' sentences that resembles the corpus, at most 'maxTokens' tokens
+// are generated in case the corpus has no sentence endings
+func (m *MarkovChain) GenerateSentences(count, maxTokens int) (string, error) {
+	if count < 1 || maxTokens < 1 {
+		return "", fmt.Errorf("error, invalid arguments in MarkovChain.GenerateSentences(count = %d, maxTokens = %d)",
+			count, maxTokens)
+	}
+	sentences, counted := 0, 0
+	tokens, err := m.walk(maxTokens, func(tokens []string) bool {
+		for ; counted < len(tokens); counted++ {
+			if m.endsSentence(tokens[counted]) {
+				sentences++
+			}
+		}
+		return sentences >= count
+	})
+	if err != nil {
+		return "", err
+	}
+	return m.join(tokens), nil
+}
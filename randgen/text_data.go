@@ -0,0 +1,55 @@
+package randgen
+
+// The classic opening of the lorem ipsum text
+const loremIpsumOpening = "Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua."
+
+// Words of the lorem ipsum text, from Cicero's De finibus bonorum et malorum
+var loremWords = []string{
+	"a", "ac", "accumsan", "ad", "adipiscing", "aenean", "aliqua", "aliquam", "aliquet", "aliquip", "amet",
+	"anim", "ante", "arcu", "at", "auctor", "augue", "aute", "bibendum", "blandit", "cillum", "commodo",
+	"condimentum", "congue", "consectetur", "consequat", "convallis", "cras", "culpa", "cupidatat", "curabitur",
+	"cursus", "dapibus", "deserunt", "diam", "dictum", "dignissim", "do", "dolor", "dolore", "donec", "dui",
+	"duis", "egestas", "eget", "eiusmod", "eleifend", "elementum", "elit", "enim", "erat", "eros", "esse",
+	"est", "et", "etiam", "eu", "euismod", "ex", "excepteur", "exercitation", "facilisis", "fames", "faucibus",
+	"felis", "fermentum", "feugiat", "fringilla", "fugiat", "fusce", "gravida", "habitant", "hendrerit",
+	"iaculis", "id", "imperdiet", "in", "incididunt", "integer", "interdum", "ipsum", "irure", "justo",
+	"labore", "laboris", "lacinia", "lacus", "laoreet", "lectus", "leo", "libero", "ligula", "lobortis",
+	"lorem", "luctus", "maecenas", "magna", "malesuada", "massa", "mattis", "mauris", "metus", "mi",
+	"minim", "molestie", "mollit", "morbi", "nam", "nec", "neque", "netus", "nibh", "nisi", "nisl", "non",
+	"nostrud", "nulla", "nullam", "nunc", "occaecat", "odio", "officia", "orci", "ornare", "pariatur",
+	"pellentesque", "pharetra", "placerat", "porta", "porttitor", "posuere", "praesent", "pretium", "proident",
+	"pulvinar", "purus", "quam", "qui", "quis", "quisque", "reprehenderit", "rhoncus", "risus", "rutrum",
+	"sagittis", "sapien", "scelerisque", "sed", "sem", "semper", "senectus", "sint", "sit", "sollicitudin",
+	"sunt", "suscipit", "suspendisse", "tellus", "tempor", "tempus", "tincidunt", "tortor", "tristique",
+	"turpis", "ullamco", "ullamcorper", "ultrices", "ultricies", "urna", "ut", "varius", "vehicula", "velit",
+	"venenatis", "veniam", "vestibulum", "vitae", "vivamus", "viverra", "voluptate", "volutpat", "vulputate",
+}
+
+// Common Spanish words
+var spanishWords = []string{
+	"agua", "ahora", "algo", "alto", "amigo", "año", "antes", "aquí", "árbol", "así", "bajo", "bien",
+	"bueno", "cada", "calle", "cambio", "camino", "casa", "caso", "cerca", "ciudad", "claro", "color",
+	"como", "con", "cosa", "cuando", "cuerpo", "de", "decir", "desde", "día", "donde", "el", "ella",
+	"en", "entre", "esta", "este", "familia", "forma", "fuerte", "gente", "gracias", "grande", "gusto",
+	"hacer", "hasta", "historia", "hoy", "idea", "igual", "joven", "la", "largo", "libro", "los", "lugar",
+	"luz", "madre", "mañana", "mano", "mar", "más", "mejor", "mesa", "mismo", "momento", "mucho", "mujer",
+	"mundo", "muy", "noche", "nombre", "nuevo", "nunca", "otro", "padre", "país", "palabra", "para",
+	"parte", "pequeño", "poco", "poder", "por", "pregunta", "primero", "pronto", "pueblo", "que", "querer",
+	"rápido", "respuesta", "río", "saber", "salir", "siempre", "sin", "sobre", "sol", "tarde", "también",
+	"tiempo", "tierra", "todo", "trabajo", "un", "una", "valor", "ver", "verdad", "vez", "vida", "y", "ya",
+}
+
+// Common English words
+var englishWords = []string{
+	"a", "about", "after", "again", "air", "all", "also", "always", "and", "answer", "around", "away",
+	"back", "because", "before", "best", "big", "book", "both", "but", "by", "can", "change", "city",
+	"close", "come", "could", "day", "different", "does", "each", "early", "earth", "end", "even", "every",
+	"family", "far", "few", "find", "first", "for", "found", "from", "give", "good", "great", "group",
+	"hand", "hard", "has", "have", "help", "here", "high", "home", "house", "idea", "important", "in",
+	"into", "just", "keep", "kind", "know", "large", "last", "learn", "light", "line", "little", "long",
+	"look", "make", "many", "more", "most", "move", "much", "name", "near", "never", "new", "next",
+	"night", "number", "often", "old", "only", "open", "other", "over", "own", "part", "people", "place",
+	"point", "right", "river", "same", "say", "school", "see", "should", "small", "some", "start", "still",
+	"story", "take", "the", "thing", "think", "through", "time", "together", "under", "until", "very",
+	"water", "way", "well", "while", "with", "word", "work", "world", "year", "young",
+}
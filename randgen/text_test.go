@@ -0,0 +1,118 @@
+package randgen
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRandomWords(t *testing.T) {
+	for _, language := range TextLanguages() {
+		words, err := RandomWords(50, language)
+		assert.Nil(t, err)
+		assert.Len(t, words, 50)
+		for _, word := range words {
+			assert.Contains(t, wordLists[language], word)
+		}
+	}
+	_, err := RandomWord("xx")
+	assert.NotNil(t, err)
+	_, err = RandomWords(-1, "en")
+	assert.NotNil(t, err)
+}
+
+func TestRandomSentence(t *testing.T) {
+	for test := 0; test < 1000; test++ {
+		sentence, err := RandomSentence(3, 10, "es")
+		assert.Nil(t, err)
+		assert.True(t, strings.HasSuffix(sentence, "."))
+		first, _ := utf8.DecodeRuneInString(sentence)
+		assert.Equal(t, strings.ToUpper(string(first)), string(first))
+		words := len(strings.Fields(sentence))
+		assert.GreaterOrEqual(t, words, 3)
+		assert.LessOrEqual(t, words, 10)
+	}
+	_, err := RandomSentence(0, 3, "en")
+	assert.NotNil(t, err)
+}
+
+func TestRandomParagraphs(t *testing.T) {
+	text, err := RandomParagraphs(3, "en")
+	assert.Nil(t, err)
+	assert.Len(t, strings.Split(text, "\n\n"), 3)
+	paragraph, err := RandomParagraph(2, 2, "la")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, strings.Count(paragraph, "."))
+	lorem, err := LoremIpsum(2)
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(lorem, "Lorem ipsum dolor sit amet"))
+	_, err = RandomParagraphs(0, "en")
+	assert.NotNil(t, err)
+}
+
+const markovCorpus = `The product arrived on time and works as described. The battery lasts all day.
+I would buy the product again. The seller answered my questions quickly! Works great with my phone.`
+
+func TestMarkovChainWords(t *testing.T) {
+	chain, err := NewMarkovChain(1, MarkovWords)
+	assert.Nil(t, err)
+	_, err = chain.Generate(5)
+	assert.NotNil(t, err)
+	assert.Nil(t, chain.Train(markovCorpus))
+	corpusWords := strings.Fields(markovCorpus)
+	for test := 0; test < 100; test++ {
+		text, err := chain.Generate(20)
+		assert.Nil(t, err)
+		words := strings.Fields(text)
+		assert.Len(t, words, 20)
+		for i, word := range words {
+			assert.Contains(t, corpusWords, word)
+			if i > 0 && !chain.endsSentence(words[i-1]) {
+				// every pair of consecutive words in a sentence appears in the corpus
+				assert.Contains(t, strings.Join(strings.Fields(markovCorpus), " "), words[i-1]+" "+word)
+			}
+		}
+		sentences, err := chain.GenerateSentences(2, 200)
+		assert.Nil(t, err)
+		assert.True(t, chain.endsSentence(sentences))
+		assert.Contains(t, []string{"The", "I", "Works"}, strings.Fields(sentences)[0])
+	}
+	assert.NotNil(t, chain.Train("one"))
+}
+
+func TestMarkovChainCharacters(t *testing.T) {
+	chain, err := NewMarkovChain(3, MarkovCharacters)
+	assert.Nil(t, err)
+	assert.Nil(t, chain.Train(markovCorpus))
+	text, err := chain.Generate(80)
+	assert.Nil(t, err)
+	assert.Equal(t, 80, utf8.RuneCountInString(text))
+	_, err = NewMarkovChain(0, MarkovCharacters)
+	assert.NotNil(t, err)
+	_, err = chain.GenerateSentences(0, 10)
+	assert.NotNil(t, err)
+}
+
+func TestGeneratorText(t *testing.T) {
+	for seed := int64(0); seed < 20; seed++ {
+		first, second := NewGenerator(seed), NewGenerator(seed)
+		word, err := first.Word("es")
+		assert.Nil(t, err)
+		other, _ := second.Word("es")
+		assert.Equal(t, word, other)
+		sentence, err := first.Sentence(3, 9, "en")
+		assert.Nil(t, err)
+		other, _ = second.Sentence(3, 9, "en")
+		assert.Equal(t, sentence, other)
+		paragraph, err := first.Paragraph(1, 3, "la")
+		assert.Nil(t, err)
+		other, _ = second.Paragraph(1, 3, "la")
+		assert.Equal(t, paragraph, other)
+	}
+	_, err := NewGenerator(1).Words(-1, "en")
+	assert.NotNil(t, err)
+	_, err = NewGenerator(1).Paragraph(2, 1, "en")
+	assert.NotNil(t, err)
+}
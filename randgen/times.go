@@ -0,0 +1,219 @@
+package randgen
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Returns a pseudo random time in the interval [from,to), every nanosecond has the same probability.
+// The returned time is in the location of 'from'.
+func RandomTime(from, to time.Time) (time.Time, error) {
+	if !from.Before(to) {
+		return time.Time{}, fmt.Errorf("error, invalid arguments in RandomTime(from = %v, to = %v)", from, to)
+	}
+	if span := to.Sub(from); span < math.MaxInt64 {
+		return from.Add(time.Duration(RandomInt64(0, int64(span)-1))), nil
+	}
+	// the span does not fit in a time.Duration (292 years), so seconds and nanoseconds are drawn apart
+	seconds := to.Unix() - from.Unix()
+	for {
+		candidate := time.Unix(from.Unix()+RandomInt64(0, seconds), RandomInt64(0, int64(time.Second)-1))
+		if !candidate.Before(from) && candidate.Before(to) {
+			return candidate.In(from.Location()), nil
+		}
+	}
+}
+
+// Same as RandomTime, but the returned time is in the given location
+func RandomTimeIn(from, to time.Time, location *time.Location) (time.Time, error) {
+	if location == nil {
+		return time.Time{}, fmt.Errorf("error, invalid arguments in RandomTimeIn(location = nil)")
+	}
+	t, err := RandomTime(from, to)
+	return t.In(location), err
+}
+
+// Returns a pseudo random time in the interval [from,to) formatted with the given layout,
+// time.RFC3339 if the layout is empty
+func RandomTimeString(from, to time.Time, layout string) (string, error) {
+	t, err := RandomTime(from, to)
+	if err != nil {
+		return "", err
+	}
+	return FormatTime(t, layout), nil
+}
+
+// Formats the time with the given layout, time.RFC3339 if the layout is empty
+func FormatTime(t time.Time, layout string) string {
+	if layout == "" {
+		layout = time.RFC3339
+	}
+	return t.Format(layout)
+}
+
+// Formats every time with the given layout, time.RFC3339 if the layout is empty
+func FormatTimes(times []time.Time, layout string) []string {
+	formatted := make([]string, 0, len(times))
+	for _, t := range times {
+		formatted = append(formatted, FormatTime(t, layout))
+	}
+	return formatted
+}
+
+// Returns a pseudo random duration in the interval [minValue,maxValue]
+func RandomDuration(minValue, maxValue time.Duration) (time.Duration, error) {
+	if minValue < 0 || maxValue < minValue {
+		return 0, fmt.Errorf("error, invalid arguments in RandomDuration(minValue = %v, maxValue = %v)", minValue, maxValue)
+	}
+	return time.Duration(RandomInt64(int64(minValue), int64(maxValue))), nil
+}
+
+// Returns the date of Easter Sunday of the given year (Gregorian calendar, anonymous algorithm)
+func easterSunday(year int) time.Time {
+	a := year % 19
+	b := year / 100
+	c := year % 100
+	d := (19*a + b - b/4 - (b-(b+8)/25+1)/3 + 15) % 30
+	e := (32 + 2*(b%4) + 2*(c/4) - d - c%4) % 7
+	f := d + e - 7*((a+11*d+22*e)/451) + 114
+	return time.Date(year, time.Month(f/31), f%31+1, 0, 0, 0, 0, time.UTC)
+}
+
+// Returns the given date or the next Monday, as the Ley Emiliani moves most Colombian holidays
+func nextMonday(date time.Time) time.Time {
+	return date.AddDate(0, 0, (8-int(date.Weekday()))%7)
+}
+
+// Returns the Colombian public holidays of the given year, sorted, at midnight UTC
+func ColombianHolidays(year int) []time.Time {
+	date := func(month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+	easter := easterSunday(year)
+	holidays := []time.Time{
+		date(time.January, 1), date(time.May, 1), date(time.July, 20), date(time.August, 7),
+		date(time.December, 8), date(time.December, 25),
+		nextMonday(date(time.January, 6)), nextMonday(date(time.March, 19)), nextMonday(date(time.June, 29)),
+		nextMonday(date(time.August, 15)), nextMonday(date(time.October, 12)), nextMonday(date(time.November, 1)),
+		nextMonday(date(time.November, 11)),
+		easter.AddDate(0, 0, -3), easter.AddDate(0, 0, -2),
+		easter.AddDate(0, 0, 43), easter.AddDate(0, 0, 64), easter.AddDate(0, 0, 71),
+	}
+	sort.Slice(holidays, func(i, j int) bool { return holidays[i].Before(holidays[j]) })
+	return holidays
+}
+
+// Returns true if the date of the given time, in its own location, is a Colombian public holiday
+func IsColombianHoliday(t time.Time) bool {
+	for _, holiday := range ColombianHolidays(t.Year()) {
+		if holiday.Month() == t.Month() && holiday.Day() == t.Day() {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns true if the time falls from Monday to Friday in its own location
+func IsBusinessDay(t time.Time) bool {
+	return t.Weekday() != time.Saturday && t.Weekday() != time.Sunday
+}
+
+// Returns a pseudo random date (midnight in the location of 'from') in the interval [from,to) that is a
+// business day. If excludeColombianHolidays is set, Colombian public holidays are excluded too.
+func RandomBusinessDay(from, to time.Time, excludeColombianHolidays bool) (time.Time, error) {
+	if !from.Before(to) {
+		return time.Time{}, fmt.Errorf("error, invalid arguments in RandomBusinessDay(from = %v, to = %v)", from, to)
+	}
+	first := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, from.Location())
+	if first.Before(from) {
+		first = first.AddDate(0, 0, 1)
+	}
+	candidates := make([]time.Time, 0)
+	for day := first; day.Before(to); day = day.AddDate(0, 0, 1) {
+		if IsBusinessDay(day) && !(excludeColombianHolidays && IsColombianHoliday(day)) {
+			candidates = append(candidates, day)
+		}
+	}
+	if len(candidates) == 0 {
+		return time.Time{}, fmt.Errorf("error, there are no business days between %v and %v", from, to)
+	}
+	return candidates[RandomInt(0, len(candidates)-1)], nil
+}
+
+// Returns a pseudo random number with exponential distribution of the given rate (mean 1/rate)
+func RandomExponential(rate float64) float64 {
+	return defaultGenerator.Exponential(rate)
+}
+
+// Returns 'count' sorted timestamps starting after 'start', the time between two consecutive timestamps
+// follows an exponential distribution, so they are the arrivals of a Poisson process of the given rate
+// of events per second
+func RandomPoissonTimestamps(start time.Time, count int, ratePerSecond float64) ([]time.Time, error) {
+	if count < 1 || ratePerSecond <= 0 {
+		return nil, fmt.Errorf("error, invalid arguments in RandomPoissonTimestamps(count = %d, ratePerSecond = %v)",
+			count, ratePerSecond)
+	}
+	timestamps := make([]time.Time, 0, count)
+	current := start
+	for i := 0; i < count; i++ {
+		current = current.Add(time.Duration(RandomExponential(ratePerSecond) * float64(time.Second)))
+		timestamps = append(timestamps, current)
+	}
+	return timestamps, nil
+}
+
+// Returns 'count' sorted timestamps uniformly distributed in the interval [from,to)
+func RandomSortedTimes(count int, from, to time.Time) ([]time.Time, error) {
+	if count < 1 {
+		return nil, fmt.Errorf("error, invalid arguments in RandomSortedTimes(count = %d)", count)
+	}
+	times := make([]time.Time, 0, count)
+	for i := 0; i < count; i++ {
+		t, err := RandomTime(from, to)
+		if err != nil {
+			return nil, err
+		}
+		times = append(times, t)
+	}
+	sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+	return times, nil
+}
+
+// Returns a random field of a cron expression for values in [minValue,maxValue]: '*', a step like '*/15',
+// a single value, a range like '1-5' or a list like '1,15,30'
+func randomCronField(minValue, maxValue int) string {
+	switch RandomInt(0, 4) {
+	case 0:
+		return "*"
+	case 1:
+		return fmt.Sprintf("*/%d", RandomInt(2, (maxValue-minValue+1)/2))
+	case 2:
+		return fmt.Sprintf("%d", RandomInt(minValue, maxValue))
+	case 3:
+		from := RandomInt(minValue, maxValue-1)
+		return fmt.Sprintf("%d-%d", from, RandomInt(from+1, maxValue))
+	}
+	_, set := RandomIntSet(RandomInt(2, 3), minValue, maxValue)
+	values := make([]int, 0, len(set))
+	for value := range set {
+		values = append(values, value)
+	}
+	sort.Ints(values)
+	parts := make([]string, 0, len(values))
+	for _, value := range values {
+		parts = append(parts, fmt.Sprintf("%d", value))
+	}
+	return strings.Join(parts, ",")
+}
+
+// Returns a pseudo random valid cron expression with the five standard fields:
+// minute, hour, day of month, month and day of week, like "*/15 9-17 * * 1-5"
+func RandomCronSchedule() string {
+	return strings.Join([]string{
+		randomCronField(0, 59), randomCronField(0, 23), randomCronField(1, 28), randomCronField(1, 12),
+		randomCronField(0, 6),
+	}, " ")
+}
@@ -0,0 +1,138 @@
+package randgen
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/niquefa/gominirandgen/stats"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRandomTime(t *testing.T) {
+	bogota := time.FixedZone("COT", -5*3600)
+	for test := 0; test < 1000; test++ {
+		from := time.Date(RandomInt(1, 3000), time.January, 1, 0, 0, 0, 0, time.UTC)
+		to := from.Add(time.Duration(RandomInt64(1, 1<<62)))
+		if RandomInt(0, 1) == 0 {
+			to = from.AddDate(RandomInt(0, 800), 0, RandomInt(1, 100))
+		}
+		value, err := RandomTimeIn(from, to, bogota)
+		assert.Nil(t, err)
+		assert.False(t, value.Before(from))
+		assert.True(t, value.Before(to))
+		assert.Equal(t, bogota, value.Location())
+	}
+	now := time.Now()
+	value, err := RandomTime(now, now.Add(1))
+	assert.Nil(t, err)
+	assert.Equal(t, now, value)
+	_, err = RandomTime(now, now)
+	assert.NotNil(t, err)
+	_, err = RandomTimeIn(now, now.Add(time.Hour), nil)
+	assert.NotNil(t, err)
+}
+
+func TestRandomTimeString(t *testing.T) {
+	from := time.Date(2019, time.June, 1, 0, 0, 0, 0, time.UTC)
+	str, err := RandomTimeString(from, from.AddDate(1, 0, 0), "")
+	assert.Nil(t, err)
+	parsed, err := time.Parse(time.RFC3339, str)
+	assert.Nil(t, err)
+	assert.False(t, parsed.Before(from))
+	assert.True(t, parsed.Before(from.AddDate(1, 0, 0)))
+	str, err = RandomTimeString(from, from.AddDate(0, 0, 1), "2006-01-02")
+	assert.Nil(t, err)
+	assert.Equal(t, "2019-06-01", str)
+	assert.Equal(t, []string{"2019-06-01T00:00:00Z"}, FormatTimes([]time.Time{from}, ""))
+	_, err = RandomTimeString(from, from, "")
+	assert.NotNil(t, err)
+}
+
+func TestColombianHolidays(t *testing.T) {
+	expected2019 := []string{
+		"2019-01-01", "2019-01-07", "2019-03-25", "2019-04-18", "2019-04-19", "2019-05-01", "2019-06-03",
+		"2019-06-24", "2019-07-01", "2019-07-01", "2019-07-20", "2019-08-07", "2019-08-19", "2019-10-14",
+		"2019-11-04", "2019-11-11", "2019-12-08", "2019-12-25",
+	}
+	assert.Equal(t, expected2019, FormatTimes(ColombianHolidays(2019), "2006-01-02"))
+	assert.True(t, IsColombianHoliday(time.Date(2019, time.July, 20, 15, 0, 0, 0, time.UTC)))
+	assert.False(t, IsColombianHoliday(time.Date(2019, time.July, 21, 15, 0, 0, 0, time.UTC)))
+	assert.Equal(t, "2024-03-31", FormatTime(easterSunday(2024), "2006-01-02"))
+}
+
+func TestRandomBusinessDay(t *testing.T) {
+	from := time.Date(2019, time.January, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(1, 0, 0)
+	for test := 0; test < 1000; test++ {
+		day, err := RandomBusinessDay(from, to, true)
+		assert.Nil(t, err)
+		assert.True(t, IsBusinessDay(day))
+		assert.False(t, IsColombianHoliday(day), fmt.Sprintf("%v is a holiday", day))
+		assert.False(t, day.Before(from))
+		assert.True(t, day.Before(to))
+	}
+	saturday := time.Date(2019, time.June, 1, 0, 0, 0, 0, time.UTC)
+	_, err := RandomBusinessDay(saturday, saturday.AddDate(0, 0, 2), false)
+	assert.NotNil(t, err)
+}
+
+func TestRandomDuration(t *testing.T) {
+	for test := 0; test < 1000; test++ {
+		duration, err := RandomDuration(time.Second, time.Hour)
+		assert.Nil(t, err)
+		assert.GreaterOrEqual(t, int64(duration), int64(time.Second))
+		assert.LessOrEqual(t, int64(duration), int64(time.Hour))
+	}
+	_, err := RandomDuration(time.Hour, time.Second)
+	assert.NotNil(t, err)
+}
+
+func TestRandomExponential(t *testing.T) {
+	defer seedDefaultGenerator(qualityTestSeed)()
+	sample := make([]float64, 10000)
+	for i := range sample {
+		sample[i] = RandomExponential(2.5)
+	}
+	result, err := stats.KolmogorovSmirnov(sample, stats.ExponentialCDF(2.5))
+	assert.Nil(t, err)
+	assert.True(t, result.Passed(stats.DefaultAlpha), result.String())
+	assert.True(t, RandomExponential(2.5) >= 0)
+	assert.Panics(t, func() { RandomExponential(0) })
+}
+
+func TestRandomPoissonTimestamps(t *testing.T) {
+	start := time.Date(2019, time.June, 1, 0, 0, 0, 0, time.UTC)
+	timestamps, err := RandomPoissonTimestamps(start, 10000, 2)
+	assert.Nil(t, err)
+	assert.Len(t, timestamps, 10000)
+	for i := 1; i < len(timestamps); i++ {
+		assert.False(t, timestamps[i].Before(timestamps[i-1]))
+	}
+	mean := timestamps[len(timestamps)-1].Sub(start).Seconds() / float64(len(timestamps))
+	assert.InDelta(t, 0.5, mean, 0.05)
+	_, err = RandomPoissonTimestamps(start, 10, 0)
+	assert.NotNil(t, err)
+}
+
+func TestRandomSortedTimes(t *testing.T) {
+	from := time.Date(2019, time.June, 1, 0, 0, 0, 0, time.UTC)
+	times, err := RandomSortedTimes(100, from, from.AddDate(0, 1, 0))
+	assert.Nil(t, err)
+	assert.Len(t, times, 100)
+	for i := 1; i < len(times); i++ {
+		assert.False(t, times[i].Before(times[i-1]))
+	}
+	_, err = RandomSortedTimes(0, from, from.AddDate(0, 1, 0))
+	assert.NotNil(t, err)
+}
+
+func TestRandomCronSchedule(t *testing.T) {
+	field := `(\*|\*/\d+|\d+|\d+-\d+|\d+(,\d+)+)`
+	cron := regexp.MustCompile(`^` + field + ` ` + field + ` ` + field + ` ` + field + ` ` + field + `$`)
+	for test := 0; test < 1000; test++ {
+		schedule := RandomCronSchedule()
+		assert.True(t, cron.MatchString(schedule), schedule)
+	}
+}
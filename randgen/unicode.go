@@ -0,0 +1,320 @@
+package randgen
+
+import (
+	"fmt"
+	"unicode"
+	"unicode/utf8"
+)
+
+// A closed interval [From,To] of Unicode code points
+type UnicodeRange struct {
+	From rune
+	To   rune
+}
+
+// Predefined Unicode ranges to use with RandomUnicodeStringExactLength and friends
+var (
+	// Accented latin letters of Latin-1 Supplement, without × and ÷
+	UnicodeLatin1 = []UnicodeRange{{0x00C0, 0x00D6}, {0x00D8, 0x00F6}, {0x00F8, 0x00FF}}
+	// Upper and lower case greek letters
+	UnicodeGreek = []UnicodeRange{{0x0391, 0x03A1}, {0x03A3, 0x03A9}, {0x03B1, 0x03C9}}
+	// Basic russian cyrillic letters, upper and lower case
+	UnicodeCyrillic = []UnicodeRange{{0x0410, 0x044F}}
+	// CJK Unified Ideographs
+	UnicodeCJK = []UnicodeRange{{0x4E00, 0x9FFF}}
+	// Emoticons and a part of Miscellaneous Symbols and Pictographs, all of them outside the BMP
+	UnicodeEmoji = []UnicodeRange{{0x1F600, 0x1F64F}, {0x1F300, 0x1F3FA}, {0x1F400, 0x1F4FF}}
+	// Combining Diacritical Marks, they modify the previous character
+	UnicodeCombiningMarks = []UnicodeRange{{0x0300, 0x036F}}
+)
+
+// Characters that usually break text handling: direction marks and overrides, zero width characters,
+// byte order marks, code points next to the surrogate block, the replacement character,
+// non characters and the last valid code point
+var nastyRunes = []rune{
+	0x200E, 0x200F, 0x202A, 0x202B, 0x202C, 0x202D, 0x202E, 0x2066, 0x2067, 0x2068, 0x2069,
+	0x200B, 0x200C, 0x200D, 0x2060, 0xFEFF, 0x00AD, 0x034F,
+	0xD7FF, 0xE000, 0xFFFD, 0xFFFE, 0xFFFF, 0x10FFFF,
+	0x0000, 0x0009, 0x000A, 0x000D, 0x0085, 0x2028, 0x2029,
+}
+
+// Bits of text mixed by RandomNastyString, right to left scripts, emoji sequences joined by ZWJ,
+// flags made of regional indicators and stacked combining marks
+var nastyFragments = []string{
+	"שלום", "مرحبا", "‮evil‬", "👩‍👩‍👧‍👦", "🏳️‍🌈", "👍🏽",
+	"🇨🇴", "🇺🇸", "é", "Z͑ͫ̓ͪ̂", "ǅ", "ß", "İ", "ﬁ", "Ω", "Å",
+	"\u0000", "\\", "\"", "'", "<script>", "%s", "${x}", "null", "𝕳𝖊𝖑𝖑𝖔", "١٢٣",
+}
+
+// Limits of the surrogate block, those code points are not valid in UTF-8
+const (
+	surrogateMin = 0xD800
+	surrogateMax = 0xDFFF
+)
+
+// Units to measure the length of a string
+type LengthUnit int
+
+const (
+	LengthRunes LengthUnit = iota
+	LengthBytes
+	LengthGraphemes
+)
+
+// Returns the given ranges without the surrogate block, panic if one of them is not a valid range
+func withoutSurrogates(ranges []UnicodeRange) []UnicodeRange {
+	result := make([]UnicodeRange, 0, len(ranges))
+	for _, r := range ranges {
+		if r.To < r.From || r.From < 0 || r.To > unicode.MaxRune {
+			panic(fmt.Sprintf("Error, invalid Unicode range [%U,%U]", r.From, r.To))
+		}
+		if r.From < surrogateMin && r.To >= surrogateMin {
+			result = append(result, UnicodeRange{r.From, surrogateMin - 1})
+		}
+		if r.From <= surrogateMax && r.To > surrogateMax {
+			result = append(result, UnicodeRange{surrogateMax + 1, r.To})
+		}
+		if r.To < surrogateMin || r.From > surrogateMax {
+			result = append(result, r)
+		}
+	}
+	return result
+}
+
+// Returns a pseudo random code point of the given ranges, every code point has the same probability.
+// Surrogate halves are never returned, panic if the ranges have no valid code point.
+func RandomRuneFromRanges(ranges []UnicodeRange) rune {
+	return defaultGenerator.RuneFromRanges(ranges)
+}
+
+// Returns a pseudo random code point of the given ranges, same as RandomRuneFromRanges
+func (g *Generator) RuneFromRanges(ranges []UnicodeRange) rune {
+	valid := withoutSurrogates(ranges)
+	total := 0
+	for _, r := range valid {
+		total += int(r.To-r.From) + 1
+	}
+	if total == 0 {
+		panic(fmt.Sprintf("Error, the Unicode ranges %v have no valid code points", ranges))
+	}
+	index := g.Int(0, total-1)
+	for _, r := range valid {
+		size := int(r.To-r.From) + 1
+		if index < size {
+			return r.From + rune(index)
+		}
+		index -= size
+	}
+	return valid[len(valid)-1].To
+}
+
+// Returns a pseudo random rune of the given alphabet, the alphabet is read as runes, not as bytes
+func RandomRune(alphabet string) rune {
+	runes := []rune(alphabet)
+	if len(runes) <= 0 {
+		panic("Error, alphabet with no positive length")
+	}
+	return runes[RandomInt(0, len(runes)-1)]
+}
+
+// Same as RandomStringExactLength, but the alphabet is read as runes, so "áéíóúñ" is a six letters
+// alphabet. The resulting string has exactly 'length' runes and is always valid UTF-8.
+func RandomRuneStringExactLength(length int, alphabet string) string {
+	runes := []rune(alphabet)
+	if len(runes) <= 0 {
+		panic("Error, alphabet with no positive length")
+	}
+	result := make([]rune, length)
+	for i := range result {
+		result[i] = runes[RandomInt(0, len(runes)-1)]
+	}
+	return string(result)
+}
+
+// Same as RandomString, but the alphabet is read as runes and the length is measured in runes
+func RandomRuneString(minLength, maxLength int, alphabet string) string {
+	if minLength < 0 || maxLength < 0 || maxLength < minLength {
+		return ""
+	}
+	return RandomRuneStringExactLength(RandomInt(minLength, maxLength), alphabet)
+}
+
+// Returns a pseudo random string of exactly 'length' runes drawn from the given Unicode ranges
+func RandomUnicodeStringExactLength(length int, ranges []UnicodeRange) string {
+	result := make([]rune, length)
+	for i := range result {
+		result[i] = RandomRuneFromRanges(ranges)
+	}
+	return string(result)
+}
+
+// Returns a pseudo random string drawn from the given Unicode ranges, it length will be at least
+// 'minLength' and less or equal to 'maxLength' runes
+func RandomUnicodeString(minLength, maxLength int, ranges []UnicodeRange) string {
+	if minLength < 0 || maxLength < 0 || maxLength < minLength {
+		return ""
+	}
+	return RandomUnicodeStringExactLength(RandomInt(minLength, maxLength), ranges)
+}
+
+// Returns the given ranges clipped to the code points whose UTF-8 encoding fits in 'bytes' bytes
+func rangesFittingBytes(ranges []UnicodeRange, bytes int) []UnicodeRange {
+	limit := rune(unicode.MaxRune)
+	switch {
+	case bytes < 1:
+		return nil
+	case bytes == 1:
+		limit = utf8.RuneSelf - 1
+	case bytes == 2:
+		limit = 0x7FF
+	case bytes == 3:
+		limit = 0xFFFF
+	}
+	result := make([]UnicodeRange, 0, len(ranges))
+	for _, r := range withoutSurrogates(ranges) {
+		if r.From <= limit {
+			if r.To > limit {
+				r.To = limit
+			}
+			result = append(result, r)
+		}
+	}
+	return result
+}
+
+// Returns a pseudo random string drawn from the given Unicode ranges, it length measured in the given
+// unit will be exactly 'length' when possible. When measuring bytes and no rune of the ranges fits in
+// the remaining bytes, the returned string is shorter.
+func RandomUnicodeStringMeasured(length int, unit LengthUnit, ranges []UnicodeRange) string {
+	if length < 0 {
+		panic(fmt.Sprintf("Error, invalid arguments in RandomUnicodeStringMeasured(length = %d)", length))
+	}
+	result := make([]rune, 0, length)
+	current := 0
+	for attempt := 0; current < length && attempt < 16*length+16; attempt++ {
+		candidates := ranges
+		if unit == LengthBytes {
+			candidates = rangesFittingBytes(ranges, length-current)
+			if len(candidates) == 0 {
+				break
+			}
+		}
+		candidate := RandomRuneFromRanges(candidates)
+		next := StringLength(string(append(result, candidate)), unit)
+		if next <= length {
+			result = append(result, candidate)
+			current = next
+		}
+	}
+	return string(result)
+}
+
+// Returns a pseudo random string of exactly 'length' grapheme clusters, each one is a base character
+// drawn from the given ranges followed by up to 'maxMarks' combining diacritical marks
+func RandomCombiningString(length int, base []UnicodeRange, maxMarks int) string {
+	if length < 0 || maxMarks < 0 {
+		panic(fmt.Sprintf("Error, invalid arguments in RandomCombiningString(length = %d, maxMarks = %d)",
+			length, maxMarks))
+	}
+	result := make([]rune, 0, length*(maxMarks+1))
+	for i := 0; i < length; i++ {
+		result = append(result, RandomRuneFromRanges(base))
+		for marks := RandomInt(0, maxMarks); marks > 0; marks-- {
+			result = append(result, RandomRuneFromRanges(UnicodeCombiningMarks))
+		}
+	}
+	return string(result)
+}
+
+// Returns a valid UTF-8 string of at least 'length' runes meant to stress-test text handling. It mixes
+// plain latin letters, right to left marks and scripts, zero width joiners, code points adjacent to the
+// surrogate block, emoji sequences and stacked combining marks.
+func RandomNastyString(length int) string {
+	if length < 0 {
+		panic(fmt.Sprintf("Error, invalid arguments in RandomNastyString(length = %d)", length))
+	}
+	result := make([]rune, 0, length)
+	for len(result) < length {
+		switch RandomInt(0, 3) {
+		case 0:
+			result = append(result, nastyRunes[RandomInt(0, len(nastyRunes)-1)])
+		case 1:
+			result = append(result, []rune(nastyFragments[RandomInt(0, len(nastyFragments)-1)])...)
+		case 2:
+			result = append(result, RandomRuneFromRanges(UnicodeLatin1))
+		default:
+			result = append(result, rune(RandomAlphaDigitByte()))
+		}
+	}
+	return string(result)
+}
+
+// Returns a slice of 'size' nasty strings, see RandomNastyString
+func RandomNastyStringSlice(size, minLength, maxLength int) ([]string, error) {
+	if size < 1 || minLength < 0 || maxLength < 0 || maxLength < minLength {
+		return nil, fmt.Errorf("error, invalid arguments in RandomNastyStringSlice(size = %v, minLength = %v, maxLength = %v)",
+			size, minLength, maxLength)
+	}
+	slice := make([]string, 0, size)
+	for i := 0; i < size; i++ {
+		slice = append(slice, RandomNastyString(RandomInt(minLength, maxLength)))
+	}
+	return slice, nil
+}
+
+// Returns the length of the string in the given unit
+func StringLength(str string, unit LengthUnit) int {
+	switch unit {
+	case LengthBytes:
+		return len(str)
+	case LengthGraphemes:
+		return GraphemeCount(str)
+	default:
+		return utf8.RuneCountInString(str)
+	}
+}
+
+// Returns true if the rune never starts a grapheme cluster, it extends the previous one
+func isGraphemeExtender(r rune) bool {
+	return unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc) || r == 0x200D ||
+		(r >= 0xFE00 && r <= 0xFE0F) || (r >= 0x1F3FB && r <= 0x1F3FF) || (r >= 0xE0020 && r <= 0xE007F)
+}
+
+// Returns true if the rune is a regional indicator, two of them form a flag
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1F1E6 && r <= 0x1F1FF
+}
+
+// Returns the number of grapheme clusters (user perceived characters) of the string. It is a
+// simplification of the rules of UAX #29: combining marks, variation selectors, emoji modifiers and
+// tags extend the previous cluster, a zero width joiner glues the next character, pairs of regional
+// indicators form one flag and CR LF is a single cluster.
+func GraphemeCount(str string) int {
+	count := 0
+	previous := rune(-1)
+	pendingRegional := false
+	for _, r := range str {
+		startsCluster := true
+		switch {
+		case previous < 0:
+		case isGraphemeExtender(r):
+			startsCluster = false
+		case previous == 0x200D:
+			startsCluster = false
+		case previous == '\r' && r == '\n':
+			startsCluster = false
+		case isRegionalIndicator(r) && pendingRegional:
+			startsCluster = false
+		}
+		if isRegionalIndicator(r) {
+			pendingRegional = startsCluster
+		} else if !isGraphemeExtender(r) {
+			pendingRegional = false
+		}
+		if startsCluster {
+			count++
+		}
+		previous = r
+	}
+	return count
+}
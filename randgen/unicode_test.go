@@ -0,0 +1,113 @@
+package randgen
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRandomRuneString(t *testing.T) {
+	alphabet := "áéíóúñ"
+	for test := 0; test < 1000; test++ {
+		minLen := RandomInt(0, 20)
+		maxLen := minLen + RandomInt(0, 3)
+		str := RandomRuneString(minLen, maxLen, alphabet)
+		assert.True(t, utf8.ValidString(str))
+		assert.GreaterOrEqual(t, utf8.RuneCountInString(str), minLen)
+		assert.LessOrEqual(t, utf8.RuneCountInString(str), maxLen)
+		for _, r := range str {
+			assert.True(t, strings.ContainsRune(alphabet, r), fmt.Sprintf("%q must be in %v", r, alphabet))
+		}
+	}
+}
+
+func TestRandomUnicodeString(t *testing.T) {
+	allRanges := [][]UnicodeRange{UnicodeLatin1, UnicodeGreek, UnicodeCyrillic, UnicodeCJK, UnicodeEmoji}
+	for _, ranges := range allRanges {
+		for test := 0; test < 100; test++ {
+			length := RandomInt(0, 30)
+			str := RandomUnicodeStringExactLength(length, ranges)
+			assert.True(t, utf8.ValidString(str))
+			assert.Equal(t, length, utf8.RuneCountInString(str))
+			assert.Equal(t, length, GraphemeCount(str))
+			for _, r := range str {
+				inRange := false
+				for _, rng := range ranges {
+					inRange = inRange || (rng.From <= r && r <= rng.To)
+				}
+				assert.True(t, inRange, fmt.Sprintf("%U must be in %v", r, ranges))
+			}
+		}
+	}
+}
+
+func TestRandomRuneFromRangesSkipsSurrogates(t *testing.T) {
+	for test := 0; test < 1000; test++ {
+		r := RandomRuneFromRanges([]UnicodeRange{{0xD7FE, 0xE001}})
+		assert.True(t, utf8.ValidRune(r))
+	}
+}
+
+func TestRandomUnicodeStringMeasured(t *testing.T) {
+	units := []LengthUnit{LengthRunes, LengthBytes, LengthGraphemes}
+	for _, unit := range units {
+		for test := 0; test < 100; test++ {
+			length := RandomInt(0, 40)
+			str := RandomUnicodeStringMeasured(length, unit, append([]UnicodeRange{{0x61, 0x7A}}, UnicodeEmoji...))
+			assert.True(t, utf8.ValidString(str))
+			assert.Equal(t, length, StringLength(str, unit))
+		}
+	}
+}
+
+func TestRandomCombiningString(t *testing.T) {
+	for test := 0; test < 100; test++ {
+		length := RandomInt(0, 20)
+		str := RandomCombiningString(length, UnicodeGreek, 3)
+		assert.True(t, utf8.ValidString(str))
+		assert.Equal(t, length, GraphemeCount(str))
+		assert.GreaterOrEqual(t, utf8.RuneCountInString(str), length)
+	}
+}
+
+func TestRandomNastyString(t *testing.T) {
+	for test := 0; test < 1000; test++ {
+		length := RandomInt(0, 50)
+		str := RandomNastyString(length)
+		assert.True(t, utf8.ValidString(str))
+		assert.GreaterOrEqual(t, utf8.RuneCountInString(str), length)
+	}
+	slice, err := RandomNastyStringSlice(10, 1, 5)
+	assert.Nil(t, err)
+	assert.Len(t, slice, 10)
+}
+
+func TestGraphemeCount(t *testing.T) {
+	cases := map[string]int{
+		"":        0,
+		"abc":     3,
+		"e\u0301": 1,
+		"\U0001F469\u200D\U0001F469\u200D\U0001F467": 1,
+		"\U0001F44D\U0001F3FDx":                      2,
+		"\U0001F1E8\U0001F1F4\U0001F1FA\U0001F1F8":   2,
+		"\r\n":                 1,
+		"Z\u0351\u036B\u0343a": 2,
+		"\u200Fab":             3,
+	}
+	for str, expected := range cases {
+		assert.Equal(t, expected, GraphemeCount(str), fmt.Sprintf("GraphemeCount(%q)", str))
+	}
+}
+
+func TestGeneratorRuneFromRanges(t *testing.T) {
+	first, second := NewGenerator(9), NewGenerator(9)
+	ranges := []UnicodeRange{{0x41, 0x5A}, {0xD7FF, 0xE000}}
+	for test := 0; test < 100; test++ {
+		r := first.RuneFromRanges(ranges)
+		assert.Equal(t, r, second.RuneFromRanges(ranges))
+		assert.True(t, (r >= 0x41 && r <= 0x5A) || r == 0xD7FF || r == 0xE000)
+	}
+}
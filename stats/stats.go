@@ -0,0 +1,290 @@
+// Package stats has statistical tests to check the quality of pseudo random generators: chi-square
+// goodness of fit, Kolmogorov-Smirnov, runs, serial correlation and birthday spacings. Every test
+// returns a Result with its p-value, the probability of a statistic at least as extreme if the values
+// were truly random, so a test fails when the p-value is below the chosen false positive rate (alpha).
+package stats
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// A false positive rate small enough to use in go test, one run in a million of a good generator fails
+const DefaultAlpha = 1e-6
+
+// The outcome of a statistical test
+type Result struct {
+	Name      string
+	Statistic float64
+	PValue    float64
+}
+
+// Returns true if the p-value is at least alpha, the false positive rate
+func (r Result) Passed(alpha float64) bool {
+	return r.PValue >= alpha
+}
+
+func (r Result) String() string {
+	return fmt.Sprintf("%s: statistic = %.6g, p-value = %.6g", r.Name, r.Statistic, r.PValue)
+}
+
+// Returns the regularized lower incomplete gamma function P(a,x)
+func lowerGamma(a, x float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	logPrefix := a*math.Log(x) - x
+	lgamma, _ := math.Lgamma(a)
+	if x < a+1 {
+		// series expansion
+		term := 1 / a
+		sum := term
+		for n := 1; n < 1000; n++ {
+			term *= x / (a + float64(n))
+			sum += term
+			if math.Abs(term) < math.Abs(sum)*1e-15 {
+				break
+			}
+		}
+		return sum * math.Exp(logPrefix-lgamma)
+	}
+	// continued fraction of the upper function (modified Lentz)
+	tiny := 1e-300
+	b := x + 1 - a
+	c := 1 / tiny
+	d := 1 / b
+	h := d
+	for n := 1; n < 1000; n++ {
+		an := -float64(n) * (float64(n) - a)
+		b += 2
+		d = an*d + b
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = b + an/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		delta := d * c
+		h *= delta
+		if math.Abs(delta-1) < 1e-15 {
+			break
+		}
+	}
+	return 1 - math.Exp(logPrefix-lgamma)*h
+}
+
+// Returns the probability that a chi-square variable with the given degrees of freedom exceeds x
+func ChiSquareSurvival(x float64, degreesOfFreedom int) float64 {
+	return 1 - lowerGamma(float64(degreesOfFreedom)/2, x/2)
+}
+
+// Returns the cumulative distribution function of the normal distribution
+func NormalCDF(mean, standardDeviation float64) func(float64) float64 {
+	return func(x float64) float64 {
+		return 0.5 * math.Erfc(-(x-mean)/(standardDeviation*math.Sqrt2))
+	}
+}
+
+// Returns the cumulative distribution function of the uniform distribution in [minValue,maxValue)
+func UniformCDF(minValue, maxValue float64) func(float64) float64 {
+	return func(x float64) float64 {
+		return math.Max(0, math.Min(1, (x-minValue)/(maxValue-minValue)))
+	}
+}
+
+// Returns the cumulative distribution function of the exponential distribution with the given rate
+func ExponentialCDF(rate float64) func(float64) float64 {
+	return func(x float64) float64 {
+		if x < 0 {
+			return 0
+		}
+		return 1 - math.Exp(-rate*x)
+	}
+}
+
+// Returns the two sided p-value of a standard normal statistic
+func normalTwoSided(z float64) float64 {
+	return math.Erfc(math.Abs(z) / math.Sqrt2)
+}
+
+// Chi-square goodness of fit of the observed counts against the expected counts
+func ChiSquare(observed []int, expected []float64) (Result, error) {
+	if len(observed) < 2 || len(observed) != len(expected) {
+		return Result{}, fmt.Errorf("error, invalid arguments in ChiSquare(len(observed) = %d, len(expected) = %d)",
+			len(observed), len(expected))
+	}
+	statistic := 0.0
+	for i, count := range observed {
+		if expected[i] <= 0 {
+			return Result{}, fmt.Errorf("error, the expected count of category %d is not positive", i)
+		}
+		difference := float64(count) - expected[i]
+		statistic += difference * difference / expected[i]
+	}
+	return Result{"chi-square", statistic, ChiSquareSurvival(statistic, len(observed)-1)}, nil
+}
+
+// Chi-square goodness of fit of the observed counts against the uniform distribution
+func ChiSquareUniform(observed []int) (Result, error) {
+	total := 0
+	for _, count := range observed {
+		total += count
+	}
+	expected := make([]float64, len(observed))
+	for i := range expected {
+		expected[i] = float64(total) / float64(len(observed))
+	}
+	return ChiSquare(observed, expected)
+}
+
+// Returns the probability that the Kolmogorov distribution exceeds lambda
+func kolmogorovSurvival(lambda float64) float64 {
+	if lambda < 0.2 {
+		return 1
+	}
+	sum := 0.0
+	sign := 1.0
+	for j := 1; j <= 100; j++ {
+		term := sign * math.Exp(-2*float64(j*j)*lambda*lambda)
+		sum += term
+		if math.Abs(term) < 1e-16 {
+			break
+		}
+		sign = -sign
+	}
+	return math.Max(0, math.Min(1, 2*sum))
+}
+
+// Kolmogorov-Smirnov test of the sample against the continuous cumulative distribution function
+func KolmogorovSmirnov(sample []float64, cdf func(float64) float64) (Result, error) {
+	if len(sample) < 1 || cdf == nil {
+		return Result{}, fmt.Errorf("error, invalid arguments in KolmogorovSmirnov(len(sample) = %d)", len(sample))
+	}
+	sorted := append([]float64(nil), sample...)
+	sort.Float64s(sorted)
+	n := float64(len(sorted))
+	distance := 0.0
+	for i, x := range sorted {
+		value := cdf(x)
+		distance = math.Max(distance, math.Max(float64(i+1)/n-value, value-float64(i)/n))
+	}
+	lambda := (math.Sqrt(n) + 0.12 + 0.11/math.Sqrt(n)) * distance
+	return Result{"kolmogorov-smirnov", distance, kolmogorovSurvival(lambda)}, nil
+}
+
+// Wald-Wolfowitz runs test: counts the runs of values above and below the median, too few runs mean the
+// values are clustered and too many mean they alternate
+func Runs(sample []float64) (Result, error) {
+	sorted := append([]float64(nil), sample...)
+	sort.Float64s(sorted)
+	if len(sorted) < 20 {
+		return Result{}, fmt.Errorf("error, the runs test needs at least 20 values, got %d", len(sorted))
+	}
+	median := sorted[len(sorted)/2]
+	if len(sorted)%2 == 0 {
+		median = (sorted[len(sorted)/2-1] + median) / 2
+	}
+	above, below, runs := 0.0, 0.0, 0.0
+	previous := 0
+	for _, x := range sample {
+		side := 0
+		switch {
+		case x > median:
+			side = 1
+			above++
+		case x < median:
+			side = -1
+			below++
+		default:
+			continue
+		}
+		if side != previous {
+			runs++
+			previous = side
+		}
+	}
+	n := above + below
+	if above == 0 || below == 0 {
+		return Result{"runs", runs, 0}, nil
+	}
+	mean := 2*above*below/n + 1
+	variance := 2 * above * below * (2*above*below - n) / (n * n * (n - 1))
+	z := (runs - mean) / math.Sqrt(variance)
+	return Result{"runs", z, normalTwoSided(z)}, nil
+}
+
+// Serial correlation test: the correlation between each value and the value 'lag' positions later,
+// which is close to zero for independent values
+func SerialCorrelation(sample []float64, lag int) (Result, error) {
+	if lag < 1 || len(sample) < lag+20 {
+		return Result{}, fmt.Errorf("error, invalid arguments in SerialCorrelation(len(sample) = %d, lag = %d)",
+			len(sample), lag)
+	}
+	mean := 0.0
+	for _, x := range sample {
+		mean += x
+	}
+	mean /= float64(len(sample))
+	numerator, denominator := 0.0, 0.0
+	for i, x := range sample {
+		denominator += (x - mean) * (x - mean)
+		if i+lag < len(sample) {
+			numerator += (x - mean) * (sample[i+lag] - mean)
+		}
+	}
+	if denominator == 0 {
+		return Result{"serial correlation", 1, 0}, nil
+	}
+	n := float64(len(sample))
+	correlation := numerator / denominator
+	z := (correlation + 1/n) * math.Sqrt(n)
+	return Result{"serial correlation", correlation, normalTwoSided(z)}, nil
+}
+
+// Returns the cumulative distribution function of the Poisson distribution at k
+func poissonCDF(lambda float64, k int) float64 {
+	term := math.Exp(-lambda)
+	sum := term
+	for i := 1; i <= k; i++ {
+		term *= lambda / float64(i)
+		sum += term
+	}
+	return math.Min(1, sum)
+}
+
+// Marsaglia's birthday spacings test: the birthdays are values in [0,days), the number of repeated
+// spacings between the sorted birthdays follows a Poisson distribution of mean n^3/(4 days). For a good
+// approximation choose n and days so that mean is small, like 512 birthdays in 2^24 days.
+func BirthdaySpacings(birthdays []int64, days int64) (Result, error) {
+	if len(birthdays) < 2 || days < 2 {
+		return Result{}, fmt.Errorf("error, invalid arguments in BirthdaySpacings(len(birthdays) = %d, days = %d)",
+			len(birthdays), days)
+	}
+	sorted := append([]int64(nil), birthdays...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	if sorted[0] < 0 || sorted[len(sorted)-1] >= days {
+		return Result{}, fmt.Errorf("error, the birthdays must be in the interval [0,%d)", days)
+	}
+	spacings := make([]int64, 0, len(sorted)-1)
+	for i := 1; i < len(sorted); i++ {
+		spacings = append(spacings, sorted[i]-sorted[i-1])
+	}
+	sort.Slice(spacings, func(i, j int) bool { return spacings[i] < spacings[j] })
+	repeated := 0
+	for i := 1; i < len(spacings); i++ {
+		if spacings[i] == spacings[i-1] {
+			repeated++
+		}
+	}
+	n := float64(len(birthdays))
+	lambda := n * n * n / (4 * float64(days))
+	lower := poissonCDF(lambda, repeated)
+	upper := 1.0
+	if repeated > 0 {
+		upper = 1 - poissonCDF(lambda, repeated-1)
+	}
+	return Result{"birthday spacings", float64(repeated), math.Min(1, 2*math.Min(lower, upper))}, nil
+}
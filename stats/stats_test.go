@@ -0,0 +1,114 @@
+package stats
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testSeed = 20190601
+
+func uniformSample(size int) []float64 {
+	source := rand.New(rand.NewSource(testSeed))
+	sample := make([]float64, size)
+	for i := range sample {
+		sample[i] = source.Float64()
+	}
+	return sample
+}
+
+func TestChiSquareSurvival(t *testing.T) {
+	assert.InDelta(t, 0.05, ChiSquareSurvival(3.841459, 1), 1e-6)
+	assert.InDelta(t, 0.05, ChiSquareSurvival(18.307038, 10), 1e-6)
+	assert.InDelta(t, 0.01, ChiSquareSurvival(135.806723, 100), 1e-6)
+	assert.Equal(t, 1.0, ChiSquareSurvival(0, 5))
+}
+
+func TestChiSquare(t *testing.T) {
+	result, err := ChiSquareUniform([]int{100, 100, 100, 100})
+	assert.Nil(t, err)
+	assert.Equal(t, 0.0, result.Statistic)
+	assert.True(t, result.Passed(DefaultAlpha))
+	result, err = ChiSquareUniform([]int{400, 100, 100, 100})
+	assert.Nil(t, err)
+	assert.False(t, result.Passed(DefaultAlpha), result.String())
+	_, err = ChiSquare([]int{1, 2}, []float64{1})
+	assert.NotNil(t, err)
+	_, err = ChiSquare([]int{1, 2}, []float64{1, 0})
+	assert.NotNil(t, err)
+}
+
+func TestKolmogorovSmirnov(t *testing.T) {
+	result, err := KolmogorovSmirnov(uniformSample(10000), UniformCDF(0, 1))
+	assert.Nil(t, err)
+	assert.True(t, result.Passed(DefaultAlpha), result.String())
+	squared := uniformSample(10000)
+	for i := range squared {
+		squared[i] *= squared[i]
+	}
+	result, err = KolmogorovSmirnov(squared, UniformCDF(0, 1))
+	assert.Nil(t, err)
+	assert.False(t, result.Passed(DefaultAlpha), result.String())
+	source := rand.New(rand.NewSource(testSeed))
+	normal := make([]float64, 10000)
+	exponential := make([]float64, 10000)
+	for i := range normal {
+		normal[i] = 3 + 2*source.NormFloat64()
+		exponential[i] = source.ExpFloat64() / 4
+	}
+	result, _ = KolmogorovSmirnov(normal, NormalCDF(3, 2))
+	assert.True(t, result.Passed(DefaultAlpha), result.String())
+	result, _ = KolmogorovSmirnov(exponential, ExponentialCDF(4))
+	assert.True(t, result.Passed(DefaultAlpha), result.String())
+	_, err = KolmogorovSmirnov(nil, UniformCDF(0, 1))
+	assert.NotNil(t, err)
+}
+
+func TestRuns(t *testing.T) {
+	result, err := Runs(uniformSample(10000))
+	assert.Nil(t, err)
+	assert.True(t, result.Passed(DefaultAlpha), result.String())
+	alternating := make([]float64, 1000)
+	for i := range alternating {
+		alternating[i] = float64(i % 2)
+	}
+	result, _ = Runs(alternating)
+	assert.False(t, result.Passed(DefaultAlpha), result.String())
+	_, err = Runs([]float64{1, 2, 3})
+	assert.NotNil(t, err)
+}
+
+func TestSerialCorrelation(t *testing.T) {
+	result, err := SerialCorrelation(uniformSample(10000), 1)
+	assert.Nil(t, err)
+	assert.True(t, result.Passed(DefaultAlpha), result.String())
+	walk := make([]float64, 10000)
+	for i := 1; i < len(walk); i++ {
+		walk[i] = walk[i-1] + math.Sin(float64(i))
+	}
+	result, _ = SerialCorrelation(walk, 1)
+	assert.False(t, result.Passed(DefaultAlpha), result.String())
+	_, err = SerialCorrelation(walk, 0)
+	assert.NotNil(t, err)
+}
+
+func TestBirthdaySpacings(t *testing.T) {
+	source := rand.New(rand.NewSource(testSeed))
+	days := int64(1 << 24)
+	birthdays := make([]int64, 512)
+	for i := range birthdays {
+		birthdays[i] = source.Int63n(days)
+	}
+	result, err := BirthdaySpacings(birthdays, days)
+	assert.Nil(t, err)
+	assert.True(t, result.Passed(DefaultAlpha), result.String())
+	for i := range birthdays {
+		birthdays[i] = int64(i) * 32749
+	}
+	result, _ = BirthdaySpacings(birthdays, days)
+	assert.False(t, result.Passed(DefaultAlpha), result.String())
+	_, err = BirthdaySpacings(birthdays, 10)
+	assert.NotNil(t, err)
+}